@@ -0,0 +1,98 @@
+package go_logger
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync/atomic"
+)
+
+// delivery stats of an output
+type DeliveryStats struct {
+	// delivered on the first write
+	Delivered int64
+	// delivered after retry
+	Retried int64
+	// failed after all retries
+	Dropped int64
+	// error of the flush or its acknowledgement, nil if flushed
+	FlushError error
+}
+
+// delivery stats of outputs by adapter name
+type DeliveryReport map[string]DeliveryStats
+
+//adapter is attached, has no dropped message and is flushed
+func (report DeliveryReport) Confirmed(adapterName string) bool {
+	stats, ok := report[adapterName]
+	return ok && stats.Dropped == 0 && stats.FlushError == nil
+}
+
+//set write retries of an attached output, a message is dropped after all retries failed
+//params : adapterName string, retries int
+//return : error
+func (logger *Logger) SetWriteRetries(adapterName string, retries int) error {
+	if retries < 0 {
+		return errors.New("logger: write retries cannot be negative!")
+	}
+	logger.lock.Lock()
+	defer logger.lock.Unlock()
+
+	return logger.updateOutput(adapterName, func(output *outputLogger) error {
+		output.retries = retries
+		return nil
+	})
+}
+
+//flush and report delivery stats of every output since the last report
+//e.g. report.Confirmed("audit") ensures all messages are written to the audit output
+//return : DeliveryReport
+func (logger *Logger) FlushReport() DeliveryReport {
+	adapterErrors, _ := logger.Flush().(AdapterErrors)
+
+	logger.lock.Lock()
+	defer logger.lock.Unlock()
+
+	outputs := logger.outputList()
+	report := make(DeliveryReport, len(outputs))
+	for _, output := range outputs {
+		report[output.Name] = DeliveryStats{
+			Delivered:  atomic.SwapInt64(&output.delivered, 0),
+			Retried:    atomic.SwapInt64(&output.retried, 0),
+			Dropped:    atomic.SwapInt64(&output.dropped, 0),
+			FlushError: adapterErrors[output.Name],
+		}
+	}
+	return report
+}
+
+//write message to output with retries and track the delivery
+func (output *outputLogger) deliver(loggerMsg *loggerMessage) {
+	write := output.Write
+	if output.intern != nil {
+		internMsg, internId, repeat := output.intern.intern(loggerMsg)
+		loggerMsg = internMsg
+		if repeat {
+			write = func(loggerMsg *loggerMessage) error {
+				return output.LoggerAbstract.(RepeatWriter).WriteRepeat(loggerMsg, internId)
+			}
+		}
+	}
+	write = guardWriter(output.Name, write)
+
+	var err error
+	for i := 0; i <= output.retries; i++ {
+		err = write(loggerMsg)
+		if err == nil {
+			if i == 0 {
+				atomic.AddInt64(&output.delivered, 1)
+			} else {
+				atomic.AddInt64(&output.retried, 1)
+			}
+			return
+		}
+		atomic.AddInt64(&output.errors, 1)
+	}
+	atomic.AddInt64(&output.dropped, 1)
+	fmt.Fprintf(os.Stderr, "logger: unable write loggerMessage to adapter:%v, error: %v\n", output.Name, err)
+}
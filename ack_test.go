@@ -0,0 +1,53 @@
+package go_logger
+
+import (
+	"testing"
+)
+
+func TestLogger_FlushReport(t *testing.T) {
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("chaos", LoggerLevelDebug, &ChaosConfig{
+		Adapter:   CONSOLE_ADAPTER_NAME,
+		Config:    &ConsoleConfig{},
+		ErrorRate: 1,
+	})
+	logger.SetWriteRetries("chaos", 2)
+
+	logger.Info("logger flush report test")
+	report := logger.FlushReport()
+	if report.Confirmed("chaos") || report["chaos"].Dropped != 1 {
+		t.Error("logger flush report dropped error")
+	}
+	if logger.outputList()[0].errors != 3 {
+		t.Error("logger write retries error")
+	}
+
+	logger.outputList()[0].LoggerAbstract.(*AdapterChaos).config.ErrorRate = 0
+	logger.Info("logger flush report test")
+	report = logger.FlushReport()
+	if !report.Confirmed("chaos") || report["chaos"].Delivered != 1 {
+		t.Error("logger flush report delivered error")
+	}
+	if report.Confirmed("file") {
+		t.Error("logger flush report not attached adapter must not be confirmed")
+	}
+}
+
+func TestLogger_FlushReportFlushError(t *testing.T) {
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("count", LoggerLevelDebug, &countConfig{})
+	logger.Attach("flush_error", LoggerLevelDebug, &countConfig{})
+
+	logger.Info("logger flush report test")
+	report := logger.FlushReport()
+	if report.Confirmed("flush_error") || report["flush_error"].Delivered != 1 || report["flush_error"].FlushError == nil {
+		t.Errorf("logger flush report of a failed flush must not be confirmed: %+v", report["flush_error"])
+	}
+	if !report.Confirmed("count") || report["count"].FlushError != nil {
+		t.Errorf("logger flush report of a flushed output must be confirmed: %+v", report["count"])
+	}
+}
@@ -0,0 +1,45 @@
+package go_logger
+
+import "sort"
+
+// adapter provides an empty config to describe its config fields
+type ConfigFactory interface {
+	NewConfig() Config
+}
+
+// registered adapter description
+type AdapterInfo struct {
+	Name string `json:"name"`
+
+	// config fields, empty if the adapter is not a ConfigFactory
+	Fields []ConfigField `json:"fields"`
+}
+
+// adapter config field description
+type ConfigField struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+
+	// tagged by `config:"required"`
+	Required bool `json:"required"`
+}
+
+//enumerate registered adapters and their config fields, sorted by name
+//return : []AdapterInfo
+func Adapters() []AdapterInfo {
+	names := make([]string, 0, len(adapters))
+	for name := range adapters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	infos := make([]AdapterInfo, 0, len(names))
+	for _, name := range names {
+		info := AdapterInfo{Name: name, Fields: []ConfigField{}}
+		if factory, ok := adapters[name]().(ConfigFactory); ok {
+			info.Fields = configFields(factory.NewConfig())
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
@@ -0,0 +1,53 @@
+//go:build !tinygo && !logger_tiny
+// +build !tinygo,!logger_tiny
+
+package go_logger
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestAdapters(t *testing.T) {
+
+	infos := Adapters()
+	for i := 1; i < len(infos); i++ {
+		if infos[i-1].Name >= infos[i].Name {
+			t.Errorf("adapters are not sorted: %s, %s", infos[i-1].Name, infos[i].Name)
+		}
+	}
+
+	var api *AdapterInfo
+	for i := range infos {
+		if infos[i].Name == API_ADAPTER_NAME {
+			api = &infos[i]
+		}
+	}
+	if api == nil {
+		t.Fatal("adapter api is not listed")
+	}
+	required := map[string]bool{}
+	types := map[string]string{}
+	for _, field := range api.Fields {
+		required[field.Name] = field.Required
+		types[field.Name] = field.Type
+	}
+	if !required["Url"] || !required["Method"] || required["Headers"] {
+		t.Errorf("adapter api required fields error: %+v", api.Fields)
+	}
+	if types["Headers"] != "map[string]string" || types["VerifyCode"] != "int" {
+		t.Errorf("adapter api field types error: %+v", api.Fields)
+	}
+	if _, err := json.Marshal(infos); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func TestAdapters_NoConfigFactory(t *testing.T) {
+
+	for _, info := range Adapters() {
+		if info.Name == "count" && len(info.Fields) != 0 {
+			t.Errorf("adapter without ConfigFactory has fields: %+v", info.Fields)
+		}
+	}
+}
@@ -0,0 +1,256 @@
+package go_logger
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const AGGREGATE_ADAPTER_NAME = "aggregate"
+
+// adapter aggregate, count messages over fixed windows and write one summary record to another adapter
+type AdapterAggregate struct {
+	lock        sync.Mutex
+	adapter     LoggerAbstract
+	config      *AggregateConfig
+	windowStart time.Time
+	counts      map[int]int64
+	durations   []float64
+	exemplars   *exemplarSet
+	timer       *time.Timer
+}
+
+// aggregate config
+type AggregateConfig struct {
+
+	// downstream adapter name, receives the summary records
+	// console, file, api ...
+	Adapter string `config:"required"`
+
+	// downstream adapter config
+	Config Config `config:"required"`
+
+	// window length, default 1 minute
+	Window time.Duration
+
+	// numeric or time.Duration field summarized as <field>_avg, <field>_p50, <field>_p99 and <field>_max
+	// time.Duration values are in milliseconds, empty is not summarized
+	DurationField string
+
+	// example records kept per window (first, random and last), in the "exemplars" field
+	// 0 keeps none
+	Exemplars int
+}
+
+func (ac *AggregateConfig) Name() string {
+	return AGGREGATE_ADAPTER_NAME
+}
+
+func NewAdapterAggregate() LoggerAbstract {
+	return &AdapterAggregate{
+		config: &AggregateConfig{},
+		counts: map[int]int64{},
+	}
+}
+
+func (adapterAggregate *AdapterAggregate) Init(aggregateConfig Config) error {
+	if aggregateConfig.Name() != AGGREGATE_ADAPTER_NAME {
+		return errors.New("logger aggregate adapter init error, config must AggregateConfig")
+	}
+
+	ac := aggregateConfig.(*AggregateConfig)
+	adapterAggregate.config = ac
+
+	if ac.Adapter == "" || ac.Adapter == AGGREGATE_ADAPTER_NAME {
+		return errors.New("config Adapter must be another adapter name!")
+	}
+	if ac.Config == nil {
+		return errors.New("config Config cannot be nil!")
+	}
+	if ac.Exemplars < 0 {
+		return errors.New("config Exemplars cannot be negative!")
+	}
+	if ac.Window < 0 {
+		return errors.New("config Window cannot be negative!")
+	}
+	if ac.Window == 0 {
+		ac.Window = time.Minute
+	}
+
+	logFun, ok := adapters[ac.Adapter]
+	if !ok {
+		return errors.New("config Adapter " + ac.Adapter + " is not registered!")
+	}
+	adapter := logFun()
+	err := adapter.Init(ac.Config)
+	if err != nil {
+		return err
+	}
+	adapterAggregate.adapter = adapter
+	return nil
+}
+
+func (adapterAggregate *AdapterAggregate) Write(loggerMsg *loggerMessage) error {
+	adapterAggregate.lock.Lock()
+	defer adapterAggregate.lock.Unlock()
+
+	// the first message of a window arms the summary timer, idle windows write nothing
+	if adapterAggregate.timer == nil {
+		adapterAggregate.windowStart = time.Now()
+		adapterAggregate.timer = time.AfterFunc(adapterAggregate.config.Window, func() {
+			adapterAggregate.summarize()
+		})
+		if adapterAggregate.config.Exemplars > 0 {
+			adapterAggregate.exemplars = newExemplarSet(adapterAggregate.config.Exemplars)
+		}
+	}
+	adapterAggregate.counts[loggerMsg.Level]++
+	if field := adapterAggregate.config.DurationField; field != "" {
+		if duration, ok := aggregateDuration(loggerMsg.Fields[field]); ok {
+			adapterAggregate.durations = append(adapterAggregate.durations, duration)
+		}
+	}
+	if adapterAggregate.exemplars != nil {
+		// the record is shared by the outputs, a copy is kept
+		exemplarMsg := *loggerMsg
+		adapterAggregate.exemplars.add(&exemplarMsg)
+	}
+	return nil
+}
+
+//write the summary of the current window, nothing if the window is empty
+//return : downstream write error
+func (adapterAggregate *AdapterAggregate) summarize() error {
+	adapterAggregate.lock.Lock()
+	if adapterAggregate.timer == nil {
+		adapterAggregate.lock.Unlock()
+		return nil
+	}
+	adapterAggregate.timer.Stop()
+	adapterAggregate.timer = nil
+	loggerMsg := adapterAggregate.summary(time.Now())
+	adapterAggregate.counts = map[int]int64{}
+	adapterAggregate.durations = nil
+	adapterAggregate.exemplars = nil
+	adapterAggregate.lock.Unlock()
+
+	return adapterAggregate.adapter.Write(loggerMsg)
+}
+
+//summary record of the current window after lock, level is the most severe level in the window
+func (adapterAggregate *AdapterAggregate) summary(now time.Time) *loggerMessage {
+	levels := make([]int, 0, len(adapterAggregate.counts))
+	for level := range adapterAggregate.counts {
+		levels = append(levels, level)
+	}
+	sort.Ints(levels)
+
+	fields := map[string]interface{}{
+		"window_start_ms": adapterAggregate.windowStart.UnixNano() / 1e6,
+		"window_ms":       int64(now.Sub(adapterAggregate.windowStart) / time.Millisecond),
+	}
+	counts := make([]string, 0, len(levels))
+	for _, level := range levels {
+		levelString := strings.ToLower(levelStringMapping[level])
+		fields["count_"+levelString] = adapterAggregate.counts[level]
+		counts = append(counts, strconv.FormatInt(adapterAggregate.counts[level], 10)+" "+levelString)
+	}
+	if durations := adapterAggregate.durations; len(durations) > 0 {
+		sort.Float64s(durations)
+		sum := 0.0
+		for _, duration := range durations {
+			sum += duration
+		}
+		field := adapterAggregate.config.DurationField
+		fields[field+"_avg"] = sum / float64(len(durations))
+		fields[field+"_p50"] = aggregatePercentile(durations, 50)
+		fields[field+"_p99"] = aggregatePercentile(durations, 99)
+		fields[field+"_max"] = durations[len(durations)-1]
+	}
+	if records := adapterAggregate.exemplars.records(); records != nil {
+		fields[exemplarsField] = records
+	}
+
+	levelString := levelStringMapping[levels[0]]
+	return &loggerMessage{
+		Timestamp:         now.Unix(),
+		TimestampFormat:   now.Format("2006-01-02 15:04:05"),
+		Millisecond:       now.UnixNano() / 1e6,
+		MillisecondFormat: now.Format("2006-01-02 15:04:05.999"),
+		Level:             levels[0],
+		LevelString:       levelString,
+		Body:              "aggregate " + adapterAggregate.config.Window.String() + ": " + strings.Join(counts, ", "),
+		File:              "null",
+		Function:          "null",
+		Fields:            fields,
+		SchemaVersion:     LoggerMessageSchemaVersion,
+	}
+}
+
+//nearest-rank percentile of sorted values
+func aggregatePercentile(sorted []float64, percentile int) float64 {
+	rank := (len(sorted)*percentile + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+//numeric field value, time.Duration in milliseconds
+func aggregateDuration(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case time.Duration:
+		return float64(v) / float64(time.Millisecond), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func (adapterAggregate *AdapterAggregate) Flush() {
+	adapterAggregate.FlushError()
+}
+
+//write the summary of the current window early, then flush the downstream adapter
+func (adapterAggregate *AdapterAggregate) FlushError() error {
+	err := adapterAggregate.summarize()
+	if flusher, ok := adapterAggregate.adapter.(ErrorFlusher); ok {
+		if flushErr := flusher.FlushError(); flushErr != nil {
+			return flushErr
+		}
+		return err
+	}
+	adapterAggregate.adapter.Flush()
+	return err
+}
+
+func (adapterAggregate *AdapterAggregate) Name() string {
+	return AGGREGATE_ADAPTER_NAME
+}
+
+//new empty config of the adapter, describes the config fields
+func (adapterAggregate *AdapterAggregate) NewConfig() Config {
+	return &AggregateConfig{}
+}
+
+func init() {
+	Register(AGGREGATE_ADAPTER_NAME, NewAdapterAggregate)
+}
@@ -0,0 +1,146 @@
+package go_logger
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAdapterAggregate_Init(t *testing.T) {
+
+	aggregateAdapter := NewAdapterAggregate()
+	if aggregateAdapter.Init(&AggregateConfig{}) == nil {
+		t.Error("aggregate adapter init without Adapter must be error")
+	}
+	if aggregateAdapter.Init(&AggregateConfig{Adapter: CONSOLE_ADAPTER_NAME, Config: &ConsoleConfig{}, Window: -time.Second}) == nil {
+		t.Error("aggregate adapter init with negative Window must be error")
+	}
+	err := aggregateAdapter.Init(&AggregateConfig{
+		Adapter: CONSOLE_ADAPTER_NAME,
+		Config:  &ConsoleConfig{},
+	})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if aggregateAdapter.(*AdapterAggregate).config.Window != time.Minute {
+		t.Error("aggregate adapter default Window must be 1 minute")
+	}
+}
+
+func TestAdapterAggregate_Write(t *testing.T) {
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach(AGGREGATE_ADAPTER_NAME, LoggerLevelDebug, &AggregateConfig{
+		Adapter:       CONSOLE_ADAPTER_NAME,
+		Config:        &ConsoleConfig{Format: "%level_string% %body%"},
+		Window:        time.Hour,
+		DurationField: "duration",
+	})
+	aggregate := logger.outputList()[0].LoggerAbstract.(*AdapterAggregate)
+	buffer := &bytes.Buffer{}
+	aggregate.adapter.(*AdapterConsole).write.writer = buffer
+
+	for i := 1; i <= 100; i++ {
+		logger.Infow("logger aggregate request", "duration", time.Duration(i)*time.Millisecond)
+	}
+	logger.Error("logger aggregate error")
+	if buffer.Len() != 0 {
+		t.Error("aggregate adapter must not write before window end: " + buffer.String())
+	}
+
+	aggregate.lock.Lock()
+	loggerMsg := aggregate.summary(time.Now())
+	aggregate.lock.Unlock()
+	if loggerMsg.Level != LoggerLevelError || loggerMsg.Fields["count_info"] != int64(100) || loggerMsg.Fields["count_error"] != int64(1) {
+		t.Errorf("aggregate summary counts error: %+v", loggerMsg)
+	}
+	if loggerMsg.Fields["duration_p50"] != 50.0 || loggerMsg.Fields["duration_p99"] != 99.0 ||
+		loggerMsg.Fields["duration_max"] != 100.0 || loggerMsg.Fields["duration_avg"] != 50.5 {
+		t.Errorf("aggregate summary durations error: %+v", loggerMsg.Fields)
+	}
+
+	logger.Flush()
+	if !bytes.HasPrefix(buffer.Bytes(), []byte("Error aggregate 1h0m0s: 1 error, 100 info")) {
+		t.Error("aggregate summary record error: " + buffer.String())
+	}
+	buffer.Reset()
+	logger.Flush()
+	if buffer.Len() != 0 {
+		t.Error("aggregate adapter must not write empty window: " + buffer.String())
+	}
+}
+
+func TestAdapterAggregate_Exemplars(t *testing.T) {
+
+	aggregateAdapter := NewAdapterAggregate()
+	if aggregateAdapter.Init(&AggregateConfig{Adapter: CONSOLE_ADAPTER_NAME, Config: &ConsoleConfig{}, Exemplars: -1}) == nil {
+		t.Error("aggregate adapter init with negative Exemplars must be error")
+	}
+	aggregateAdapter.Init(&AggregateConfig{
+		Adapter:   CONSOLE_ADAPTER_NAME,
+		Config:    &ConsoleConfig{},
+		Window:    time.Hour,
+		Exemplars: 3,
+	})
+	aggregate := aggregateAdapter.(*AdapterAggregate)
+	for i := 0; i < 10; i++ {
+		aggregate.Write(&loggerMessage{Level: LoggerLevelInfo, Body: "logger aggregate exemplar", Line: i})
+	}
+	aggregate.lock.Lock()
+	loggerMsg := aggregate.summary(time.Now())
+	aggregate.lock.Unlock()
+	records, _ := loggerMsg.Fields[exemplarsField].([]map[string]interface{})
+	if len(records) != 3 || records[0]["line"] != 0 || records[2]["line"] != 9 {
+		t.Errorf("aggregate exemplars error: %v", loggerMsg.Fields[exemplarsField])
+	}
+	aggregate.timer.Stop()
+}
+
+// buffer safe for concurrent use
+type syncBuffer struct {
+	lock   sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (sb *syncBuffer) Write(p []byte) (int, error) {
+	sb.lock.Lock()
+	defer sb.lock.Unlock()
+	return sb.buffer.Write(p)
+}
+
+func (sb *syncBuffer) String() string {
+	sb.lock.Lock()
+	defer sb.lock.Unlock()
+	return sb.buffer.String()
+}
+
+func (sb *syncBuffer) Reset() {
+	sb.lock.Lock()
+	defer sb.lock.Unlock()
+	sb.buffer.Reset()
+}
+
+func TestAdapterAggregate_Window(t *testing.T) {
+
+	aggregateAdapter := NewAdapterAggregate()
+	aggregateAdapter.Init(&AggregateConfig{
+		Adapter: CONSOLE_ADAPTER_NAME,
+		Config:  &ConsoleConfig{Format: "%body%"},
+		Window:  10 * time.Millisecond,
+	})
+	aggregate := aggregateAdapter.(*AdapterAggregate)
+	buffer := &syncBuffer{}
+	aggregate.adapter.(*AdapterConsole).write.writer = buffer
+
+	aggregate.Write(&loggerMessage{Level: LoggerLevelWarning, Body: "logger aggregate window"})
+	deadline := time.Now().Add(time.Second)
+	for buffer.String() == "" && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if !strings.HasPrefix(buffer.String(), "aggregate 10ms: 1 warning count_warning=1 window_ms=") {
+		t.Error("aggregate window summary error: " + buffer.String())
+	}
+}
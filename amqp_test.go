@@ -0,0 +1,318 @@
+//go:build !tinygo && !logger_tiny
+// +build !tinygo,!logger_tiny
+
+package go_logger
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// message published to the fake AMQP broker
+type amqpPublished struct {
+	exchange     string
+	routingKey   string
+	contentType  string
+	deliveryMode byte
+	body         string
+}
+
+// fake AMQP 0-9-1 broker, closes the channel of the first reject publishes by 404 NOT_FOUND
+type amqpServer struct {
+	listener  net.Listener
+	lock      sync.Mutex
+	auth      []string
+	vhosts    []string
+	channels  int
+	published []amqpPublished
+	reject    int
+	conns     []net.Conn
+}
+
+func newAmqpServer(t *testing.T, reject int) *amqpServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	server := &amqpServer{listener: listener, reject: reject}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			server.lock.Lock()
+			server.conns = append(server.conns, conn)
+			server.lock.Unlock()
+			go server.serve(conn)
+		}
+	}()
+	return server
+}
+
+func (server *amqpServer) writeMethod(conn net.Conn, channel uint16, id uint32, args []byte) {
+	frames := &bytes.Buffer{}
+	amqpFrame(frames, amqpFrameMethod, channel, amqpMethodPayload(id, args))
+	conn.Write(frames.Bytes())
+}
+
+//read a method frame, shortstr and longstr arguments are parsed by the caller
+func (server *amqpServer) readMethod(reader *bufio.Reader) (uint16, uint32, []byte, bool) {
+	frameType, channel, payload, err := amqpReadFrame(reader)
+	if err != nil || frameType != amqpFrameMethod {
+		return 0, 0, nil, false
+	}
+	return channel, binary.BigEndian.Uint32(payload[0:4]), payload[4:], true
+}
+
+func (server *amqpServer) serve(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	header := make([]byte, 8)
+	if _, err := io.ReadFull(reader, header); err != nil || string(header) != "AMQP\x00\x00\x09\x01" {
+		return
+	}
+
+	start := &amqpArgs{}
+	start.octet(0)
+	start.octet(9)
+	start.table(nil)
+	start.longstr("PLAIN AMQPLAIN")
+	start.longstr("en_US")
+	server.writeMethod(conn, 0, amqpConnectionStart, start.Bytes())
+	_, _, startOk, ok := server.readMethod(reader)
+	if !ok {
+		return
+	}
+	// client properties table, mechanism, response
+	offset := 4 + int(binary.BigEndian.Uint32(startOk[0:4]))
+	offset += 1 + int(startOk[offset])
+	size := int(binary.BigEndian.Uint32(startOk[offset : offset+4]))
+	auth := string(startOk[offset+4 : offset+4+size])
+
+	tune := &amqpArgs{}
+	tune.short(2047)
+	tune.long(4096)
+	tune.short(60)
+	server.writeMethod(conn, 0, amqpConnectionTune, tune.Bytes())
+	if _, _, _, ok := server.readMethod(reader); !ok {
+		return
+	}
+	_, _, open, ok := server.readMethod(reader)
+	if !ok {
+		return
+	}
+	server.lock.Lock()
+	server.auth = append(server.auth, auth)
+	server.vhosts = append(server.vhosts, string(open[1:1+int(open[0])]))
+	server.lock.Unlock()
+	openOk := &amqpArgs{}
+	openOk.shortstr("")
+	server.writeMethod(conn, 0, amqpConnectionOpenOk, openOk.Bytes())
+
+	confirms := map[uint16]uint64{}
+	for {
+		channel, id, args, ok := server.readMethod(reader)
+		if !ok {
+			return
+		}
+		switch id {
+		case amqpChannelOpen:
+			server.lock.Lock()
+			server.channels++
+			server.lock.Unlock()
+			channelOpenOk := &amqpArgs{}
+			channelOpenOk.longstr("")
+			server.writeMethod(conn, channel, amqpChannelOpenOk, channelOpenOk.Bytes())
+		case amqpConfirmSelect:
+			confirms[channel] = 0
+			server.writeMethod(conn, channel, amqpConfirmSelectOk, nil)
+		case amqpBasicPublish:
+			exchange := string(args[3 : 3+int(args[2])])
+			args = args[3+int(args[2]):]
+			routingKey := string(args[1 : 1+int(args[0])])
+
+			_, _, properties, err := amqpReadFrame(reader)
+			if err != nil {
+				return
+			}
+			bodySize := int(binary.BigEndian.Uint64(properties[4:12]))
+			contentType := string(properties[15 : 15+int(properties[14])])
+			published := amqpPublished{
+				exchange:     exchange,
+				routingKey:   routingKey,
+				contentType:  contentType,
+				deliveryMode: properties[15+int(properties[14])],
+			}
+			body := &bytes.Buffer{}
+			for body.Len() < bodySize {
+				_, _, payload, err := amqpReadFrame(reader)
+				if err != nil {
+					return
+				}
+				if len(payload) > 4096-8 {
+					return
+				}
+				body.Write(payload)
+			}
+			published.body = body.String()
+
+			server.lock.Lock()
+			server.published = append(server.published, published)
+			reject := server.reject > 0
+			if reject {
+				server.reject--
+			}
+			server.lock.Unlock()
+			if reject {
+				closeArgs := &amqpArgs{}
+				closeArgs.short(404)
+				closeArgs.shortstr("NOT_FOUND - no exchange")
+				closeArgs.short(60)
+				closeArgs.short(40)
+				server.writeMethod(conn, channel, amqpChannelClose, closeArgs.Bytes())
+				delete(confirms, channel)
+				continue
+			}
+			if tag, ok := confirms[channel]; ok {
+				confirms[channel] = tag + 1
+				ack := &amqpArgs{}
+				ack.longlong(tag + 1)
+				ack.octet(0)
+				server.writeMethod(conn, channel, amqpBasicAck, ack.Bytes())
+			}
+		case amqpConnectionClose:
+			server.writeMethod(conn, 0, amqpConnectionCloseOk, nil)
+			return
+		}
+	}
+}
+
+func (server *amqpServer) received() ([]string, []string, int, []amqpPublished) {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+	return append([]string{}, server.auth...), append([]string{}, server.vhosts...), server.channels,
+		append([]amqpPublished{}, server.published...)
+}
+
+//close the accepted connections, as a restarted broker
+func (server *amqpServer) drop() {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+	for _, conn := range server.conns {
+		conn.Close()
+	}
+	server.conns = nil
+}
+
+func TestAdapterAmqp_Init(t *testing.T) {
+
+	configs := []*AmqpConfig{
+		{},
+		{Url: "http://127.0.0.1:5672/", RoutingKey: "logs"},
+		{Url: "amqp://127.0.0.1:5672/"},
+		{Url: "amqp://127.0.0.1:5672/", RoutingKey: "logs", Timeout: -1},
+	}
+	for _, config := range configs {
+		if NewAdapterAmqp().Init(config) == nil {
+			t.Errorf("amqp adapter config %+v must be error", config)
+		}
+	}
+}
+
+func TestAdapterAmqp_Write(t *testing.T) {
+
+	server := newAmqpServer(t, 0)
+	defer server.listener.Close()
+
+	amqpAdapter := NewAdapterAmqp()
+	err := amqpAdapter.Init(&AmqpConfig{
+		Url:        "amqp://app:secret@" + server.listener.Addr().String() + "/logs",
+		Exchange:   "logs",
+		RoutingKey: "app.%level_string%",
+		Confirm:    true,
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer amqpAdapter.(*AdapterAmqp).Close()
+
+	if err := amqpAdapter.Write(&loggerMessage{Level: LoggerLevelError, LevelString: "Error", Body: "logger amqp"}); err != nil {
+		t.Fatal(err.Error())
+	}
+	// body frames are split by the frame max of the broker
+	large := strings.Repeat("x", 10000)
+	if err := amqpAdapter.Write(&loggerMessage{Level: LoggerLevelInfo, LevelString: "Info", Body: large}); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	auth, vhosts, _, published := server.received()
+	if len(auth) != 1 || auth[0] != "\x00app\x00secret" || vhosts[0] != "logs" {
+		t.Fatalf("amqp auth %q vhost %q", auth, vhosts)
+	}
+	if len(published) != 2 {
+		t.Fatalf("amqp published %d messages, expected 2", len(published))
+	}
+	if published[0].exchange != "logs" || published[0].routingKey != "app.error" ||
+		published[0].contentType != "application/json" || published[0].deliveryMode != 2 ||
+		!strings.Contains(published[0].body, `"body":"logger amqp"`) {
+		t.Errorf("amqp published %+v", published[0])
+	}
+	if published[1].routingKey != "app.info" || !strings.Contains(published[1].body, large) {
+		t.Errorf("amqp published %+v", published[1].routingKey)
+	}
+}
+
+func TestAdapterAmqp_WriteReopen(t *testing.T) {
+
+	server := newAmqpServer(t, 1)
+	defer server.listener.Close()
+
+	amqpAdapter := NewAdapterAmqp()
+	err := amqpAdapter.Init(&AmqpConfig{
+		Url:        "amqp://" + server.listener.Addr().String(),
+		RoutingKey: "logs",
+		Transient:  true,
+		Confirm:    true,
+		Format:     "%body%",
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer amqpAdapter.(*AdapterAmqp).Close()
+
+	// the channel closed by the broker is opened again
+	if err := amqpAdapter.Write(&loggerMessage{LevelString: "Info", Body: "logger amqp 1"}); err != nil {
+		t.Fatal(err.Error())
+	}
+	// a restarted broker, the next write reconnects
+	server.drop()
+	<-amqpAdapter.(*AdapterAmqp).conn.done
+	if err := amqpAdapter.Write(&loggerMessage{LevelString: "Info", Body: "logger amqp 2"}); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	auth, vhosts, channels, published := server.received()
+	if len(auth) != 2 || auth[0] != "\x00guest\x00guest" || vhosts[0] != "/" {
+		t.Fatalf("amqp auth %q vhost %q", auth, vhosts)
+	}
+	if channels != 3 {
+		t.Errorf("amqp opened %d channels, expected 3", channels)
+	}
+	bodies := []string{}
+	for _, message := range published {
+		if message.contentType != "text/plain" || message.deliveryMode != 1 {
+			t.Errorf("amqp published %+v", message)
+		}
+		bodies = append(bodies, message.body)
+	}
+	if strings.Join(bodies, ",") != "logger amqp 1,logger amqp 1,logger amqp 2" {
+		t.Errorf("amqp published %q", bodies)
+	}
+}
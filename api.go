@@ -1,10 +1,16 @@
+//go:build !tinygo && !logger_tiny
+// +build !tinygo,!logger_tiny
+
 package go_logger
 
 import (
+	"bytes"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/qjyoung/go-logger/utils"
-	"reflect"
+	"net/http"
+	"net/url"
 	"strconv"
 )
 
@@ -12,18 +18,19 @@ const API_ADAPTER_NAME = "api"
 
 // adapter api
 type AdapterApi struct {
-	config *ApiConfig
+	config     *ApiConfig
+	compressor Compressor // request body compressor, nil is not compressed
 }
 
 // api config
 type ApiConfig struct {
 
 	// request url adddress
-	Url string
+	Url string `config:"required"`
 
 	// request method
 	// GET, POST
-	Method string
+	Method string `config:"required"`
 
 	// request headers
 	Headers map[string]string
@@ -33,6 +40,10 @@ type ApiConfig struct {
 
 	// verify response http code
 	VerifyCode int
+
+	// POST body compression, a registered compressor name e.g. "gzip", sent as Content-Encoding
+	// the form is sent in the body only, default none
+	Compression string
 }
 
 func (ac *ApiConfig) Name() string {
@@ -49,8 +60,7 @@ func (adapterApi *AdapterApi) Init(apiConfig Config) error {
 		return errors.New("logger api adapter init error, config must ApiConfig")
 	}
 
-	vc := reflect.ValueOf(apiConfig)
-	ac := vc.Interface().(*ApiConfig)
+	ac := apiConfig.(*ApiConfig)
 	adapterApi.config = ac
 
 	if adapterApi.config.Url == "" {
@@ -62,17 +72,19 @@ func (adapterApi *AdapterApi) Init(apiConfig Config) error {
 	if adapterApi.config.IsVerify && (adapterApi.config.VerifyCode == 0) {
 		return errors.New("config if IsVerify is true, VerifyCode cannot be 0!")
 	}
+	compressor, err := compressorByName(adapterApi.config.Compression)
+	if err != nil {
+		return err
+	}
+	if compressor != nil && adapterApi.config.Method != "POST" {
+		return errors.New("config Compression needs POST Method!")
+	}
+	adapterApi.compressor = compressor
 	return nil
 }
 
 func (adapterApi *AdapterApi) Write(loggerMsg *loggerMessage) error {
 
-	url := adapterApi.config.Url
-	method := adapterApi.config.Method
-	isVerify := adapterApi.config.IsVerify
-	verifyCode := adapterApi.config.VerifyCode
-	headers := adapterApi.config.Headers
-
 	loggerMap := map[string]string{
 		"timestamp":          strconv.FormatInt(loggerMsg.Timestamp, 10),
 		"timestamp_format":   loggerMsg.TimestampFormat,
@@ -84,11 +96,29 @@ func (adapterApi *AdapterApi) Write(loggerMsg *loggerMessage) error {
 		"file":               loggerMsg.File,
 		"line":               strconv.Itoa(loggerMsg.Line),
 		"function":           loggerMsg.Function,
+		"schema_version":     strconv.Itoa(loggerMsg.SchemaVersion),
+	}
+	if len(loggerMsg.Fields) > 0 {
+		fieldsByte, _ := json.Marshal(loggerMsg.Fields)
+		loggerMap["fields"] = string(fieldsByte)
 	}
+	return adapterApi.request(loggerMap)
+}
+
+//send logger map by config
+func (adapterApi *AdapterApi) request(loggerMap map[string]string) error {
+
+	url := adapterApi.config.Url
+	method := adapterApi.config.Method
+	isVerify := adapterApi.config.IsVerify
+	verifyCode := adapterApi.config.VerifyCode
+	headers := adapterApi.config.Headers
 
 	var err error
 	var code int
-	if method == "GET" {
+	if adapterApi.compressor != nil {
+		code, err = adapterApi.postCompressed(loggerMap)
+	} else if method == "GET" {
 		_, code, err = utils.NewMisc().HttpGet(url, loggerMap, headers, 0)
 	} else {
 		_, code, err = utils.NewMisc().HttpPost(url, loggerMap, headers, 0)
@@ -103,6 +133,44 @@ func (adapterApi *AdapterApi) Write(loggerMsg *loggerMessage) error {
 	return nil
 }
 
+//post logger map as a compressed form body
+//return : response code, error
+func (adapterApi *AdapterApi) postCompressed(loggerMap map[string]string) (int, error) {
+	form := url.Values{}
+	for key, value := range loggerMap {
+		form.Set(key, value)
+	}
+	body, err := compressBytes(adapterApi.compressor, []byte(form.Encode()))
+	if err != nil {
+		return 0, err
+	}
+	request, err := http.NewRequest("POST", adapterApi.config.Url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	for key, value := range adapterApi.config.Headers {
+		request.Header.Set(key, value)
+	}
+	request.Header.Set("Content-Encoding", adapterApi.compressor.Name())
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	response.Body.Close()
+	return response.StatusCode, nil
+}
+
+//write a compact record of a message identical to the interned record
+func (adapterApi *AdapterApi) WriteRepeat(loggerMsg *loggerMessage, internId string) error {
+	loggerMap := map[string]string{
+		"timestamp":   strconv.FormatInt(loggerMsg.Timestamp, 10),
+		"millisecond": strconv.FormatInt(loggerMsg.Millisecond, 10),
+		"repeat":      internId,
+	}
+	return adapterApi.request(loggerMap)
+}
+
 func (adapterApi *AdapterApi) Flush() {
 
 }
@@ -111,6 +179,11 @@ func (adapterApi *AdapterApi) Name() string {
 	return API_ADAPTER_NAME
 }
 
+//new empty config of the adapter, describes the config fields
+func (adapterApi *AdapterApi) NewConfig() Config {
+	return &ApiConfig{}
+}
+
 func init() {
 	Register(API_ADAPTER_NAME, NewAdapterApi)
 }
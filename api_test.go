@@ -0,0 +1,53 @@
+//go:build !tinygo && !logger_tiny
+// +build !tinygo,!logger_tiny
+
+package go_logger
+
+import (
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestAdapterApi_Compression(t *testing.T) {
+
+	if NewAdapterApi().Init(&ApiConfig{Url: "http://127.0.0.1", Method: "GET", Compression: "gzip"}) == nil {
+		t.Error("api adapter GET Compression must be error")
+	}
+
+	var encoding string
+	var form url.Values
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encoding = r.Header.Get("Content-Encoding")
+		gzipReader, err := gzip.NewReader(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		data, _ := ioutil.ReadAll(gzipReader)
+		form, _ = url.ParseQuery(string(data))
+	}))
+	defer httpServer.Close()
+
+	apiAdapter := NewAdapterApi()
+	err := apiAdapter.Init(&ApiConfig{
+		Url:         httpServer.URL,
+		Method:      "POST",
+		IsVerify:    true,
+		VerifyCode:  http.StatusOK,
+		Compression: "gzip",
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	err = apiAdapter.Write(&loggerMessage{Level: LoggerLevelInfo, LevelString: "Info", Body: "logger api compressed"})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if encoding != "gzip" || form.Get("body") != "logger api compressed" || form.Get("level_string") != "Info" {
+		t.Errorf("api adapter compressed request %v %v", encoding, form)
+	}
+}
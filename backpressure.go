@@ -0,0 +1,65 @@
+package go_logger
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// overflowed message is dropped and counted
+	OVERFLOW_POLICY_DROP = iota
+	// overflowed message is written synchronously by the caller
+	OVERFLOW_POLICY_WRITE
+)
+
+//set async enqueue timeout, a message that cannot be queued in timeout
+//is handled by the overflow policy instead of blocking the caller
+//params : timeout time.Duration, 0 blocks until queued; policy OVERFLOW_POLICY_DROP | OVERFLOW_POLICY_WRITE
+//return : error
+func (logger *Logger) SetEnqueueTimeout(timeout time.Duration, policy int) error {
+	if timeout < 0 {
+		return errors.New("logger: enqueue timeout cannot be negative!")
+	}
+	if policy != OVERFLOW_POLICY_DROP && policy != OVERFLOW_POLICY_WRITE {
+		return errors.New("logger: overflow policy is illegal!")
+	}
+	logger.asyncLock.Lock()
+	defer logger.asyncLock.Unlock()
+
+	logger.enqueueTimeout = timeout
+	logger.overflowPolicy = policy
+	return nil
+}
+
+//send message to channel, must hold asyncLock read lock
+//return : false if enqueue timeout
+func (logger *Logger) enqueue(msgChan chan *loggerMessage, loggerMsg *loggerMessage) bool {
+	if logger.enqueueTimeout == 0 {
+		msgChan <- loggerMsg
+		return true
+	}
+	select {
+	case msgChan <- loggerMsg:
+		return true
+	default:
+	}
+	timer := time.NewTimer(logger.enqueueTimeout)
+	defer timer.Stop()
+	select {
+	case msgChan <- loggerMsg:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
+//handle message by overflow policy
+func (logger *Logger) overflow(loggerMsg *loggerMessage, policy int) {
+	atomic.AddInt64(&logger.stats.overflowed, 1)
+	if policy == OVERFLOW_POLICY_WRITE {
+		logger.writeToOutputs(loggerMsg)
+		return
+	}
+	logger.drop(loggerMsg, DROP_REASON_OVERFLOW)
+}
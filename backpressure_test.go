@@ -0,0 +1,43 @@
+package go_logger
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLogger_SetEnqueueTimeout(t *testing.T) {
+
+	logger := NewLogger()
+	if logger.SetEnqueueTimeout(-1, OVERFLOW_POLICY_DROP) == nil {
+		t.Error("negative enqueue timeout must be error")
+	}
+	if logger.SetEnqueueTimeout(time.Millisecond, 100) == nil {
+		t.Error("illegal overflow policy must be error")
+	}
+
+	logger.Detach("console")
+	logger.Attach("count", LoggerLevelDebug, &countConfig{})
+	count := logger.outputList()[0].LoggerAbstract.(*adapterCount)
+
+	// async without worker goroutine, the channel is full after one message
+	logger.synchronous = false
+	logger.msgChan = make(chan *loggerMessage, 1)
+	logger.SetEnqueueTimeout(10*time.Millisecond, OVERFLOW_POLICY_DROP)
+
+	logger.Info("logger enqueue test")
+	start := time.Now()
+	logger.Info("logger enqueue timeout drop test")
+	if time.Since(start) < 10*time.Millisecond || time.Since(start) > time.Second {
+		t.Error("enqueue must wait for the timeout")
+	}
+	if atomic.LoadInt64(&logger.stats.overflowed) != 1 || atomic.LoadInt64(&logger.stats.dropped) != 1 {
+		t.Error("enqueue timeout drop policy must count overflowed and dropped")
+	}
+
+	logger.SetEnqueueTimeout(time.Millisecond, OVERFLOW_POLICY_WRITE)
+	logger.Info("logger enqueue timeout write test")
+	if atomic.LoadInt64(&count.writes) != 1 || len(logger.msgChan) != 1 {
+		t.Error("enqueue timeout write policy must write by the caller")
+	}
+}
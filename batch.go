@@ -0,0 +1,72 @@
+package go_logger
+
+import (
+	"errors"
+	"strconv"
+	"time"
+)
+
+//write historical records through the outputs, e.g. by a migration tool importing old logs
+//record times, caller and fields are kept; Millisecond is required, or Timestamp in seconds
+//records pass rewriters, the sanitizer, route rules and output levels, but not sampling or quotas,
+//and are written by the caller without the async queue, so the queue TTL does not drop them
+//the records are checked before any is written, then the outputs are flushed
+//params : loggerMsgs []*LoggerMessage, not modified
+//return : error of an illegal record, or AdapterErrors of outputs failed to flush
+func (logger *Logger) WriteBatch(loggerMsgs []*LoggerMessage) error {
+	for i, loggerMsg := range loggerMsgs {
+		if loggerMsg == nil {
+			return errors.New("logger: batch record " + strconv.Itoa(i) + " is nil!")
+		}
+		if _, ok := levelStringMapping[loggerMsg.Level]; !ok {
+			return errors.New("logger: batch record " + strconv.Itoa(i) + " level " + strconv.Itoa(loggerMsg.Level) + " is illegal!")
+		}
+		if loggerMsg.Millisecond <= 0 && loggerMsg.Timestamp <= 0 {
+			return errors.New("logger: batch record " + strconv.Itoa(i) + " has no Millisecond or Timestamp!")
+		}
+	}
+	if logger.Disabled() {
+		return nil
+	}
+
+	for _, loggerMsg := range loggerMsgs {
+		batchMsg := batchMessage(loggerMsg)
+		logger.rewrite(batchMsg)
+		logger.sanitize(batchMsg)
+		logger.stats.count(batchMsg.Level)
+		if !logger.route(batchMsg) {
+			logger.drop(batchMsg, DROP_REASON_ROUTE)
+			continue
+		}
+		batchMsg.formats = &formatCache{owner: batchMsg}
+		logger.writeToOutputs(batchMsg)
+	}
+	return logger.flushOutputs()
+}
+
+//copy of a batch record with the missing time formats, level string and schema version
+func batchMessage(loggerMsg *LoggerMessage) *loggerMessage {
+	batchMsg := *loggerMsg
+	batchMsg.routeAdapters = nil
+	batchMsg.formats = nil
+	if batchMsg.Millisecond <= 0 {
+		batchMsg.Millisecond = batchMsg.Timestamp * 1000
+	}
+	t := time.Unix(0, batchMsg.Millisecond*int64(time.Millisecond))
+	if batchMsg.Timestamp <= 0 {
+		batchMsg.Timestamp = t.Unix()
+	}
+	if batchMsg.TimestampFormat == "" {
+		batchMsg.TimestampFormat = t.Format("2006-01-02 15:04:05")
+	}
+	if batchMsg.MillisecondFormat == "" {
+		batchMsg.MillisecondFormat = t.Format("2006-01-02 15:04:05.999")
+	}
+	if batchMsg.LevelString == "" {
+		batchMsg.LevelString = levelStringMapping[batchMsg.Level]
+	}
+	if batchMsg.SchemaVersion == 0 {
+		batchMsg.SchemaVersion = LoggerMessageSchemaVersion
+	}
+	return &batchMsg
+}
@@ -0,0 +1,54 @@
+package go_logger
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestLogger_WriteBatch(t *testing.T) {
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("console", LoggerLevelInfo, &ConsoleConfig{
+		Format: "%millisecond_format% %level_string% %file%:%line% %body% %fields%",
+	})
+	buffer := &bytes.Buffer{}
+	logger.outputList()[0].LoggerAbstract.(*AdapterConsole).write.writer = buffer
+
+	illegal := [][]*LoggerMessage{
+		{nil},
+		{{Level: 100, Millisecond: 1}},
+		{{Level: LoggerLevelInfo, Body: "logger batch"}},
+	}
+	for _, records := range illegal {
+		if logger.WriteBatch(records) == nil {
+			t.Errorf("batch record %+v must be error", records[0])
+		}
+	}
+
+	// historical records are not dropped by the queue TTL of async loggers
+	logger.SetAsync()
+	logger.SetQueueTTL(time.Second, "")
+	logger.SetRouteRules([]RouteRule{{BodyRegexp: "^drop", Action: ROUTE_ACTION_DROP}})
+	millisecond := time.Date(2019, 5, 1, 10, 30, 0, 250e6, time.Local).UnixNano() / 1e6
+	records := []*LoggerMessage{
+		{Millisecond: millisecond, Level: LoggerLevelError, Body: "logger batch 1", File: "import.go", Line: 7,
+			Fields: map[string]interface{}{"user": "u1"}},
+		{Timestamp: millisecond / 1000, Level: LoggerLevelWarning, LevelString: "Warning", Body: "logger batch 2"},
+		{Millisecond: millisecond, Level: LoggerLevelDebug, Body: "logger batch debug"},
+		{Millisecond: millisecond, Level: LoggerLevelInfo, Body: "drop logger batch"},
+	}
+	if err := logger.WriteBatch(records); err != nil {
+		t.Fatal(err.Error())
+	}
+	logger.SetSync()
+	expected := "2019-05-01 10:30:00.25 Error import.go:7 logger batch 1 user=u1\n" +
+		"2019-05-01 10:30:00 Warning :0 logger batch 2 \n"
+	if buffer.String() != expected {
+		t.Errorf("batch records output %q", buffer.String())
+	}
+	if records[0].LevelString != "" || records[0].formats != nil {
+		t.Error("batch records must not be modified")
+	}
+}
@@ -1,6 +1,7 @@
 package go_logger
 
 import (
+	"io/ioutil"
 	"testing"
 )
 
@@ -95,3 +96,35 @@ func BenchmarkLoggerFileJson(b *testing.B) {
 		}
 	})
 }
+
+// go test -run=benchmark -cpu=1,2,4 -benchmem -benchtime=3s -bench="SharedFormat"
+func BenchmarkLoggerSharedFormat(b *testing.B) {
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("console", LoggerLevelDebug, &ConsoleConfig{
+		Format: "%millisecond_format% [%level_string%] [%file%:%line%] %body%",
+	})
+	logger.outputList()[0].LoggerAbstract.(*AdapterConsole).write.writer = ioutil.Discard
+	logger.Attach("file", LoggerLevelDebug, &FileConfig{
+		Filename: "./test.log",
+		Format:   "%millisecond_format% [%level_string%] [%file%:%line%] %body%",
+	})
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			logger.Infow("benchmark logger message", "user", "u1", "cost", 12)
+		}
+	})
+}
+
+// go test -run=benchmark -cpu=1,2,4 -benchmem -benchtime=3s -bench="Disabled"
+func BenchmarkLoggerDisabled(b *testing.B) {
+	logger := NewLogger()
+	logger.Disable()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			logger.Infow("benchmark logger message", "user", "u1", "cost", 12)
+		}
+	})
+}
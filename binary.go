@@ -0,0 +1,88 @@
+package go_logger
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strconv"
+)
+
+const (
+	BINARY_ENCODING_BASE64  = "base64"
+	BINARY_ENCODING_HEX     = "hex"
+	BINARY_ENCODING_PREVIEW = "preview"
+)
+
+// encoding of []byte field values
+type binaryEncoder struct {
+	encoding string
+	maxBytes int
+}
+
+//set encoding of []byte field values, default base64 and unlimited
+//"base64" standard base64
+//"hex" lowercase hex
+//"preview" printable ASCII, other bytes are shown as "."
+//params : encoding string, maxBytes int, bytes over maxBytes are cut and the size is appended, 0 is unlimited
+//return : error
+func (logger *Logger) SetBinaryEncoding(encoding string, maxBytes int) error {
+	switch encoding {
+	case BINARY_ENCODING_BASE64, BINARY_ENCODING_HEX, BINARY_ENCODING_PREVIEW:
+	default:
+		return errors.New("logger: binary encoding must be one of the 'base64', 'hex', 'preview'!")
+	}
+	if maxBytes < 0 {
+		return errors.New("logger: binary maxBytes cannot be negative!")
+	}
+
+	logger.lock.Lock()
+	defer logger.lock.Unlock()
+
+	logger.binaryEncoding.Store(&binaryEncoder{encoding: encoding, maxBytes: maxBytes})
+	return nil
+}
+
+//encode []byte field values to string
+func (logger *Logger) encodeBinary(loggerMsg *loggerMessage) {
+	encoder := logger.binaryEncoding.Load().(*binaryEncoder)
+	var fields map[string]interface{}
+	for key, value := range loggerMsg.Fields {
+		data, ok := value.([]byte)
+		if !ok {
+			continue
+		}
+		if fields == nil {
+			fields = make(map[string]interface{}, len(loggerMsg.Fields))
+			for k, v := range loggerMsg.Fields {
+				fields[k] = v
+			}
+		}
+		fields[key] = encodeBinaryValue(data, encoder.encoding, encoder.maxBytes)
+	}
+	if fields != nil {
+		loggerMsg.Fields = fields
+	}
+}
+
+//encode bytes, cut to maxBytes and append "...(size bytes)"
+func encodeBinaryValue(data []byte, encoding string, maxBytes int) string {
+	suffix := ""
+	if maxBytes > 0 && len(data) > maxBytes {
+		suffix = "...(" + strconv.Itoa(len(data)) + " bytes)"
+		data = data[:maxBytes]
+	}
+	switch encoding {
+	case BINARY_ENCODING_HEX:
+		return hex.EncodeToString(data) + suffix
+	case BINARY_ENCODING_PREVIEW:
+		preview := make([]byte, len(data))
+		for i, b := range data {
+			if b < 0x20 || b > 0x7e {
+				b = '.'
+			}
+			preview[i] = b
+		}
+		return string(preview) + suffix
+	}
+	return base64.StdEncoding.EncodeToString(data) + suffix
+}
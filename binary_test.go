@@ -0,0 +1,36 @@
+package go_logger
+
+import (
+	"testing"
+)
+
+func TestEncodeBinaryValue(t *testing.T) {
+
+	data := []byte("hi\x00there")
+	if encodeBinaryValue(data, BINARY_ENCODING_BASE64, 0) != "aGkAdGhlcmU=" {
+		t.Error("encode binary base64 error")
+	}
+	if encodeBinaryValue(data, BINARY_ENCODING_HEX, 2) != "6869...(8 bytes)" {
+		t.Error("encode binary hex error")
+	}
+	if encodeBinaryValue(data, BINARY_ENCODING_PREVIEW, 0) != "hi.there" {
+		t.Error("encode binary preview error")
+	}
+}
+
+func TestLogger_SetBinaryEncoding(t *testing.T) {
+
+	logger := NewLogger()
+	if logger.SetBinaryEncoding("base32", 0) == nil {
+		t.Error("unknown binary encoding must be error")
+	}
+	logger.SetBinaryEncoding(BINARY_ENCODING_PREVIEW, 4)
+
+	loggerMsg := &loggerMessage{
+		Fields: map[string]interface{}{"payload": []byte("GET / HTTP/1.1\r\n")},
+	}
+	logger.encodeBinary(loggerMsg)
+	if loggerMsg.Fields["payload"] != "GET ...(16 bytes)" {
+		t.Errorf("logger binary encoding error: %v", loggerMsg.Fields["payload"])
+	}
+}
@@ -0,0 +1,120 @@
+package go_logger
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// blob store of large field values
+type BlobStore interface {
+	// save data with key, return the reference url
+	Put(key string, data []byte) (url string, err error)
+}
+
+// blob store and threshold of large fields
+type blobStore struct {
+	store     BlobStore
+	threshold int
+}
+
+//set blob store, string and []byte field values larger than threshold bytes
+//are saved to the store and replaced by {"url", "sha256", "size"}
+//the store is called on the logging goroutine
+//params : store BlobStore, nil to remove; threshold int
+//return : error
+func (logger *Logger) SetBlobStore(store BlobStore, threshold int) error {
+	if store != nil && threshold <= 0 {
+		return errors.New("logger: blob threshold must be greater than 0!")
+	}
+	logger.lock.Lock()
+	defer logger.lock.Unlock()
+
+	if store == nil {
+		logger.blobStore.Store((*blobStore)(nil))
+		return nil
+	}
+	logger.blobStore.Store(&blobStore{store: store, threshold: threshold})
+	return nil
+}
+
+//save large field values to blob store
+func (logger *Logger) externalize(loggerMsg *loggerMessage) {
+	blobs, _ := logger.blobStore.Load().(*blobStore)
+	if blobs == nil {
+		return
+	}
+	var fields map[string]interface{}
+	for key, value := range loggerMsg.Fields {
+		var data []byte
+		switch v := value.(type) {
+		case []byte:
+			data = v
+		case string:
+			if len(v) > blobs.threshold {
+				data = []byte(v)
+			}
+		}
+		if len(data) <= blobs.threshold {
+			continue
+		}
+		if fields == nil {
+			fields = make(map[string]interface{}, len(loggerMsg.Fields))
+			for k, v := range loggerMsg.Fields {
+				fields[k] = v
+			}
+		}
+		sum := sha256.Sum256(data)
+		hash := hex.EncodeToString(sum[:])
+		ref := map[string]interface{}{
+			"sha256": hash,
+			"size":   len(data),
+		}
+		url, err := blobs.store.Put(hash, data)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "logger: unable put field %v to blob store, error: %v\n", key, err)
+			ref["error"] = err.Error()
+		} else {
+			ref["url"] = url
+		}
+		fields[key] = ref
+	}
+	if fields != nil {
+		loggerMsg.Fields = fields
+	}
+}
+
+// blob store of local directory
+type DiskBlobStore struct {
+
+	// blob directory, created if not exists
+	Dir string
+
+	// reference url prefix, default "file://" + absolute Dir
+	URLPrefix string
+}
+
+func (store *DiskBlobStore) Put(key string, data []byte) (string, error) {
+	err := os.MkdirAll(store.Dir, 0755)
+	if err != nil {
+		return "", err
+	}
+	filename := filepath.Join(store.Dir, key+".blob")
+	err = ioutil.WriteFile(filename, data, 0644)
+	if err != nil {
+		return "", err
+	}
+	if store.URLPrefix != "" {
+		return strings.TrimRight(store.URLPrefix, "/") + "/" + key + ".blob", nil
+	}
+	absFilename, err := filepath.Abs(filename)
+	if err != nil {
+		return "", err
+	}
+	return "file://" + filepath.ToSlash(absFilename), nil
+}
@@ -0,0 +1,111 @@
+//go:build !tinygo && !logger_tiny
+// +build !tinygo,!logger_tiny
+
+package go_logger
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// blob store of S3 compatible object storage, requests are signed by AWS Signature Version 4
+type S3BlobStore struct {
+
+	// endpoint, default "https://s3.<Region>.amazonaws.com"
+	Endpoint string
+
+	Region    string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+
+	// object key prefix, e.g. "logs/blobs/"
+	Prefix string
+
+	// request timeout, default 10s
+	Timeout time.Duration
+}
+
+func (store *S3BlobStore) Put(key string, data []byte) (string, error) {
+	endpoint := store.Endpoint
+	if endpoint == "" {
+		endpoint = "https://s3." + store.Region + ".amazonaws.com"
+	}
+	endpoint = strings.TrimRight(endpoint, "/")
+	url := endpoint + "/" + store.Bucket + "/" + store.Prefix + key
+
+	req, err := http.NewRequest("PUT", url, bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	store.sign(req, data, time.Now().UTC())
+
+	timeout := store.Timeout
+	if timeout == 0 {
+		timeout = 10 * time.Second
+	}
+	client := &http.Client{Timeout: timeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return "", errors.New("s3 put " + url + " failed, code=" + strconv.Itoa(resp.StatusCode) + " " + string(body))
+	}
+	return url, nil
+}
+
+//sign request by AWS Signature Version 4
+func (store *S3BlobStore) sign(req *http.Request, payload []byte, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadSum := sha256.Sum256(payload)
+	payloadHash := hex.EncodeToString(payloadSum[:])
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signedHeaders := "content-type;host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := "content-type:" + req.Header.Get("Content-Type") + "\n" +
+		"host:" + req.URL.Host + "\n" +
+		"x-amz-content-sha256:" + payloadHash + "\n" +
+		"x-amz-date:" + amzDate + "\n"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + store.Region + "/s3/aws4_request"
+	requestSum := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestSum[:])
+
+	signingKey := hmacSha256([]byte("AWS4"+store.SecretKey), date)
+	signingKey = hmacSha256(signingKey, store.Region)
+	signingKey = hmacSha256(signingKey, "s3")
+	signingKey = hmacSha256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSha256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+store.AccessKey+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+func hmacSha256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
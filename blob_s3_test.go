@@ -0,0 +1,38 @@
+//go:build !tinygo && !logger_tiny
+// +build !tinygo,!logger_tiny
+
+package go_logger
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestS3BlobStore_Put(t *testing.T) {
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Path != "/logs/blobs/key" ||
+			!strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AK/") {
+			w.WriteHeader(http.StatusForbidden)
+		}
+	}))
+	defer server.Close()
+
+	store := &S3BlobStore{
+		Endpoint:  server.URL,
+		Region:    "us-east-1",
+		Bucket:    "logs",
+		AccessKey: "AK",
+		SecretKey: "SK",
+		Prefix:    "blobs/",
+	}
+	url, err := store.Put("key", []byte("data"))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if url != server.URL+"/logs/blobs/key" {
+		t.Error("s3 blob store url error: " + url)
+	}
+}
@@ -0,0 +1,39 @@
+package go_logger
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestLogger_SetBlobStore(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "logger_blob")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer os.RemoveAll(dir)
+
+	logger := NewLogger()
+	logger.SetBlobStore(&DiskBlobStore{Dir: dir, URLPrefix: "http://blobs/"}, 8)
+
+	loggerMsg := &loggerMessage{
+		Fields: map[string]interface{}{
+			"small": "tiny",
+			"body":  []byte("a very large response body"),
+		},
+	}
+	logger.externalize(loggerMsg)
+	ref, ok := loggerMsg.Fields["body"].(map[string]interface{})
+	if !ok || ref["size"] != 26 || !strings.HasPrefix(ref["url"].(string), "http://blobs/") {
+		t.Fatalf("logger blob store error: %v", loggerMsg.Fields)
+	}
+	if loggerMsg.Fields["small"] != "tiny" {
+		t.Error("logger blob store must keep small fields")
+	}
+	data, _ := ioutil.ReadFile(dir + "/" + ref["sha256"].(string) + ".blob")
+	if string(data) != "a very large response body" {
+		t.Error("disk blob store write error")
+	}
+}
@@ -0,0 +1,125 @@
+//go:build js && wasm
+// +build js,wasm
+
+package go_logger
+
+import (
+	"encoding/json"
+	"errors"
+	"syscall/js"
+)
+
+const BROWSER_ADAPTER_NAME = "browser"
+
+// browser console method of levels
+var levelBrowserMethods = map[int]string{
+	LoggerLevelEmergency: "error",
+	LoggerLevelAlert:     "error",
+	LoggerLevelCritical:  "error",
+	LoggerLevelError:     "error",
+	LoggerLevelWarning:   "warn",
+	LoggerLevelNotice:    "info",
+	LoggerLevelInfo:      "info",
+	LoggerLevelDebug:     "debug",
+}
+
+// adapter browser, write to the browser console by syscall/js
+type AdapterBrowser struct {
+	config *BrowserConfig
+}
+
+// browser config
+type BrowserConfig struct {
+
+	// is json format
+	JsonFormat bool
+
+	// jsonFormat is true, JSON field mapping profile
+	// "" default, "gelf" Graylog GELF, "ecs" Elastic Common Schema, "otel" OpenTelemetry
+	JsonProfile string
+
+	// jsonFormat is false, please input format string
+	// if format is empty, default format "%millisecond_format% [%level_string%] %body%"
+	// fields are passed to the console as an object, unless format has "%fields%"
+	Format string
+}
+
+func (bc *BrowserConfig) Name() string {
+	return BROWSER_ADAPTER_NAME
+}
+
+func NewAdapterBrowser() LoggerAbstract {
+	return &AdapterBrowser{
+		config: &BrowserConfig{},
+	}
+}
+
+func (adapterBrowser *AdapterBrowser) Init(browserConfig Config) error {
+	if browserConfig.Name() != BROWSER_ADAPTER_NAME {
+		return errors.New("logger browser adapter init error, config must BrowserConfig")
+	}
+
+	bc := browserConfig.(*BrowserConfig)
+	adapterBrowser.config = bc
+
+	if bc.JsonFormat == false && bc.Format == "" {
+		bc.Format = defaultLoggerMessageFormat
+	}
+	if err := checkJsonProfile(bc.JsonProfile); err != nil {
+		return err
+	}
+	if js.Global().Get("console").IsUndefined() {
+		return errors.New("logger browser adapter init error, console is undefined")
+	}
+	return nil
+}
+
+func (adapterBrowser *AdapterBrowser) Write(loggerMsg *loggerMessage) error {
+	method, ok := levelBrowserMethods[loggerMsg.Level]
+	if !ok {
+		method = "log"
+	}
+	console := js.Global().Get("console")
+
+	if adapterBrowser.config.JsonFormat {
+		jsonByte, err := loggerMessageJson(loggerMsg, adapterBrowser.config.JsonProfile)
+		if err != nil {
+			return err
+		}
+		console.Call(method, string(jsonByte))
+		return nil
+	}
+
+	// show fields as an expandable object
+	outputMsg := *loggerMsg
+	outputMsg.Fields = nil
+	msg := loggerMessageFormat(adapterBrowser.config.Format, &outputMsg)
+	if len(loggerMsg.Fields) == 0 {
+		console.Call(method, msg)
+		return nil
+	}
+	fieldsByte, err := json.Marshal(loggerMsg.Fields)
+	if err != nil {
+		console.Call(method, loggerMessageFormat(adapterBrowser.config.Format, loggerMsg))
+		return nil
+	}
+	console.Call(method, msg, js.Global().Get("JSON").Call("parse", string(fieldsByte)))
+	return nil
+}
+
+func (adapterBrowser *AdapterBrowser) Flush() {
+
+}
+
+func (adapterBrowser *AdapterBrowser) Name() string {
+	return BROWSER_ADAPTER_NAME
+}
+
+//new empty config of the adapter, describes the config fields
+func (adapterBrowser *AdapterBrowser) NewConfig() Config {
+	return &BrowserConfig{}
+}
+
+func init() {
+	Register(BROWSER_ADAPTER_NAME, NewAdapterBrowser)
+}
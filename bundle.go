@@ -0,0 +1,139 @@
+package go_logger
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"os"
+	"runtime"
+	"sync/atomic"
+	"time"
+)
+
+// health of an attached output in the support bundle
+type AdapterHealth struct {
+	Name string `json:"name"`
+
+	// write errors count
+	Errors   int64 `json:"errors"`
+	Silenced bool  `json:"silenced"`
+
+	// false for a lazy adapter still retrying Init, see AttachLazy
+	Initialized bool   `json:"initialized"`
+	InitError   string `json:"init_error,omitempty"`
+
+	// records buffered and dropped by a lazy adapter not initialized
+	Buffered int   `json:"buffered,omitempty"`
+	Dropped  int64 `json:"dropped,omitempty"`
+
+	// encoded record sizes, see SetRecordSizeMetrics
+	RecordSizes *RecordSizeStats `json:"record_sizes,omitempty"`
+}
+
+//write a support bundle, a gzip compressed tar archive of
+//	bundle.json   creation time, hostname, pid and go version
+//	config.json   configuration snapshot, see Config
+//	stats.json    pipeline stats, counts per level, dropped, stale and adapter errors
+//	health.json   health of the outputs
+//	records.jsonl recent records as JSON lines, oldest first, see SetRecentBuffer
+//params : w io.Writer
+//return : error
+func (logger *Logger) ExportBundle(w io.Writer) error {
+	now := time.Now()
+	hostname, _ := os.Hostname()
+	files := []struct {
+		name  string
+		value interface{}
+	}{
+		{"bundle.json", map[string]interface{}{
+			"created":        now.Format(time.RFC3339Nano),
+			"hostname":       hostname,
+			"pid":            os.Getpid(),
+			"go_version":     runtime.Version(),
+			"schema_version": LoggerMessageSchemaVersion,
+		}},
+		{"config.json", logger.Config()},
+		{"stats.json", logger.statsFields()},
+		{"health.json", logger.health()},
+	}
+
+	gzipWriter := gzip.NewWriter(w)
+	tarWriter := tar.NewWriter(gzipWriter)
+	for _, file := range files {
+		data, err := json.MarshalIndent(file.value, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := bundleWriteFile(tarWriter, file.name, data, now); err != nil {
+			return err
+		}
+	}
+
+	recent, _ := logger.recent.Load().(*recentBuffer)
+	records := &bytes.Buffer{}
+	for _, loggerMsg := range recent.snapshot() {
+		data, err := loggerMessageJson(loggerMsg, "")
+		if err != nil {
+			continue
+		}
+		records.Write(data)
+		records.WriteByte('\n')
+	}
+	if err := bundleWriteFile(tarWriter, "records.jsonl", records.Bytes(), now); err != nil {
+		return err
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return err
+	}
+	return gzipWriter.Close()
+}
+
+//write a file to the bundle archive
+func bundleWriteFile(tarWriter *tar.Writer, name string, data []byte, modTime time.Time) error {
+	err := tarWriter.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(data)),
+		ModTime: modTime,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = tarWriter.Write(data)
+	return err
+}
+
+//health of the outputs
+func (logger *Logger) health() []AdapterHealth {
+	outputs := logger.outputList()
+	healths := make([]AdapterHealth, 0, len(outputs))
+	for _, output := range outputs {
+		health := AdapterHealth{
+			Name:        output.Name,
+			Errors:      atomic.LoadInt64(&output.errors),
+			Silenced:    output.isSilenced(),
+			Initialized: true,
+		}
+		if lazy, ok := output.LoggerAbstract.(*lazyAdapter); ok && atomic.LoadInt32(&lazy.ready) == 0 {
+			lazy.lock.Lock()
+			health.Initialized = atomic.LoadInt32(&lazy.ready) == 1
+			if !health.Initialized {
+				if lazy.err != nil {
+					health.InitError = lazy.err.Error()
+				}
+				health.Buffered = len(lazy.buffer)
+				health.Dropped = lazy.dropped
+			}
+			lazy.lock.Unlock()
+		}
+		if output.recordSizes != nil {
+			stats := output.recordSizes.stats()
+			health.RecordSizes = &stats
+		}
+		healths = append(healths, health)
+	}
+	return healths
+}
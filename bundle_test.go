@@ -0,0 +1,76 @@
+package go_logger
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLogger_ExportBundle(t *testing.T) {
+
+	atomic.StoreInt32(&flakyAvailable, 0)
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.AttachLazy("flaky", LoggerLevelDebug, &countConfig{}, time.Hour, 10)
+	defer logger.Detach("flaky")
+	logger.SetRecentBuffer(2)
+	logger.Info("logger bundle 1")
+	logger.Info("logger bundle 2")
+	logger.Error("logger bundle 3")
+
+	buffer := &bytes.Buffer{}
+	if err := logger.ExportBundle(buffer); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	gzipReader, err := gzip.NewReader(buffer)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	files := map[string]string{}
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		data, _ := ioutil.ReadAll(tarReader)
+		files[header.Name] = string(data)
+	}
+
+	for _, name := range []string{"bundle.json", "config.json", "stats.json", "health.json", "records.jsonl"} {
+		if _, ok := files[name]; !ok {
+			t.Error("logger bundle must contain " + name)
+		}
+	}
+	records := strings.Split(strings.TrimSpace(files["records.jsonl"]), "\n")
+	if len(records) != 2 || !strings.Contains(records[0], "logger bundle 2") || !strings.Contains(records[1], "logger bundle 3") {
+		t.Error("logger bundle records error: " + files["records.jsonl"])
+	}
+
+	stats := map[string]interface{}{}
+	json.Unmarshal([]byte(files["stats.json"]), &stats)
+	if stats["total"] != float64(3) {
+		t.Error("logger bundle stats error: " + files["stats.json"])
+	}
+
+	healths := []AdapterHealth{}
+	json.Unmarshal([]byte(files["health.json"]), &healths)
+	if len(healths) != 1 || healths[0].Name != "flaky" || healths[0].Initialized ||
+		healths[0].InitError != "connection refused" || healths[0].Buffered != 3 {
+		t.Errorf("logger bundle health error: %+v", healths)
+	}
+	if !strings.Contains(files["config.json"], `"recent_buffer_size": 2`) {
+		t.Error("logger bundle config error: " + files["config.json"])
+	}
+}
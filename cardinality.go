@@ -0,0 +1,151 @@
+package go_logger
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"strconv"
+	"sync"
+)
+
+const (
+	// values over the limit are replaced by one of MaxValues buckets, "overflow_<bucket>"
+	CARDINALITY_ACTION_HASH = iota
+	// fields with values over the limit are dropped
+	CARDINALITY_ACTION_DROP
+	// values over the limit are kept, a warning is printed once per field
+	CARDINALITY_ACTION_WARN
+)
+
+// per-field cardinality guard, protects label-based backends from fields with exploding distinct values
+type CardinalityGuard struct {
+
+	// guarded field keys, empty guards all string fields
+	Fields []string
+
+	// max distinct values of a field, the first MaxValues values are kept as is
+	MaxValues int
+
+	// action of values over MaxValues
+	// CARDINALITY_ACTION_HASH | CARDINALITY_ACTION_DROP | CARDINALITY_ACTION_WARN
+	Action int
+}
+
+// distinct values seen by the guard of an output
+type cardinalityGuard struct {
+	CardinalityGuard
+	adapterName string
+	fields      map[string]bool
+
+	lock   sync.Mutex
+	values map[string]map[string]bool // distinct values of fields, at most MaxValues each
+	warned map[string]bool            // fields warned by CARDINALITY_ACTION_WARN
+}
+
+//set the cardinality guard of an attached output, distinct values are counted per output
+//params : adapterName string, guard *CardinalityGuard, nil to remove
+//return : error
+func (logger *Logger) SetCardinalityGuard(adapterName string, guard *CardinalityGuard) error {
+	if guard != nil {
+		if guard.MaxValues <= 0 {
+			return errors.New("logger: CardinalityGuard MaxValues must be positive!")
+		}
+		if guard.Action != CARDINALITY_ACTION_HASH && guard.Action != CARDINALITY_ACTION_DROP &&
+			guard.Action != CARDINALITY_ACTION_WARN {
+			return errors.New("logger: CardinalityGuard Action is illegal!")
+		}
+	}
+	logger.lock.Lock()
+	defer logger.lock.Unlock()
+
+	return logger.updateOutput(adapterName, func(output *outputLogger) error {
+		output.cardinality = nil
+		if guard != nil {
+			output.cardinality = newCardinalityGuard(adapterName, *guard)
+		}
+		return nil
+	})
+}
+
+func newCardinalityGuard(adapterName string, guard CardinalityGuard) *cardinalityGuard {
+	fields := map[string]bool{}
+	for _, field := range guard.Fields {
+		fields[field] = true
+	}
+	guard.Fields = append([]string{}, guard.Fields...)
+	return &cardinalityGuard{
+		CardinalityGuard: guard,
+		adapterName:      adapterName,
+		fields:           fields,
+		values:           map[string]map[string]bool{},
+		warned:           map[string]bool{},
+	}
+}
+
+//guard fields, return new fields if any value is over the limit
+func (guard *cardinalityGuard) guard(fields map[string]interface{}) map[string]interface{} {
+	guarded := fields
+	copied := false
+	guard.lock.Lock()
+	defer guard.lock.Unlock()
+
+	for key, value := range fields {
+		var s string
+		if len(guard.fields) == 0 {
+			str, ok := value.(string)
+			if !ok {
+				continue
+			}
+			s = str
+		} else if guard.fields[key] {
+			s = fmt.Sprint(value)
+		} else {
+			continue
+		}
+		if guard.allow(key, s) {
+			continue
+		}
+
+		if guard.Action == CARDINALITY_ACTION_WARN {
+			if !guard.warned[key] {
+				guard.warned[key] = true
+				fmt.Fprintf(os.Stderr, "logger: field %v of adapter:%v exceeds %d distinct values\n", key, guard.adapterName, guard.MaxValues)
+			}
+			continue
+		}
+		// the shared fields are copied before the first change
+		if !copied {
+			guarded = make(map[string]interface{}, len(fields))
+			for k, v := range fields {
+				guarded[k] = v
+			}
+			copied = true
+		}
+		if guard.Action == CARDINALITY_ACTION_DROP {
+			delete(guarded, key)
+			continue
+		}
+		h := fnv.New64a()
+		h.Write([]byte(s))
+		guarded[key] = "overflow_" + strconv.FormatUint(h.Sum64()%uint64(guard.MaxValues), 10)
+	}
+	return guarded
+}
+
+//value is one of the first MaxValues distinct values of the field
+func (guard *cardinalityGuard) allow(key string, value string) bool {
+	values, ok := guard.values[key]
+	if !ok {
+		values = map[string]bool{}
+		guard.values[key] = values
+	}
+	if values[value] {
+		return true
+	}
+	if len(values) < guard.MaxValues {
+		values[value] = true
+		return true
+	}
+	return false
+}
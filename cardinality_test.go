@@ -0,0 +1,73 @@
+package go_logger
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCardinalityGuard_guard(t *testing.T) {
+
+	guard := newCardinalityGuard("console", CardinalityGuard{MaxValues: 2, Action: CARDINALITY_ACTION_HASH})
+	for _, user := range []string{"u1", "u2", "u1"} {
+		fields := map[string]interface{}{"user": user, "status": 200}
+		if guarded := guard.guard(fields); guarded["user"] != user {
+			t.Errorf("cardinality guard must keep the first values: %v", guarded)
+		}
+	}
+	fields := map[string]interface{}{"user": "u3", "status": 200}
+	guarded := guard.guard(fields)
+	if user, _ := guarded["user"].(string); !strings.HasPrefix(user, "overflow_") || guarded["status"] != 200 {
+		t.Errorf("cardinality guard hash error: %v", guarded)
+	}
+	if fields["user"] != "u3" {
+		t.Error("cardinality guard must not change the shared fields")
+	}
+	if again := guard.guard(map[string]interface{}{"user": "u3"}); again["user"] != guarded["user"] {
+		t.Error("cardinality guard hash must be stable")
+	}
+
+	guard = newCardinalityGuard("console", CardinalityGuard{Fields: []string{"code"}, MaxValues: 1, Action: CARDINALITY_ACTION_DROP})
+	guard.guard(map[string]interface{}{"code": 1})
+	guarded = guard.guard(map[string]interface{}{"code": 2, "user": "u1"})
+	if _, ok := guarded["code"]; ok || guarded["user"] != "u1" {
+		t.Errorf("cardinality guard drop error: %v", guarded)
+	}
+
+	guard = newCardinalityGuard("console", CardinalityGuard{MaxValues: 1, Action: CARDINALITY_ACTION_WARN})
+	guard.guard(map[string]interface{}{"user": "u1"})
+	guarded = guard.guard(map[string]interface{}{"user": "u2"})
+	if guarded["user"] != "u2" || !guard.warned["user"] {
+		t.Errorf("cardinality guard warn error: %v", guarded)
+	}
+}
+
+func TestLogger_SetCardinalityGuard(t *testing.T) {
+
+	logger := NewLogger()
+	if logger.SetCardinalityGuard(CONSOLE_ADAPTER_NAME, &CardinalityGuard{}) == nil {
+		t.Error("cardinality guard without MaxValues must be error")
+	}
+	if logger.SetCardinalityGuard(CONSOLE_ADAPTER_NAME, &CardinalityGuard{MaxValues: 1, Action: 10}) == nil {
+		t.Error("cardinality guard illegal action must be error")
+	}
+	if logger.SetCardinalityGuard("file", &CardinalityGuard{MaxValues: 1}) == nil {
+		t.Error("cardinality guard of not attached adapter must be error")
+	}
+
+	logger.Detach(CONSOLE_ADAPTER_NAME)
+	logger.Attach(CONSOLE_ADAPTER_NAME, LoggerLevelDebug, &ConsoleConfig{Format: "%body% %fields%"})
+	buffer := &bytes.Buffer{}
+	logger.outputList()[0].LoggerAbstract.(*AdapterConsole).write.writer = buffer
+	err := logger.SetCardinalityGuard(CONSOLE_ADAPTER_NAME, &CardinalityGuard{MaxValues: 1, Action: CARDINALITY_ACTION_DROP})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	logger.Infow("login", "user", "u1")
+	logger.Infow("login", "user", "u2")
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], "u1") || strings.Contains(lines[1], "u2") {
+		t.Errorf("cardinality guard output error: %q", buffer.String())
+	}
+}
@@ -0,0 +1,148 @@
+package go_logger
+
+import (
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+const CHAOS_ADAPTER_NAME = "chaos"
+
+// adapter chaos, wrap another adapter and inject faults
+type AdapterChaos struct {
+	lock    sync.Mutex
+	rand    *rand.Rand
+	adapter LoggerAbstract
+	config  *ChaosConfig
+}
+
+// chaos config
+type ChaosConfig struct {
+
+	// wrapped adapter name
+	// console, file, api ...
+	Adapter string `config:"required"`
+
+	// wrapped adapter config
+	Config Config `config:"required"`
+
+	// fixed latency before every write
+	Latency time.Duration
+
+	// random latency between 0 and LatencyJitter added to Latency
+	LatencyJitter time.Duration
+
+	// probability (0 ~ 1) that a write returns an error
+	ErrorRate float64
+
+	// error returned when ErrorRate hit
+	// if empty, default "logger chaos adapter injected error"
+	ErrorMessage string
+
+	// probability (0 ~ 1) that a write is silently dropped
+	DropRate float64
+
+	// random seed, 0 means seed by time
+	Seed int64
+}
+
+func (cc *ChaosConfig) Name() string {
+	return CHAOS_ADAPTER_NAME
+}
+
+func NewAdapterChaos() LoggerAbstract {
+	return &AdapterChaos{
+		config: &ChaosConfig{},
+	}
+}
+
+func (adapterChaos *AdapterChaos) Init(chaosConfig Config) error {
+	if chaosConfig.Name() != CHAOS_ADAPTER_NAME {
+		return errors.New("logger chaos adapter init error, config must ChaosConfig")
+	}
+
+	cc := chaosConfig.(*ChaosConfig)
+	adapterChaos.config = cc
+
+	if cc.Adapter == "" || cc.Adapter == CHAOS_ADAPTER_NAME {
+		return errors.New("config Adapter must be another adapter name!")
+	}
+	if cc.Config == nil {
+		return errors.New("config Config cannot be nil!")
+	}
+	if cc.ErrorRate < 0 || cc.ErrorRate > 1 || cc.DropRate < 0 || cc.DropRate > 1 {
+		return errors.New("config ErrorRate and DropRate must between 0 and 1!")
+	}
+	if cc.ErrorMessage == "" {
+		cc.ErrorMessage = "logger chaos adapter injected error"
+	}
+
+	logFun, ok := adapters[cc.Adapter]
+	if !ok {
+		return errors.New("config Adapter " + cc.Adapter + " is not registered!")
+	}
+	adapter := logFun()
+	err := adapter.Init(cc.Config)
+	if err != nil {
+		return err
+	}
+	adapterChaos.adapter = adapter
+
+	seed := cc.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	adapterChaos.rand = rand.New(rand.NewSource(seed))
+	return nil
+}
+
+func (adapterChaos *AdapterChaos) Write(loggerMsg *loggerMessage) error {
+	config := adapterChaos.config
+
+	// rand.Rand is not safe for concurrent use
+	adapterChaos.lock.Lock()
+	latency := config.Latency
+	if config.LatencyJitter > 0 {
+		latency += time.Duration(adapterChaos.rand.Int63n(int64(config.LatencyJitter)))
+	}
+	isDrop := adapterChaos.rand.Float64() < config.DropRate
+	isError := adapterChaos.rand.Float64() < config.ErrorRate
+	adapterChaos.lock.Unlock()
+
+	if latency > 0 {
+		time.Sleep(latency)
+	}
+	if isDrop {
+		return nil
+	}
+	if isError {
+		return errors.New(config.ErrorMessage)
+	}
+	return adapterChaos.adapter.Write(loggerMsg)
+}
+
+func (adapterChaos *AdapterChaos) Flush() {
+	adapterChaos.FlushError()
+}
+
+func (adapterChaos *AdapterChaos) FlushError() error {
+	if flusher, ok := adapterChaos.adapter.(ErrorFlusher); ok {
+		return flusher.FlushError()
+	}
+	adapterChaos.adapter.Flush()
+	return nil
+}
+
+func (adapterChaos *AdapterChaos) Name() string {
+	return CHAOS_ADAPTER_NAME
+}
+
+//new empty config of the adapter, describes the config fields
+func (adapterChaos *AdapterChaos) NewConfig() Config {
+	return &ChaosConfig{}
+}
+
+func init() {
+	Register(CHAOS_ADAPTER_NAME, NewAdapterChaos)
+}
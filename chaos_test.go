@@ -0,0 +1,65 @@
+package go_logger
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAdapterChaos_Init(t *testing.T) {
+
+	chaosAdapter := NewAdapterChaos()
+
+	err := chaosAdapter.Init(&ChaosConfig{})
+	if err == nil {
+		t.Error("chaos adapter init without Adapter must be error")
+	}
+	err = chaosAdapter.Init(&ChaosConfig{
+		Adapter: CONSOLE_ADAPTER_NAME,
+		Config:  &ConsoleConfig{},
+	})
+	if err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func TestAdapterChaos_Write(t *testing.T) {
+
+	loggerMsg := &loggerMessage{
+		Timestamp:         time.Now().Unix(),
+		TimestampFormat:   time.Now().Format("2006-01-02 15:04:05"),
+		Millisecond:       time.Now().UnixNano() / 1e6,
+		MillisecondFormat: time.Now().Format("2006-01-02 15:04:05.999"),
+		Level:             LoggerLevelDebug,
+		LevelString:       "debug",
+		Body:              "logger chaos adapter test",
+		File:              "chaos_test.go",
+		Line:              36,
+		Function:          "TestAdapterChaos_Write",
+	}
+
+	chaosAdapter := NewAdapterChaos()
+	chaosAdapter.Init(&ChaosConfig{
+		Adapter:   CONSOLE_ADAPTER_NAME,
+		Config:    &ConsoleConfig{},
+		ErrorRate: 1,
+	})
+	if chaosAdapter.Write(loggerMsg) == nil {
+		t.Error("chaos adapter ErrorRate 1 must return error")
+	}
+
+	chaosAdapter = NewAdapterChaos()
+	chaosAdapter.Init(&ChaosConfig{
+		Adapter:   CONSOLE_ADAPTER_NAME,
+		Config:    &ConsoleConfig{},
+		ErrorRate: 1,
+		DropRate:  1,
+		Latency:   10 * time.Millisecond,
+	})
+	start := time.Now()
+	if chaosAdapter.Write(loggerMsg) != nil {
+		t.Error("chaos adapter dropped message must not return error")
+	}
+	if time.Since(start) < 10*time.Millisecond {
+		t.Error("chaos adapter latency not injected")
+	}
+}
@@ -0,0 +1,319 @@
+//go:build !tinygo && !logger_tiny
+// +build !tinygo,!logger_tiny
+
+package go_logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const CLICKHOUSE_ADAPTER_NAME = "clickhouse"
+
+// adapter clickhouse, messages are batched into inserts of a MergeTree table by the HTTP interface
+// a row per message in JSONEachRow format, columns missing in the table are skipped
+type AdapterClickhouse struct {
+	lock       sync.Mutex
+	sendLock   sync.Mutex // batches are inserted in order
+	config     *ClickhouseConfig
+	client     *http.Client
+	insert     string     // insert url
+	compressor Compressor // insert body compressor, nil is not compressed
+	rows       *bytes.Buffer
+	count      int
+	timer      *time.Timer
+	failed     error // error of the last timer insert, reported by FlushAck
+}
+
+// clickhouse config
+type ClickhouseConfig struct {
+
+	// HTTP interface url, "http://127.0.0.1:8123"
+	Url string `config:"required"`
+
+	// user and password, sent as X-ClickHouse-User and X-ClickHouse-Key, empty is the default user
+	Username string
+	Password string
+
+	// database, default "default"
+	Database string
+
+	// table, columns:
+	//	timestamp DateTime64(3), level UInt8, level_string LowCardinality(String), name LowCardinality(String),
+	//	body String, file String, line UInt32, function String, fields String (JSON object)
+	// the table may have a subset of the columns, and FieldColumns
+	Table string `config:"required"`
+
+	// create the table if it does not exist, a MergeTree table partitioned by day, ordered by timestamp
+	CreateTable bool
+
+	// days of the table TTL of CreateTable, older rows are removed by merges, 0 keeps all rows
+	TTLDays int
+
+	// message fields also written as their own columns, e.g. "request_id", the columns must exist
+	FieldColumns []string
+
+	// rows per insert, default 10000
+	BatchSize int
+
+	// max wait before a batch is inserted, default 5 seconds
+	BatchWait time.Duration
+
+	// insert body compression, a registered compressor name e.g. "gzip", sent as Content-Encoding
+	// the server must support it: gzip, deflate, br, xz, zstd, lz4 or bz2; default none
+	Compression string
+
+	// insert request timeout, default 30 seconds
+	Timeout time.Duration
+}
+
+func (cc *ClickhouseConfig) Name() string {
+	return CLICKHOUSE_ADAPTER_NAME
+}
+
+func NewAdapterClickhouse() LoggerAbstract {
+	return &AdapterClickhouse{
+		config: &ClickhouseConfig{},
+		rows:   &bytes.Buffer{},
+	}
+}
+
+func (adapterClickhouse *AdapterClickhouse) Init(clickhouseConfig Config) error {
+	if clickhouseConfig.Name() != CLICKHOUSE_ADAPTER_NAME {
+		return errors.New("logger clickhouse adapter init error, config must ClickhouseConfig")
+	}
+
+	cc := clickhouseConfig.(*ClickhouseConfig)
+	adapterClickhouse.config = cc
+
+	if cc.Url == "" {
+		return errors.New("config Url cannot be empty!")
+	}
+	if cc.Table == "" {
+		return errors.New("config Table cannot be empty!")
+	}
+	if cc.Database == "" {
+		cc.Database = "default"
+	}
+	if cc.TTLDays < 0 || cc.BatchSize < 0 || cc.BatchWait < 0 || cc.Timeout < 0 {
+		return errors.New("config TTLDays, BatchSize, BatchWait and Timeout cannot be negative!")
+	}
+	if cc.BatchSize == 0 {
+		cc.BatchSize = 10000
+	}
+	if cc.BatchWait == 0 {
+		cc.BatchWait = 5 * time.Second
+	}
+	if cc.Timeout == 0 {
+		cc.Timeout = 30 * time.Second
+	}
+	table := clickhouseIdentifier(cc.Database) + "." + clickhouseIdentifier(cc.Table)
+	insert, err := clickhouseUrl(cc.Url, "INSERT INTO "+table+" FORMAT JSONEachRow")
+	if err != nil {
+		return err
+	}
+	adapterClickhouse.insert = insert
+	adapterClickhouse.client = &http.Client{Timeout: cc.Timeout}
+	if adapterClickhouse.compressor, err = compressorByName(cc.Compression); err != nil {
+		return err
+	}
+
+	if !cc.CreateTable {
+		return nil
+	}
+	create := "CREATE TABLE IF NOT EXISTS " + table + " (" +
+		"timestamp DateTime64(3), level UInt8, level_string LowCardinality(String), name LowCardinality(String), " +
+		"body String, file String, line UInt32, function String, fields String" +
+		") ENGINE = MergeTree PARTITION BY toDate(timestamp) ORDER BY timestamp"
+	if cc.TTLDays > 0 {
+		create += " TTL toDateTime(timestamp) + INTERVAL " + strconv.Itoa(cc.TTLDays) + " DAY"
+	}
+	createUrl, err := clickhouseUrl(cc.Url, "")
+	if err != nil {
+		return err
+	}
+	return adapterClickhouse.request(createUrl, []byte(create), nil)
+}
+
+//url of a query, unknown JSONEachRow fields are skipped, query params of the config url are kept
+func clickhouseUrl(rawUrl string, query string) (string, error) {
+	u, err := url.Parse(rawUrl)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", errors.New("config Url must be http://host:8123 or https://host:8443!")
+	}
+	values := u.Query()
+	if query != "" {
+		values.Set("query", query)
+		values.Set("input_format_skip_unknown_fields", "1")
+	}
+	u.RawQuery = values.Encode()
+	return u.String(), nil
+}
+
+//quoted identifier, `name`
+func clickhouseIdentifier(name string) string {
+	return "`" + strings.NewReplacer("\\", "\\\\", "`", "\\`").Replace(name) + "`"
+}
+
+func (adapterClickhouse *AdapterClickhouse) Write(loggerMsg *loggerMessage) error {
+	config := adapterClickhouse.config
+	fields := "{}"
+	if len(loggerMsg.Fields) > 0 {
+		data, err := json.Marshal(loggerMsg.Fields)
+		if err != nil {
+			return err
+		}
+		fields = string(data)
+	}
+	row := map[string]interface{}{
+		"timestamp":    loggerMsg.Millisecond,
+		"level":        loggerMsg.Level,
+		"level_string": loggerMsg.LevelString,
+		"name":         loggerMsg.Name,
+		"body":         loggerMsg.Body,
+		"file":         loggerMsg.File,
+		"line":         loggerMsg.Line,
+		"function":     loggerMsg.Function,
+		"fields":       fields,
+	}
+	for _, column := range config.FieldColumns {
+		if value, ok := loggerMsg.Fields[column]; ok {
+			row[column] = value
+		}
+	}
+	data, err := json.Marshal(row)
+	if err != nil {
+		return err
+	}
+
+	adapterClickhouse.lock.Lock()
+	adapterClickhouse.rows.Write(data)
+	adapterClickhouse.rows.WriteByte('\n')
+	adapterClickhouse.count++
+	full := adapterClickhouse.count >= config.BatchSize
+	// the first row of a batch arms the insert timer
+	if !full && adapterClickhouse.timer == nil {
+		adapterClickhouse.timer = time.AfterFunc(config.BatchWait, func() {
+			if err := adapterClickhouse.push(); err != nil {
+				adapterClickhouse.lock.Lock()
+				adapterClickhouse.failed = err
+				adapterClickhouse.lock.Unlock()
+				fmt.Fprintf(os.Stderr, "logger: unable insert batch to adapter:%v, error: %v\n", CLICKHOUSE_ADAPTER_NAME, err)
+			}
+		})
+	}
+	adapterClickhouse.lock.Unlock()
+
+	if full {
+		return adapterClickhouse.push()
+	}
+	return nil
+}
+
+//insert the pending batch, nothing if empty
+//return : error, the batch is dropped on error
+func (adapterClickhouse *AdapterClickhouse) push() error {
+	adapterClickhouse.sendLock.Lock()
+	defer adapterClickhouse.sendLock.Unlock()
+
+	adapterClickhouse.lock.Lock()
+	if adapterClickhouse.timer != nil {
+		adapterClickhouse.timer.Stop()
+		adapterClickhouse.timer = nil
+	}
+	if adapterClickhouse.count == 0 {
+		adapterClickhouse.lock.Unlock()
+		return nil
+	}
+	rows := adapterClickhouse.rows
+	adapterClickhouse.rows = &bytes.Buffer{}
+	adapterClickhouse.count = 0
+	adapterClickhouse.lock.Unlock()
+
+	return adapterClickhouse.request(adapterClickhouse.insert, rows.Bytes(), adapterClickhouse.compressor)
+}
+
+//post a query, body is the query or the inserted rows, compressed if compressor is not nil
+func (adapterClickhouse *AdapterClickhouse) request(requestUrl string, body []byte, compressor Compressor) error {
+	config := adapterClickhouse.config
+	if compressor != nil {
+		compressed, err := compressBytes(compressor, body)
+		if err != nil {
+			return err
+		}
+		body = compressed
+	}
+	request, err := http.NewRequest("POST", requestUrl, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if compressor != nil {
+		request.Header.Set("Content-Encoding", compressor.Name())
+	}
+	if config.Username != "" {
+		request.Header.Set("X-ClickHouse-User", config.Username)
+		request.Header.Set("X-ClickHouse-Key", config.Password)
+	}
+
+	response, err := adapterClickhouse.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		message, _ := ioutil.ReadAll(io.LimitReader(response.Body, 512))
+		return errors.New("request " + config.Url + " failed, code=" + strconv.Itoa(response.StatusCode) + ", " + strings.TrimSpace(string(message)))
+	}
+	return nil
+}
+
+func (adapterClickhouse *AdapterClickhouse) Flush() {
+	adapterClickhouse.FlushError()
+}
+
+//insert the pending batch
+func (adapterClickhouse *AdapterClickhouse) FlushError() error {
+	return adapterClickhouse.push()
+}
+
+//insert the pending batch and wait for the insert response up to timeout, after the inserts in flight
+//a failed timer insert since the last FlushAck is reported too
+func (adapterClickhouse *AdapterClickhouse) FlushAck(timeout time.Duration) error {
+	err := waitAck(timeout, adapterClickhouse.push)
+	adapterClickhouse.lock.Lock()
+	if err == nil {
+		err = adapterClickhouse.failed
+	}
+	adapterClickhouse.failed = nil
+	adapterClickhouse.lock.Unlock()
+	return err
+}
+
+//insert the pending batch and stop the timer, the adapter must not be written after Close
+func (adapterClickhouse *AdapterClickhouse) Close() error {
+	return adapterClickhouse.push()
+}
+
+func (adapterClickhouse *AdapterClickhouse) Name() string {
+	return CLICKHOUSE_ADAPTER_NAME
+}
+
+//new empty config of the adapter, describes the config fields
+func (adapterClickhouse *AdapterClickhouse) NewConfig() Config {
+	return &ClickhouseConfig{}
+}
+
+func init() {
+	Register(CLICKHOUSE_ADAPTER_NAME, NewAdapterClickhouse)
+}
@@ -0,0 +1,204 @@
+//go:build !tinygo && !logger_tiny
+// +build !tinygo,!logger_tiny
+
+package go_logger
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// query received by a fake ClickHouse
+type clickhouseQuery struct {
+	query string
+	user  string
+	rows  []map[string]interface{}
+}
+
+// fake ClickHouse HTTP interface, inserts of table `reject` fail
+type clickhouseServer struct {
+	lock    sync.Mutex
+	queries []clickhouseQuery
+}
+
+func (server *clickhouseServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var body io.Reader = r.Body
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		reader, err := gzip.NewReader(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		body = reader
+	}
+	received := clickhouseQuery{query: r.URL.Query().Get("query"), user: r.Header.Get("X-ClickHouse-User")}
+	if received.query == "" {
+		data, _ := ioutil.ReadAll(body)
+		received.query = string(data)
+	} else {
+		scanner := bufio.NewScanner(body)
+		for scanner.Scan() {
+			row := map[string]interface{}{}
+			if err := json.Unmarshal(scanner.Bytes(), &row); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			received.rows = append(received.rows, row)
+		}
+	}
+	server.lock.Lock()
+	server.queries = append(server.queries, received)
+	server.lock.Unlock()
+	if strings.Contains(received.query, "`reject`") {
+		http.Error(w, "Code: 60. DB::Exception: Table default.reject does not exist", http.StatusNotFound)
+	}
+}
+
+func (server *clickhouseServer) received() []clickhouseQuery {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+	return append([]clickhouseQuery{}, server.queries...)
+}
+
+func TestAdapterClickhouse_Init(t *testing.T) {
+
+	configs := []*ClickhouseConfig{
+		{},
+		{Url: "http://127.0.0.1:8123"},
+		{Url: "tcp://127.0.0.1:9000", Table: "logs"},
+		{Url: "http://127.0.0.1:8123", Table: "logs", BatchSize: -1},
+	}
+	for _, config := range configs {
+		if NewAdapterClickhouse().Init(config) == nil {
+			t.Errorf("clickhouse adapter config %+v must be error", config)
+		}
+	}
+
+	server := &clickhouseServer{}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	err := NewAdapterClickhouse().Init(&ClickhouseConfig{
+		Url:         httpServer.URL,
+		Database:    "audit",
+		Table:       "app",
+		CreateTable: true,
+		TTLDays:     30,
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	queries := server.received()
+	if len(queries) != 1 || !strings.HasPrefix(queries[0].query, "CREATE TABLE IF NOT EXISTS `audit`.`app` (") ||
+		!strings.Contains(queries[0].query, "ENGINE = MergeTree") || !strings.HasSuffix(queries[0].query, "INTERVAL 30 DAY") {
+		t.Errorf("clickhouse create table query %+v", queries)
+	}
+
+	if NewAdapterClickhouse().Init(&ClickhouseConfig{Url: httpServer.URL, Table: "reject", CreateTable: true}) == nil {
+		t.Error("clickhouse adapter must return the create table error")
+	}
+}
+
+func TestAdapterClickhouse_Write(t *testing.T) {
+
+	server := &clickhouseServer{}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	clickhouseAdapter := NewAdapterClickhouse()
+	err := clickhouseAdapter.Init(&ClickhouseConfig{
+		Url:          httpServer.URL,
+		Username:     "logger",
+		Table:        "app",
+		FieldColumns: []string{"request_id"},
+		BatchSize:    2,
+		BatchWait:    time.Hour,
+		Compression:  "gzip",
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	messages := []*loggerMessage{
+		{Millisecond: 1500000000123, Level: LoggerLevelInfo, LevelString: "Info", Body: "logger clickhouse 1", Name: "api", Line: 10,
+			Fields: map[string]interface{}{"request_id": "r1", "took": 5}},
+		{Millisecond: 1500000000124, Level: LoggerLevelError, LevelString: "Error", Body: "logger clickhouse 2"},
+		{Millisecond: 1500000000125, Level: LoggerLevelInfo, LevelString: "Info", Body: "logger clickhouse 3"},
+	}
+	for _, loggerMsg := range messages {
+		if err := clickhouseAdapter.Write(loggerMsg); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+
+	queries := server.received()
+	if len(queries) != 1 || queries[0].query != "INSERT INTO `default`.`app` FORMAT JSONEachRow" ||
+		queries[0].user != "logger" || len(queries[0].rows) != 2 {
+		t.Fatalf("clickhouse adapter must insert a full batch of 2 rows: %+v", queries)
+	}
+	row := queries[0].rows[0]
+	if row["timestamp"] != float64(1500000000123) || row["level"] != float64(LoggerLevelInfo) || row["level_string"] != "Info" ||
+		row["body"] != "logger clickhouse 1" || row["name"] != "api" || row["line"] != float64(10) ||
+		row["fields"] != `{"request_id":"r1","took":5}` || row["request_id"] != "r1" {
+		t.Errorf("clickhouse adapter row error: %v", row)
+	}
+	if _, ok := queries[0].rows[1]["request_id"]; ok || queries[0].rows[1]["fields"] != "{}" {
+		t.Errorf("clickhouse adapter row without fields error: %v", queries[0].rows[1])
+	}
+
+	if err := clickhouseAdapter.(*AdapterClickhouse).FlushError(); err != nil {
+		t.Fatal(err.Error())
+	}
+	queries = server.received()
+	if len(queries) != 2 || len(queries[1].rows) != 1 || queries[1].rows[0]["body"] != "logger clickhouse 3" {
+		t.Errorf("clickhouse adapter flush must insert the pending batch: %+v", queries)
+	}
+}
+
+func TestAdapterClickhouse_BatchWait(t *testing.T) {
+
+	server := &clickhouseServer{}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	clickhouseAdapter := NewAdapterClickhouse()
+	err := clickhouseAdapter.Init(&ClickhouseConfig{Url: httpServer.URL, Table: "app", BatchWait: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer clickhouseAdapter.(*AdapterClickhouse).Close()
+	clickhouseAdapter.Write(&loggerMessage{LevelString: "Info", Body: "logger clickhouse wait"})
+
+	deadline := time.Now().Add(5 * time.Second)
+	for len(server.received()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("clickhouse adapter must insert after BatchWait")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if rows := server.received()[0].rows; len(rows) != 1 || rows[0]["body"] != "logger clickhouse wait" {
+		t.Errorf("clickhouse adapter rows %v", rows)
+	}
+}
+
+func TestAdapterClickhouse_WriteError(t *testing.T) {
+
+	httpServer := httptest.NewServer(&clickhouseServer{})
+	defer httpServer.Close()
+
+	clickhouseAdapter := NewAdapterClickhouse()
+	clickhouseAdapter.Init(&ClickhouseConfig{Url: httpServer.URL, Table: "reject", BatchSize: 1})
+	err := clickhouseAdapter.Write(&loggerMessage{LevelString: "Info", Body: "logger clickhouse"})
+	if err == nil || !strings.Contains(err.Error(), "code=404") {
+		t.Errorf("clickhouse adapter must return the insert error: %v", err)
+	}
+}
@@ -0,0 +1,173 @@
+package go_logger
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// option applied to a cloned logger
+type Option func(logger *Logger) error
+
+//attach an adapter to the cloned logger
+//params : adapterName string, level int, config Config
+func OptionAttach(adapterName string, level int, config Config) Option {
+	return func(logger *Logger) error {
+		return logger.Attach(adapterName, level, config)
+	}
+}
+
+//detach an adapter from the cloned logger, the source logger keeps it
+//params : adapterName string
+func OptionDetach(adapterName string) Option {
+	return func(logger *Logger) error {
+		if logger.output(adapterName) == nil {
+			return errors.New("logger: adapter " + adapterName + " is not attached!")
+		}
+		return logger.Detach(adapterName)
+	}
+}
+
+//set the level of an output of the cloned logger
+//params : adapterName string, level int
+func OptionLevel(adapterName string, level int) Option {
+	return func(logger *Logger) error {
+		return logger.SetLevel(adapterName, level)
+	}
+}
+
+//set the cloned logger async, or sync if length is 0
+//params : length int, message channel length
+func OptionAsync(length int) Option {
+	return func(logger *Logger) error {
+		if length < 0 {
+			return errors.New("logger: async channel length cannot be negative!")
+		}
+		if length == 0 {
+			logger.SetSync()
+			return nil
+		}
+		logger.SetAsync(length)
+		return nil
+	}
+}
+
+//new independent logger with the configuration of the logger, then options applied
+//adapters are shared with the logger, outputs (level, schedule, silence, retries...) are copied
+//and pipeline settings (routes, sampling, sanitizer...) are copied, so both can diverge;
+//stats, recent messages, crash handler and async queue are not copied
+//params : opts ...Option
+//return : *Logger, error of the first failed option
+func (logger *Logger) Clone(opts ...Option) (*Logger, error) {
+	logger.lock.Lock()
+	clone := NewLogger()
+	clone.detach(CONSOLE_ADAPTER_NAME)
+
+	outputs := make([]*outputLogger, 0, len(logger.outputList()))
+	for _, output := range logger.outputList() {
+		outputs = append(outputs, output.clone())
+	}
+	clone.outputs.Store(outputs)
+
+	clone.strictMode = atomic.LoadInt32(&logger.strictMode)
+	clone.levelDisplayStrings.Store(logger.levelDisplayStrings.Load())
+	clone.messageCatalog.Store(logger.messageCatalog.Load())
+	clone.routeRules.Store(logger.routeRules.Load())
+	clone.rewriters.Store(logger.rewriters.Load())
+	clone.sanitizer.Store(logger.sanitizer.Load())
+	clone.binaryEncoding.Store(logger.binaryEncoding.Load())
+	clone.blobStore.Store(logger.blobStore.Load())
+	if sampler, _ := logger.errorSampler.Load().(*errorSampler); sampler != nil {
+		sampler.lock.Lock()
+		clone.errorSampler.Store(&errorSampler{
+			interval:  sampler.interval,
+			expiry:    sampler.expiry,
+			samples:   map[uint64]*errorSample{},
+			exemplars: sampler.exemplars,
+		})
+		sampler.lock.Unlock()
+	}
+	if quota, _ := logger.quota.Load().(*quota); quota != nil {
+		clone.quota.Store(newQuota(quota.QuotaConfig))
+	}
+	clone.queueTTL = atomic.LoadInt64(&logger.queueTTL)
+	clone.deadLetterAdapter.Store(logger.deadLetterAdapter.Load())
+	if recent, _ := logger.recent.Load().(*recentBuffer); recent != nil {
+		clone.recent.Store(&recentBuffer{messages: make([]*loggerMessage, len(recent.messages))})
+	}
+	clone.fatalPolicy = logger.fatalPolicy
+	clone.fatalHooks = append([]func(){}, logger.fatalHooks...)
+	if hooks, ok := logger.dropHooks.Load().([]DropHook); ok {
+		clone.dropHooks.Store(hooks)
+	}
+	clone.enqueueTimeout = logger.enqueueTimeout
+	clone.overflowPolicy = logger.overflowPolicy
+	clone.strictOrdering = logger.strictOrdering
+	clone.disabled = atomic.LoadInt32(&logger.disabled)
+	clone.contextDeadlineFields = atomic.LoadInt32(&logger.contextDeadlineFields)
+	clone.errorFingerprint.Store(logger.errorFingerprint.Load())
+	clone.contextKeys.Store(logger.contextKeys.Load())
+	clone.namedLevels.Store(logger.namedLevels.Load())
+	clone.tags.Store(logger.tags.Load())
+	clone.flushAckTimeout.Store(logger.flushAckTimeout.Load())
+
+	logger.asyncLock.RLock()
+	asyncChanLen := 0
+	if !logger.synchronous {
+		asyncChanLen = cap(logger.msgChan)
+	}
+	logger.asyncLock.RUnlock()
+	logger.lock.Unlock()
+
+	if asyncChanLen > 0 {
+		clone.SetAsync(asyncChanLen)
+	}
+	for _, opt := range opts {
+		if err := opt(clone); err != nil {
+			return nil, err
+		}
+	}
+	return clone, nil
+}
+
+//copy of output sharing the adapter, counters are reset
+func (output *outputLogger) clone() *outputLogger {
+	clone := &outputLogger{
+		outputState: &outputState{
+			silenceUntil: atomic.LoadInt64(&output.silenceUntil),
+			level:        int64(output.getLevel()),
+		},
+		Name:           output.Name,
+		LoggerAbstract: output.LoggerAbstract,
+		config:         output.config,
+		schedule:       output.schedule,
+		normalizer:     output.normalizer,
+		retries:        output.retries,
+		clockSkew:      output.clockSkew,
+		tags:           output.tags,
+	}
+	if output.intern != nil {
+		output.intern.lock.Lock()
+		size := len(output.intern.keys)
+		output.intern.lock.Unlock()
+		clone.intern = &internTable{
+			keys: make([]uint64, size),
+			seen: make(map[uint64]bool, size),
+		}
+	}
+	if offload := output.offload; offload != nil {
+		clone.offload = newOutputOffload(offload.workers, cap(offload.queue))
+	}
+	if output.cardinality != nil {
+		clone.cardinality = newCardinalityGuard(output.Name, output.cardinality.CardinalityGuard)
+	}
+	if sizes := output.recordSizes; sizes != nil {
+		clone.recordSizes = &recordSizes{
+			adapterName: sizes.adapterName,
+			format:      sizes.format,
+			jsonProfile: sizes.jsonProfile,
+			warnBytes:   sizes.warnBytes,
+			buckets:     make([]int64, len(sizes.buckets)),
+		}
+	}
+	return clone
+}
@@ -0,0 +1,67 @@
+package go_logger
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestLogger_Clone(t *testing.T) {
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("console", LoggerLevelInfo, &ConsoleConfig{
+		Format: "%body% %fields%",
+	})
+	buffer := &bytes.Buffer{}
+	logger.outputList()[0].LoggerAbstract.(*AdapterConsole).write.writer = buffer
+	logger.SetErrorSampling(time.Minute, 0)
+	logger.SetRecentBuffer(10)
+
+	clone, err := logger.Clone(OptionLevel("console", LoggerLevelDebug), OptionAttach("count", LoggerLevelDebug, &countConfig{}))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	clone.Debug("logger clone debug")
+	logger.Debug("logger source debug")
+	if buffer.String() != "logger clone debug \n" {
+		t.Error("cloned logger must share adapters with its own levels: " + buffer.String())
+	}
+	if len(logger.outputList()) != 1 || len(clone.outputList()) != 2 {
+		t.Error("cloned logger outputs must be independent")
+	}
+	count := clone.output("count").LoggerAbstract.(*adapterCount)
+	if count.writes != 1 {
+		t.Errorf("cloned logger attached output writes error: %d", count.writes)
+	}
+	sampler, _ := clone.errorSampler.Load().(*errorSampler)
+	if sampler == nil || sampler == logger.errorSampler.Load().(*errorSampler) || clone.recent.Load() == logger.recent.Load() {
+		t.Error("cloned logger sampler and recent buffer must be copies")
+	}
+}
+
+func TestLogger_CloneOptions(t *testing.T) {
+
+	logger := NewLogger()
+	logger.SetAsync(20)
+	clone, err := logger.Clone()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if clone.synchronous || cap(clone.msgChan) != 20 {
+		t.Error("cloned logger must be async like the source")
+	}
+	clone, err = logger.Clone(OptionAsync(0), OptionDetach("console"))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if !clone.synchronous || len(clone.outputList()) != 0 || len(logger.outputList()) != 1 {
+		t.Error("cloned logger options error")
+	}
+	if _, err := logger.Clone(OptionDetach("file")); err == nil {
+		t.Error("detach not attached adapter option must be error")
+	}
+	if _, err := logger.Clone(OptionLevel("file", LoggerLevelDebug)); err == nil {
+		t.Error("level of not attached adapter option must be error")
+	}
+}
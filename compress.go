@@ -0,0 +1,153 @@
+package go_logger
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"io"
+	"os"
+	"sync"
+)
+
+// compressor of a byte stream, used by the adapters writing compressed files or request bodies:
+// rotated files of the file adapter, api, clickhouse and datadog request bodies, gelf udp payloads
+// gzip, deflate (zlib format, as the http deflate encoding) and zstd are built in, others are registered
+type Compressor interface {
+
+	// codec name, the Compression config value and the http Content-Encoding, e.g. "gzip"
+	Name() string
+
+	// file extension of compressed files, e.g. ".gz"
+	Extension() string
+
+	// new writer compressing to w, Close writes the pending data and does not close w
+	NewWriter(w io.Writer) (io.WriteCloser, error)
+}
+
+var (
+	compressorsLock sync.RWMutex
+	compressors     = map[string]Compressor{
+		"gzip":    gzipCompressor{},
+		"deflate": deflateCompressor{},
+		"zstd":    zstdCompressor{},
+	}
+)
+
+// gzip compressor
+type gzipCompressor struct{}
+
+func (gzipCompressor) Name() string {
+	return "gzip"
+}
+
+func (gzipCompressor) Extension() string {
+	return ".gz"
+}
+
+func (gzipCompressor) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	return gzip.NewWriter(w), nil
+}
+
+// zlib compressor, the http deflate encoding
+type deflateCompressor struct{}
+
+func (deflateCompressor) Name() string {
+	return "deflate"
+}
+
+func (deflateCompressor) Extension() string {
+	return ".zz"
+}
+
+func (deflateCompressor) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	return zlib.NewWriter(w), nil
+}
+
+//register a compressor, e.g. zstd or lz4 of a third-party package, by its name
+//a registered name replaces the compressor, e.g. a faster gzip implementation
+//call before attaching adapters using it
+//params : compressor Compressor
+//return : error
+func RegisterCompressor(compressor Compressor) error {
+	if compressor == nil || compressor.Name() == "" {
+		return errors.New("logger: compressor name cannot be empty!")
+	}
+	compressorsLock.Lock()
+	defer compressorsLock.Unlock()
+
+	compressors[compressor.Name()] = compressor
+	return nil
+}
+
+//registered compressor of a config Compression name
+//return : Compressor, nil if name is empty; error if it is not registered
+func compressorByName(name string) (Compressor, error) {
+	if name == "" {
+		return nil, nil
+	}
+	compressorsLock.RLock()
+	defer compressorsLock.RUnlock()
+
+	compressor, ok := compressors[name]
+	if !ok {
+		return nil, errors.New("config Compression " + name + " is not a registered compressor!")
+	}
+	return compressor, nil
+}
+
+//compressed data
+func compressBytes(compressor Compressor, data []byte) ([]byte, error) {
+	var buffer bytes.Buffer
+	writer, err := compressor.NewWriter(&buffer)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := writer.Write(data); err != nil {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+//compress a file to filename with the compressor extension, then remove it
+//the compressed file keeps the modification time, the file is kept if compression fails
+func compressFile(compressor Compressor, filename string) (err error) {
+	src, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	fileInfo, err := src.Stat()
+	if err != nil {
+		return err
+	}
+	compressedFilename := filename + compressor.Extension()
+	dst, err := os.OpenFile(compressedFilename, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, fileInfo.Mode())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := dst.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			os.Remove(compressedFilename)
+			return
+		}
+		src.Close()
+		os.Chtimes(compressedFilename, fileInfo.ModTime(), fileInfo.ModTime())
+		err = os.Remove(filename)
+	}()
+
+	writer, err := compressor.NewWriter(dst)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(writer, src); err != nil {
+		return err
+	}
+	return writer.Close()
+}
@@ -0,0 +1,116 @@
+package go_logger
+
+import (
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// test compressor writing the data uppercase
+type upperCompressor struct{}
+
+func (upperCompressor) Name() string {
+	return "upper"
+}
+
+func (upperCompressor) Extension() string {
+	return ".up"
+}
+
+func (upperCompressor) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	return &upperWriter{w}, nil
+}
+
+type upperWriter struct {
+	w io.Writer
+}
+
+func (writer *upperWriter) Write(p []byte) (int, error) {
+	return writer.w.Write([]byte(strings.ToUpper(string(p))))
+}
+
+func (writer *upperWriter) Close() error {
+	return nil
+}
+
+func TestRegisterCompressor(t *testing.T) {
+
+	if RegisterCompressor(nil) == nil {
+		t.Error("nil compressor must be error")
+	}
+	if _, err := compressorByName("lz4"); err == nil {
+		t.Error("unregistered compressor must be error")
+	}
+	if err := RegisterCompressor(upperCompressor{}); err != nil {
+		t.Fatal(err.Error())
+	}
+	compressor, err := compressorByName("upper")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	data, _ := compressBytes(compressor, []byte("logger compress"))
+	if string(data) != "LOGGER COMPRESS" {
+		t.Errorf("registered compressor output %q", data)
+	}
+	if compressor, err := compressorByName(""); compressor != nil || err != nil {
+		t.Error("empty Compression must not be compressed")
+	}
+}
+
+func TestAdapterFile_Compression(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "go_logger")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer os.RemoveAll(dir)
+
+	if NewAdapterFile().Init(&FileConfig{Filename: filepath.Join(dir, "app.log"), Compression: "lz4"}) == nil {
+		t.Error("file adapter unregistered Compression must be error")
+	}
+
+	fileAdapter := NewAdapterFile()
+	err = fileAdapter.Init(&FileConfig{
+		Filename:    filepath.Join(dir, "app.log"),
+		Format:      "%body%",
+		MaxBackups:  2,
+		Compression: "gzip",
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	for _, body := range []string{"logger rotated 1", "logger rotated 2", "logger rotated 3"} {
+		fileAdapter.Write(&loggerMessage{Level: LoggerLevelInfo, Body: body})
+		if err := fileAdapter.(*AdapterFile).Rotate(); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+	// Close waits for the rotated files being compressed
+	fileAdapter.(*AdapterFile).Close()
+
+	files, _ := ioutil.ReadDir(dir)
+	rotated := []string{}
+	for _, file := range files {
+		if file.Name() == "app.log" {
+			continue
+		}
+		if !strings.HasSuffix(file.Name(), ".log.gz") {
+			t.Fatalf("file adapter rotated file must be compressed: %s", file.Name())
+		}
+		reader, _ := os.Open(filepath.Join(dir, file.Name()))
+		gzipReader, err := gzip.NewReader(reader)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		data, _ := ioutil.ReadAll(gzipReader)
+		reader.Close()
+		rotated = append(rotated, string(data))
+	}
+	if len(rotated) != 2 || strings.Contains(strings.Join(rotated, ""), "logger rotated 1") {
+		t.Errorf("file adapter must keep the 2 newest compressed files: %q", rotated)
+	}
+}
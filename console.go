@@ -5,7 +5,8 @@ import (
 	"github.com/fatih/color"
 	"io"
 	"os"
-	"reflect"
+	"strconv"
+	"strings"
 	"sync"
 )
 
@@ -22,10 +23,54 @@ var levelColors = map[int]color.Attribute{
 	LoggerLevelDebug:     color.FgCyan,    //background blue
 }
 
+const (
+	CONSOLE_THEME_DEFAULT   = ""
+	CONSOLE_THEME_LIGHT     = "light"
+	CONSOLE_THEME_DARK      = "dark"
+	CONSOLE_THEME_SOLARIZED = "solarized"
+)
+
+// level colors of themes, default theme is levelColors
+var consoleThemes = map[string]map[int][]color.Attribute{
+	CONSOLE_THEME_LIGHT: {
+		LoggerLevelEmergency: {color.BgRed, color.FgWhite, color.Bold},
+		LoggerLevelAlert:     {color.FgRed, color.Bold},
+		LoggerLevelCritical:  {color.FgMagenta, color.Bold},
+		LoggerLevelError:     {color.FgRed},
+		LoggerLevelWarning:   {color.FgYellow, color.Bold},
+		LoggerLevelNotice:    {color.FgGreen},
+		LoggerLevelInfo:      {color.FgBlue},
+		LoggerLevelDebug:     {color.FgBlack},
+	},
+	CONSOLE_THEME_DARK: {
+		LoggerLevelEmergency: {color.BgRed, color.FgHiWhite, color.Bold},
+		LoggerLevelAlert:     {color.FgHiRed, color.Bold},
+		LoggerLevelCritical:  {color.FgHiMagenta, color.Bold},
+		LoggerLevelError:     {color.FgHiRed},
+		LoggerLevelWarning:   {color.FgHiYellow},
+		LoggerLevelNotice:    {color.FgHiGreen},
+		LoggerLevelInfo:      {color.FgHiCyan},
+		LoggerLevelDebug:     {color.FgHiBlack},
+	},
+	// solarized terminals map the 16 ANSI colors to the solarized palette
+	CONSOLE_THEME_SOLARIZED: {
+		LoggerLevelEmergency: {color.BgRed, color.FgHiWhite},
+		LoggerLevelAlert:     {color.FgRed, color.Bold},
+		LoggerLevelCritical:  {color.FgMagenta},
+		LoggerLevelError:     {color.FgRed},
+		LoggerLevelWarning:   {color.FgYellow},
+		LoggerLevelNotice:    {color.FgGreen},
+		LoggerLevelInfo:      {color.FgBlue},
+		LoggerLevelDebug:     {color.FgHiGreen},
+	},
+}
+
 // adapter console
 type AdapterConsole struct {
-	write  *ConsoleWriter
-	config *ConsoleConfig
+	write      *ConsoleWriter
+	config     *ConsoleConfig
+	color      bool // color is enabled by config and NO_COLOR
+	forceColor bool // color is forced by FORCE_COLOR even if not a terminal
 }
 
 // console writer
@@ -37,11 +82,19 @@ type ConsoleWriter struct {
 // console config
 type ConsoleConfig struct {
 	// console text is show color
+	// disabled if environment NO_COLOR is set, forced even if not a terminal if FORCE_COLOR is set
 	Color bool
 
+	// color theme, "" default, "light", "dark", "solarized"
+	Theme string
+
 	// is json format
 	JsonFormat bool
 
+	// jsonFormat is true, JSON field mapping profile
+	// "" default, "gelf" Graylog GELF, "ecs" Elastic Common Schema, "otel" OpenTelemetry
+	JsonProfile string
+
 	// jsonFormat is false, please input format string
 	// if format is empty, default format "%millisecond_format% [%level_string%] %body%"
 	//
@@ -55,9 +108,17 @@ type ConsoleConfig struct {
 	//	File string "%file%"
 	//	Line int "%line%"
 	//	Function "%function%"
+	//	Name "%name%", named child logger name
+	//	Fields "%fields%", if not in format, non-empty fields are appended
 	//
 	// example: format = "%millisecond_format% [%level_string%] %body%"
 	Format string
+
+	// jsonFormat is false, render "%file%:%line%" (or "%file%" without line) as a terminal
+	// hyperlink (OSC 8) to the URL template, "{path}" caller file full path, "{file}" file name, "{line}" line
+	// plain text if stdout is not a terminal, unless forced by FORCE_COLOR
+	// example: "vscode://file{path}:{line}", "https://github.com/user/repo/blob/master/{file}#L{line}"
+	HyperlinkTemplate string
 }
 
 func (cc *ConsoleConfig) Name() string {
@@ -80,13 +141,23 @@ func (adapterConsole *AdapterConsole) Init(consoleConfig Config) error {
 		return errors.New("logger console adapter init error, config must ConsoleConfig")
 	}
 
-	vc := reflect.ValueOf(consoleConfig)
-	cc := vc.Interface().(*ConsoleConfig)
+	cc := consoleConfig.(*ConsoleConfig)
 	adapterConsole.config = cc
 
 	if cc.JsonFormat == false && cc.Format == "" {
 		cc.Format = defaultLoggerMessageFormat
 	}
+	if err := checkJsonProfile(cc.JsonProfile); err != nil {
+		return err
+	}
+	if _, ok := consoleThemes[cc.Theme]; !ok && cc.Theme != CONSOLE_THEME_DEFAULT {
+		return errors.New("config Theme " + cc.Theme + " is not supported!")
+	}
+
+	// https://no-color.org and https://force-color.org
+	adapterConsole.color = cc.Color && os.Getenv("NO_COLOR") == ""
+	forceColor := os.Getenv("FORCE_COLOR")
+	adapterConsole.forceColor = forceColor != "" && forceColor != "0" && forceColor != "false"
 
 	return nil
 }
@@ -95,23 +166,36 @@ func (adapterConsole *AdapterConsole) Write(loggerMsg *loggerMessage) error {
 	msg := ""
 	if adapterConsole.config.JsonFormat == true {
 		//jsonByte, _ := json.Marshal(loggerMsg)
-		jsonByte, _ := loggerMsg.MarshalJSON()
-		msg = string(jsonByte)
+		msg = loggerMsg.formatJson(adapterConsole.config.JsonProfile)
 	} else {
-		msg = loggerMessageFormat(adapterConsole.config.Format, loggerMsg)
+		format := adapterConsole.config.Format
+		// escapes are written to a terminal only, like colors
+		if adapterConsole.config.HyperlinkTemplate != "" && (!color.NoColor || adapterConsole.forceColor) {
+			format = consoleHyperlinkFormat(format, adapterConsole.config.HyperlinkTemplate, loggerMsg)
+		}
+		msg = loggerMsg.formatText(format)
 	}
 	consoleWriter := adapterConsole.write
 
-	if adapterConsole.config.Color {
-		colorAttr := adapterConsole.getColorByLevel(loggerMsg.Level, msg)
+	if adapterConsole.color {
+		c := color.New(adapterConsole.getColorByLevel(loggerMsg.Level, msg)...)
+		if adapterConsole.forceColor {
+			c.EnableColor()
+		}
+		writer := consoleWriter.writer
+		if writer == os.Stdout {
+			// colorable stdout on windows
+			writer = color.Output
+		}
+		writer = stdioWriter(writer)
 		consoleWriter.lock.Lock()
-		color.New(colorAttr).Println(msg)
+		writer.Write([]byte(c.Sprint(msg) + "\n"))
 		consoleWriter.lock.Unlock()
 		return nil
 	}
 
 	consoleWriter.lock.Lock()
-	consoleWriter.writer.Write([]byte(msg + "\n"))
+	stdioWriter(consoleWriter.writer).Write([]byte(msg + "\n"))
 	consoleWriter.lock.Unlock()
 
 	return nil
@@ -121,16 +205,48 @@ func (adapterConsole *AdapterConsole) Name() string {
 	return CONSOLE_ADAPTER_NAME
 }
 
+//new empty config of the adapter, describes the config fields
+func (adapterConsole *AdapterConsole) NewConfig() Config {
+	return &ConsoleConfig{}
+}
+
 func (adapterConsole *AdapterConsole) Flush() {
 
 }
 
-func (adapterConsole *AdapterConsole) getColorByLevel(level int, content string) color.Attribute {
+//wrap "%file%:%line%" or "%file%" of format in an OSC 8 terminal hyperlink
+func consoleHyperlinkFormat(format string, template string, loggerMsg *loggerMessage) string {
+	text := "%file%:%line%"
+	if !strings.Contains(format, text) {
+		text = "%file%"
+		if !strings.Contains(format, text) {
+			return format
+		}
+	}
+	filePath := loggerMsg.filePath
+	if filePath == "" {
+		filePath = loggerMsg.File
+	}
+	url := strings.NewReplacer(
+		"{path}", filePath,
+		"{file}", loggerMsg.File,
+		"{line}", strconv.Itoa(loggerMsg.Line),
+	).Replace(template)
+	return strings.Replace(format, text, "\x1b]8;;"+url+"\x1b\\"+text+"\x1b]8;;\x1b\\", 1)
+}
+
+func (adapterConsole *AdapterConsole) getColorByLevel(level int, content string) []color.Attribute {
+	if theme, ok := consoleThemes[adapterConsole.config.Theme]; ok {
+		if attrs, ok := theme[level]; ok {
+			return attrs
+		}
+		return []color.Attribute{color.Reset}
+	}
 	lc, ok := levelColors[level]
 	if !ok {
 		lc = color.FgWhite
 	}
-	return lc
+	return []color.Attribute{lc}
 }
 
 func init() {
@@ -1,8 +1,12 @@
 package go_logger
 
 import (
+	"bytes"
+	"os"
 	"testing"
 	"time"
+
+	"github.com/fatih/color"
 )
 
 func TestNewAdapterConsole(t *testing.T) {
@@ -79,3 +83,69 @@ func TestAdapterConsole_WriteJsonFormat(t *testing.T) {
 		t.Error(err.Error())
 	}
 }
+
+func TestAdapterConsole_WriteHyperlink(t *testing.T) {
+
+	consoleAdapter := NewAdapterConsole()
+	consoleAdapter.Init(&ConsoleConfig{
+		Format:            "%file%:%line% %body%",
+		HyperlinkTemplate: "https://github.com/qjyoung/go-logger/blob/master/{file}#L{line}",
+	})
+	buffer := &bytes.Buffer{}
+	consoleAdapter.(*AdapterConsole).write.writer = buffer
+
+	noColor := color.NoColor
+	defer func() { color.NoColor = noColor }()
+	loggerMsg := &loggerMessage{
+		Body: "logger console hyperlink test",
+		File: "console_test.go",
+		Line: 10,
+	}
+
+	color.NoColor = true
+	consoleAdapter.Write(loggerMsg)
+	if buffer.String() != "console_test.go:10 logger console hyperlink test\n" {
+		t.Errorf("console hyperlink must be plain text if not a terminal: %q", buffer.String())
+	}
+
+	buffer.Reset()
+	color.NoColor = false
+	consoleAdapter.Write(loggerMsg)
+	expected := "\x1b]8;;https://github.com/qjyoung/go-logger/blob/master/console_test.go#L10\x1b\\" +
+		"console_test.go:10\x1b]8;;\x1b\\ logger console hyperlink test\n"
+	if buffer.String() != expected {
+		t.Errorf("console hyperlink output error: %q", buffer.String())
+	}
+}
+
+func TestAdapterConsole_WriteTheme(t *testing.T) {
+
+	consoleAdapter := NewAdapterConsole()
+	if consoleAdapter.Init(&ConsoleConfig{Color: true, Theme: "neon"}) == nil {
+		t.Error("console adapter unknown theme must be error")
+	}
+
+	loggerMsg := &loggerMessage{
+		Level: LoggerLevelError,
+		Body:  "logger console theme test",
+	}
+
+	os.Setenv("FORCE_COLOR", "1")
+	defer os.Unsetenv("FORCE_COLOR")
+	consoleAdapter.Init(&ConsoleConfig{Color: true, Theme: CONSOLE_THEME_DARK, Format: "%body%"})
+	buffer := &bytes.Buffer{}
+	consoleAdapter.(*AdapterConsole).write.writer = buffer
+	consoleAdapter.Write(loggerMsg)
+	if buffer.String() != "\x1b[91mlogger console theme test\x1b[0m\n" {
+		t.Errorf("console FORCE_COLOR dark theme output error: %q", buffer.String())
+	}
+
+	os.Setenv("NO_COLOR", "1")
+	defer os.Unsetenv("NO_COLOR")
+	consoleAdapter.Init(&ConsoleConfig{Color: true, Theme: CONSOLE_THEME_DARK, Format: "%body%"})
+	buffer.Reset()
+	consoleAdapter.Write(loggerMsg)
+	if buffer.String() != "logger console theme test\n" {
+		t.Errorf("console NO_COLOR output error: %q", buffer.String())
+	}
+}
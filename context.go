@@ -0,0 +1,117 @@
+package go_logger
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// type of the built-in context keys, avoids collisions with other packages
+type contextKey string
+
+// built-in context keys, their values are extracted as fields by default
+//	ctx = context.WithValue(ctx, go_logger.ContextKeyRequestId, "8d2f")
+const (
+	ContextKeyRequestId = contextKey("request_id")
+	ContextKeyTraceId   = contextKey("trace_id")
+	ContextKeyUserId    = contextKey("user_id")
+)
+
+// context key extracted as field
+type contextKeyField struct {
+	field string
+	key   interface{}
+}
+
+var defaultContextKeys = []contextKeyField{
+	{field: "request_id", key: ContextKeyRequestId},
+	{field: "trace_id", key: ContextKeyTraceId},
+	{field: "user_id", key: ContextKeyUserId},
+}
+
+//record context deadline and cancellation fields for entries with a context
+//	ctx_deadline_remaining_ms : remaining time to deadline, negative if passed, only if ctx has deadline
+//	ctx_canceled : ctx is done
+//	ctx_error : ctx.Err() if ctx is done, "context canceled" or "context deadline exceeded"
+//params : enabled bool
+func (logger *Logger) SetContextDeadlineFields(enabled bool) {
+	var value int32
+	if enabled {
+		value = 1
+	}
+	atomic.StoreInt32(&logger.contextDeadlineFields, value)
+}
+
+//register a context key, its value is extracted as field of messages with the context
+//request_id, trace_id and user_id are registered with the built-in keys by default
+//params : field string, key interface{}, nil key to unregister the field
+//return : error
+func (logger *Logger) SetContextKey(field string, key interface{}) error {
+	if field == "" {
+		return errors.New("logger: context key field cannot be empty!")
+	}
+	logger.lock.Lock()
+	defer logger.lock.Unlock()
+
+	keys := []contextKeyField{}
+	for _, contextKey := range logger.contextKeys.Load().([]contextKeyField) {
+		if contextKey.field != field {
+			keys = append(keys, contextKey)
+		}
+	}
+	if key != nil {
+		keys = append(keys, contextKeyField{field: field, key: key})
+	}
+	logger.contextKeys.Store(keys)
+	return nil
+}
+
+//new entry with context
+//params : ctx context.Context
+//return : *Entry
+func (logger *Logger) WithContext(ctx context.Context) *Entry {
+	return &Entry{logger: logger, ctx: ctx}
+}
+
+//new entry with entry fields and context
+//params : ctx context.Context
+//return : *Entry
+func (entry *Entry) WithContext(ctx context.Context) *Entry {
+	child := *entry
+	child.ctx = ctx
+	return &child
+}
+
+//add context fields to message fields
+func (logger *Logger) contextFields(ctx context.Context, fields map[string]interface{}) {
+	if ctx == nil {
+		return
+	}
+	for _, contextKey := range logger.contextKeys.Load().([]contextKeyField) {
+		if value := ctx.Value(contextKey.key); value != nil {
+			fields[contextKey.field] = fieldValue(value)
+		}
+	}
+	if atomic.LoadInt32(&logger.contextDeadlineFields) == 0 {
+		return
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		fields["ctx_deadline_remaining_ms"] = int64(time.Until(deadline) / time.Millisecond)
+	}
+	err := ctx.Err()
+	fields["ctx_canceled"] = err != nil
+	if err != nil {
+		fields["ctx_error"] = err.Error()
+	}
+}
+
+//context fields of message, nil if none
+func (logger *Logger) messageContextFields(ctx context.Context) map[string]interface{} {
+	fields := map[string]interface{}{}
+	logger.contextFields(ctx, fields)
+	if len(fields) == 0 {
+		return nil
+	}
+	return fields
+}
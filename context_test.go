@@ -0,0 +1,96 @@
+package go_logger
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLogger_SetContextDeadlineFields(t *testing.T) {
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("console", LoggerLevelDebug, &ConsoleConfig{
+		Format: "%body% %fields%",
+	})
+	buffer := &bytes.Buffer{}
+	logger.outputList()[0].LoggerAbstract.(*AdapterConsole).write.writer = buffer
+
+	ctx, cancel := context.WithCancel(context.Background())
+	logger.WithContext(ctx).Info("logger context disabled")
+	if buffer.String() != "logger context disabled \n" {
+		t.Error("context fields must be disabled by default: " + buffer.String())
+	}
+
+	logger.SetContextDeadlineFields(true)
+	buffer.Reset()
+	entry := logger.WithField("user", "tom").WithContext(ctx)
+	entry.Info("logger context active")
+	cancel()
+	entry.Info("logger context canceled")
+	expected := "logger context active ctx_canceled=false user=tom\n" +
+		"logger context canceled ctx_canceled=true ctx_error=\"context canceled\" user=tom\n"
+	if buffer.String() != expected {
+		t.Error("context cancellation fields error: " + buffer.String())
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), time.Hour)
+	defer cancel()
+	buffer.Reset()
+	logger.WithContext(ctx).Infow("logger context deadline", "id", 1)
+	if !bytes.Contains(buffer.Bytes(), []byte("ctx_deadline_remaining_ms=35")) {
+		t.Error("context deadline fields error: " + buffer.String())
+	}
+}
+
+func TestLogger_InfoCtx(t *testing.T) {
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("console", LoggerLevelDebug, &ConsoleConfig{
+		Format: "%body% %fields%",
+	})
+	buffer := &bytes.Buffer{}
+	logger.outputList()[0].LoggerAbstract.(*AdapterConsole).write.writer = buffer
+
+	ctx := context.WithValue(context.Background(), ContextKeyRequestId, "8d2f")
+	ctx = context.WithValue(ctx, ContextKeyUserId, 42)
+	logger.InfoCtx(ctx, "logger context keys")
+	logger.ErrorCtxf(ctx, "logger context %s", "format")
+	logger.WithContext(ctx).WithField("id", 1).Warning("logger context entry")
+	logger.InfoCtx(context.Background(), "logger context empty")
+	expected := "logger context keys request_id=8d2f user_id=42\n" +
+		"logger context format request_id=8d2f user_id=42\n" +
+		"logger context entry id=1 request_id=8d2f user_id=42\n" +
+		"logger context empty \n"
+	if buffer.String() != expected {
+		t.Error("context key fields error: " + buffer.String())
+	}
+}
+
+type testContextKey struct{}
+
+func TestLogger_SetContextKey(t *testing.T) {
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("console", LoggerLevelDebug, &ConsoleConfig{
+		Format: "%body% %fields%",
+	})
+	buffer := &bytes.Buffer{}
+	logger.outputList()[0].LoggerAbstract.(*AdapterConsole).write.writer = buffer
+
+	if logger.SetContextKey("", testContextKey{}) == nil {
+		t.Error("empty context key field must be error")
+	}
+	logger.SetContextKey("tenant", testContextKey{})
+	logger.SetContextKey("request_id", nil)
+
+	ctx := context.WithValue(context.Background(), testContextKey{}, "acme")
+	ctx = context.WithValue(ctx, ContextKeyRequestId, "8d2f")
+	logger.DebugCtx(ctx, "logger context custom key")
+	if buffer.String() != "logger context custom key tenant=acme\n" {
+		t.Error("custom context key fields error: " + buffer.String())
+	}
+}
@@ -0,0 +1,162 @@
+//go:build !tinygo && !logger_tiny
+// +build !tinygo,!logger_tiny
+
+package go_logger
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// default async message channel length
+const loggerAsyncChanLen = 100
+
+// max message body bytes, 0 is unlimited
+const loggerBodyMaxBytes = 0
+
+var loggerMessageFormatTokenRegexp = regexp.MustCompile(`%[a-z_]+%`)
+
+//get caller file path, line and function name
+//params : callDepth int, caller depth of the writer
+//return : file path, line, function
+func loggerCaller(callDepth int) (string, int, string) {
+	pc, file, line, ok := runtime.Caller(callDepth + 1)
+	if !ok {
+		return "null", 0, "null"
+	}
+	return file, line, runtime.FuncForPC(pc).Name()
+}
+
+//get the call stack from the caller, at most 32 frames
+//params : callDepth int, caller depth of the writer
+//return : program counters, innermost first
+func loggerStack(callDepth int) []uintptr {
+	pcs := make([]uintptr, 32)
+	return pcs[:runtime.Callers(callDepth+2, pcs)]
+}
+
+//check config Format field contains only known tokens, or is a valid text/template format
+func checkConfigFormat(config Config) error {
+	vc := reflect.Indirect(reflect.ValueOf(config))
+	if vc.Kind() != reflect.Struct {
+		return nil
+	}
+	format := vc.FieldByName("Format")
+	if !format.IsValid() || format.Kind() != reflect.String {
+		return nil
+	}
+	if isTemplateFormat(format.String()) {
+		return checkTemplateFormat(format.String())
+	}
+	for _, token := range loggerMessageFormatTokenRegexp.FindAllString(format.String(), -1) {
+		if !loggerMessageFormatTokens[token] {
+			return errors.New("logger: config Format token " + token + " is unknown!")
+		}
+	}
+	return nil
+}
+
+//get Format and JsonFormat fields of config
+func configFormat(config Config) (string, bool) {
+	vc := reflect.Indirect(reflect.ValueOf(config))
+	if vc.Kind() != reflect.Struct {
+		return "", false
+	}
+	format := ""
+	if field := vc.FieldByName("Format"); field.IsValid() && field.Kind() == reflect.String {
+		format = field.String()
+	}
+	jsonFormat := false
+	if field := vc.FieldByName("JsonFormat"); field.IsValid() && field.Kind() == reflect.Bool {
+		jsonFormat = field.Bool()
+	}
+	return format, jsonFormat
+}
+
+//get JsonProfile field of config
+func configJsonProfile(config Config) string {
+	vc := reflect.Indirect(reflect.ValueOf(config))
+	if vc.Kind() != reflect.Struct {
+		return ""
+	}
+	if field := vc.FieldByName("JsonProfile"); field.IsValid() && field.Kind() == reflect.String {
+		return field.String()
+	}
+	return ""
+}
+
+//describe exported fields of a config struct
+func configFields(config Config) []ConfigField {
+	fields := []ConfigField{}
+	t := reflect.TypeOf(config)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return fields
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		fields = append(fields, ConfigField{
+			Name:     field.Name,
+			Type:     field.Type.String(),
+			Required: field.Tag.Get("config") == "required",
+		})
+	}
+	return fields
+}
+
+//name of the type of an error value, e.g. "*errors.errorString"
+func errorTypeName(err interface{}) string {
+	return reflect.TypeOf(err).String()
+}
+
+// value formatters by field value type
+var valueFormatters = struct {
+	lock       sync.RWMutex
+	formatters map[reflect.Type]ValueFormatter
+}{formatters: map[reflect.Type]ValueFormatter{
+	reflect.TypeOf(time.Duration(0)): func(value interface{}) string {
+		return humanizeDuration(value.(time.Duration))
+	},
+}}
+
+//register a text output formatter for field values of the type of sample, replaces the formatter of the type
+//	go_logger.RegisterValueFormatter(net.IP{}, func(v interface{}) string { return v.(net.IP).String() })
+//params : sample interface{}, formatter ValueFormatter, nil to unregister
+//return : error
+func RegisterValueFormatter(sample interface{}, formatter ValueFormatter) error {
+	if sample == nil {
+		return errors.New("logger: value formatter sample cannot be nil!")
+	}
+	valueFormatters.lock.Lock()
+	defer valueFormatters.lock.Unlock()
+
+	if formatter == nil {
+		delete(valueFormatters.formatters, reflect.TypeOf(sample))
+		return nil
+	}
+	valueFormatters.formatters[reflect.TypeOf(sample)] = formatter
+	return nil
+}
+
+//text of a field value by its type formatter
+func formatValue(value interface{}) (string, bool) {
+	if value == nil {
+		return "", false
+	}
+	valueFormatters.lock.RLock()
+	formatter, ok := valueFormatters.formatters[reflect.TypeOf(value)]
+	valueFormatters.lock.RUnlock()
+	if !ok {
+		return "", false
+	}
+	return formatter(value), true
+}
@@ -0,0 +1,61 @@
+//go:build !tinygo && !logger_tiny
+// +build !tinygo,!logger_tiny
+
+package go_logger
+
+import (
+	"net"
+	"strings"
+	"testing"
+)
+
+func TestLoggerCaller(t *testing.T) {
+	file, line, funcName := loggerCaller(0)
+	if !strings.HasSuffix(file, "/core_full_test.go") || line != 13 {
+		t.Errorf("caller %s:%d", file, line)
+	}
+	if funcName != "github.com/qjyoung/go-logger.TestLoggerCaller" {
+		t.Errorf("caller function %s", funcName)
+	}
+}
+
+func TestLogger_SetStrictModeFormat(t *testing.T) {
+
+	logger := NewLogger()
+	logger.SetStrictMode(LoggerStrictError)
+	logger.Detach("console")
+	err := logger.Attach("console", LoggerLevelDebug, &ConsoleConfig{
+		Format: "%millisecond_format% [%level%] %message%",
+	})
+	if err == nil {
+		t.Error("strict mode error must return unknown format token error")
+	}
+}
+
+func TestVerifyAttachFormat(t *testing.T) {
+
+	if VerifyAttach("console", LoggerLevelInfo, &ConsoleConfig{Format: "%bdy%"}) == nil {
+		t.Error("verify attach must return unknown format token error")
+	}
+}
+
+func TestRegisterValueFormatter(t *testing.T) {
+
+	if RegisterValueFormatter(nil, nil) == nil {
+		t.Error("nil sample must be error")
+	}
+	RegisterValueFormatter(net.IP{}, func(value interface{}) string {
+		return "ip:" + value.(net.IP).String()
+	})
+	defer RegisterValueFormatter(net.IP{}, nil)
+
+	text := loggerFieldsFormat(map[string]interface{}{"addr": net.ParseIP("10.0.0.1")})
+	if text != "addr=ip:10.0.0.1" {
+		t.Error("registered value formatter error: " + text)
+	}
+}
+
+//file of a caller in the test output, see loggerCaller
+func loggerTestCallerFile(file string) string {
+	return file
+}
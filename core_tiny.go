@@ -0,0 +1,107 @@
+//go:build tinygo || logger_tiny
+// +build tinygo logger_tiny
+
+package go_logger
+
+// reduced footprint core for constrained targets, selected by build tag
+//
+//	go build -tags logger_tiny
+//	tinygo build (tag tinygo is set by tinygo)
+//
+// no runtime.Caller, messages have file "null", line 0 and function "null"
+// no reflection based config handling: no Format check in strict mode, adapter config fields
+// are not described, only console and file configs are read by snapshots and record sizes
+// no value formatter registry, time.Duration field values are humanized by a type switch
+// error types of fingerprints are named by fmt %T
+// no network and database adapters, S3 blob store and DebugRequest
+// no text/template formats
+// fixed small async channel and max message body bytes
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// default async message channel length
+const loggerAsyncChanLen = 16
+
+// max message body bytes, 0 is unlimited
+const loggerBodyMaxBytes = 512
+
+//caller is not looked up in tiny core
+//return : file, line, function
+func loggerCaller(callDepth int) (string, int, string) {
+	return "null", 0, "null"
+}
+
+//call stack is not captured in tiny core
+//return : nil
+func loggerStack(callDepth int) []uintptr {
+	return nil
+}
+
+//config Format is not checked in tiny core
+func checkConfigFormat(config Config) error {
+	return nil
+}
+
+//text/template formats are not supported in tiny core, "{{" is written as is
+//return : "", false
+func loggerMessageTemplate(format string, loggerMsg *loggerMessage) (string, bool) {
+	return "", false
+}
+
+//text/template formats are not supported in tiny core
+//return : error
+func RegisterTemplateFunc(name string, fn interface{}) error {
+	return errors.New("logger: template formats are not supported in tiny core!")
+}
+
+//get Format and JsonFormat fields of the console and file configs, other configs are not read in tiny core
+func configFormat(config Config) (string, bool) {
+	switch c := config.(type) {
+	case *ConsoleConfig:
+		return c.Format, c.JsonFormat
+	case *FileConfig:
+		return c.Format, c.JsonFormat
+	}
+	return "", false
+}
+
+//get JsonProfile field of the console and file configs, other configs are not read in tiny core
+func configJsonProfile(config Config) string {
+	switch c := config.(type) {
+	case *ConsoleConfig:
+		return c.JsonProfile
+	case *FileConfig:
+		return c.JsonProfile
+	}
+	return ""
+}
+
+//config fields are not described in tiny core
+//return : no fields
+func configFields(config Config) []ConfigField {
+	return []ConfigField{}
+}
+
+//name of the type of an error value by fmt, e.g. "*errors.errorString"
+func errorTypeName(err interface{}) string {
+	return fmt.Sprintf("%T", err)
+}
+
+//value formatters are not registered in tiny core
+//return : error
+func RegisterValueFormatter(sample interface{}, formatter ValueFormatter) error {
+	return errors.New("logger: value formatters are not supported in tiny core!")
+}
+
+//text of a time.Duration field value, other types are not formatted in tiny core
+func formatValue(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case time.Duration:
+		return humanizeDuration(v), true
+	}
+	return "", false
+}
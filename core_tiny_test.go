@@ -0,0 +1,21 @@
+//go:build tinygo || logger_tiny
+// +build tinygo logger_tiny
+
+package go_logger
+
+import (
+	"net"
+	"testing"
+)
+
+func TestRegisterValueFormatter(t *testing.T) {
+
+	if RegisterValueFormatter(net.IP{}, func(value interface{}) string { return "" }) == nil {
+		t.Error("tiny core value formatter must be error")
+	}
+}
+
+//file of a caller in the test output, callers are not looked up in tiny core
+func loggerTestCallerFile(file string) string {
+	return "null"
+}
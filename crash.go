@@ -0,0 +1,90 @@
+package go_logger
+
+import (
+	"errors"
+	"os"
+	"os/signal"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// raise the signal again with its default action, replaced by tests
+var crashRaise = raiseSignal
+
+// crash file written with the recent buffer on a fatal signal
+// the file and the buffer are allocated in advance, a crash only formats and writes
+type crashHandler struct {
+	file    *os.File
+	signals chan os.Signal
+	buffer  []byte
+	recent  atomic.Value // *recentBuffer, replaced by SetRecentBuffer
+}
+
+//write the recent buffer to filename on SIGSEGV, SIGBUS or SIGABRT, then die by the signal
+//best effort: signals are received by notification, a Go panic or a crash of the runtime itself
+//is not a signal delivered to the handler; needs SetRecentBuffer, unix only
+//params : filename string, appended, "" removes the crash handler
+//return : error
+func (logger *Logger) SetCrashHandler(filename string) error {
+	logger.lock.Lock()
+	defer logger.lock.Unlock()
+
+	if logger.crash != nil {
+		logger.crash.stop()
+		logger.crash = nil
+	}
+	if filename == "" {
+		return nil
+	}
+	recent, _ := logger.recent.Load().(*recentBuffer)
+	if recent == nil {
+		return errors.New("logger: crash handler needs the recent buffer, see SetRecentBuffer!")
+	}
+	if len(crashSignals) == 0 {
+		return errors.New("logger: crash handler is not supported on this platform!")
+	}
+	file, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	crash := &crashHandler{
+		file:    file,
+		signals: make(chan os.Signal, 1),
+		buffer:  make([]byte, 0, 64*1024),
+	}
+	crash.recent.Store(recent)
+	signal.Notify(crash.signals, crashSignals...)
+	go func() {
+		for sig := range crash.signals {
+			crash.write(sig)
+			crashRaise(sig)
+		}
+	}()
+	logger.crash = crash
+	return nil
+}
+
+//write the recent messages to the crash file in one write
+func (crash *crashHandler) write(sig os.Signal) {
+	recent, _ := crash.recent.Load().(*recentBuffer)
+	buffer := crash.buffer[:0]
+	buffer = append(buffer, "logger: crash by signal "+sig.String()+" at "+time.Now().Format(time.RFC3339Nano)+
+		", last records:\n"...)
+	messages := recent.snapshot()
+	for _, loggerMsg := range messages {
+		buffer = append(buffer, loggerMessageFormat(defaultLoggerMessageFormat, loggerMsg)...)
+		buffer = append(buffer, '\n')
+	}
+	buffer = append(buffer, "logger: crash end, "+strconv.Itoa(len(messages))+" records\n"...)
+	crash.file.Write(buffer)
+	crash.file.Sync()
+	crash.buffer = buffer
+}
+
+//stop signal notifications and close the crash file
+func (crash *crashHandler) stop() {
+	signal.Stop(crash.signals)
+	close(crash.signals)
+	crash.file.Close()
+}
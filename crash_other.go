@@ -0,0 +1,12 @@
+//go:build windows || js || plan9
+// +build windows js plan9
+
+package go_logger
+
+import "os"
+
+// fatal signals are not delivered on this platform
+var crashSignals []os.Signal
+
+func raiseSignal(sig os.Signal) {
+}
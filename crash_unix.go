@@ -0,0 +1,19 @@
+//go:build !windows && !js && !plan9
+// +build !windows,!js,!plan9
+
+package go_logger
+
+import (
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// fatal signals of the crash handler
+var crashSignals = []os.Signal{syscall.SIGSEGV, syscall.SIGBUS, syscall.SIGABRT}
+
+// restore the default action of the signal and send it to the process
+func raiseSignal(sig os.Signal) {
+	signal.Reset(sig)
+	syscall.Kill(os.Getpid(), sig.(syscall.Signal))
+}
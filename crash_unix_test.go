@@ -0,0 +1,64 @@
+//go:build !windows && !js && !plan9
+// +build !windows,!js,!plan9
+
+package go_logger
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestLogger_SetCrashHandler(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "go_logger")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer os.RemoveAll(dir)
+
+	raised := make(chan os.Signal, 1)
+	crashRaise = func(sig os.Signal) {
+		raised <- sig
+	}
+	defer func() {
+		crashRaise = raiseSignal
+	}()
+
+	filename := filepath.Join(dir, "crash.log")
+	logger := NewLogger()
+	logger.Detach("console")
+	if logger.SetCrashHandler(filename) == nil {
+		t.Error("crash handler without recent buffer must return error")
+	}
+	logger.SetRecentBuffer(2)
+	if err := logger.SetCrashHandler(filename); err != nil {
+		t.Fatal(err.Error())
+	}
+	defer logger.SetCrashHandler("")
+	logger.Info("logger crash 1")
+	logger.Info("logger crash 2")
+	logger.Error("logger crash 3")
+
+	syscall.Kill(os.Getpid(), syscall.SIGABRT)
+	select {
+	case sig := <-raised:
+		if sig != syscall.SIGABRT {
+			t.Errorf("crash handler must raise the received signal, got %v", sig)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("crash handler must handle SIGABRT")
+	}
+
+	data, _ := ioutil.ReadFile(filename)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 4 || !strings.Contains(lines[0], "crash by signal aborted") ||
+		!strings.HasSuffix(lines[1], "[Info] logger crash 2") || !strings.HasSuffix(lines[2], "[Error] logger crash 3") ||
+		lines[3] != "logger: crash end, 2 records" {
+		t.Errorf("crash handler file error: %q", string(data))
+	}
+}
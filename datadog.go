@@ -0,0 +1,312 @@
+//go:build !tinygo && !logger_tiny
+// +build !tinygo,!logger_tiny
+
+package go_logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const DATADOG_ADAPTER_NAME = "datadog"
+
+// batch limits of the logs intake, 1000 entries and 5MB of 1MB entries at most per request
+const (
+	datadogBatchMaxSize  = 1000
+	datadogBatchMaxBytes = 4 << 20
+)
+
+// attributes of an entry set by the adapter, fields of the same names are not written
+var datadogReservedAttributes = map[string]bool{
+	"message": true, "status": true, "timestamp": true, "service": true,
+	"ddsource": true, "ddtags": true, "hostname": true, "logger": true,
+}
+
+// adapter datadog, messages are batched into JSON array posts to the Datadog logs intake
+// fields are entry attributes, the payload is gzip compressed by default
+type AdapterDatadog struct {
+	lock       sync.Mutex
+	sendLock   sync.Mutex // batches are posted in order
+	config     *DatadogConfig
+	client     *http.Client
+	tags       string     // ddtags of every entry
+	compressor Compressor // payload compressor, nil is not compressed
+	entries    *bytes.Buffer
+	count      int
+	timer      *time.Timer
+	failed     error // error of the last timer post, reported by FlushAck
+}
+
+// datadog config
+type DatadogConfig struct {
+
+	// api key of the organization
+	ApiKey string `config:"required"`
+
+	// Datadog site of the organization, e.g. "datadoghq.eu", "us3.datadoghq.com", default "datadoghq.com"
+	Site string
+
+	// intake url, e.g. a proxy, default "https://http-intake.logs.<Site>/api/v2/logs"
+	Url string
+
+	// service of the entries, e.g. "checkout-api"
+	Service string
+
+	// source of the entries, the log integration, default "go"
+	Source string
+
+	// tags of the entries, e.g. {"env": "prod", "version": "1.4.2"}, sent as "env:prod,version:1.4.2"
+	Tags map[string]string
+
+	// hostname of the entries, default os.Hostname()
+	Hostname string
+
+	// payload compression, a registered compressor name, e.g. "gzip", default not compressed
+	Compression string
+
+	// entries per post, default and max 1000
+	BatchSize int
+
+	// max wait before a batch is posted, default 5 seconds
+	BatchWait time.Duration
+
+	// post request timeout, default 10 seconds
+	Timeout time.Duration
+}
+
+func (dc *DatadogConfig) Name() string {
+	return DATADOG_ADAPTER_NAME
+}
+
+func NewAdapterDatadog() LoggerAbstract {
+	return &AdapterDatadog{
+		config:  &DatadogConfig{},
+		entries: &bytes.Buffer{},
+	}
+}
+
+func (adapterDatadog *AdapterDatadog) Init(datadogConfig Config) error {
+	if datadogConfig.Name() != DATADOG_ADAPTER_NAME {
+		return errors.New("logger datadog adapter init error, config must DatadogConfig")
+	}
+
+	dc := datadogConfig.(*DatadogConfig)
+	adapterDatadog.config = dc
+
+	if dc.ApiKey == "" {
+		return errors.New("config ApiKey cannot be empty!")
+	}
+	if dc.Site == "" {
+		dc.Site = "datadoghq.com"
+	}
+	if dc.Url == "" {
+		dc.Url = "https://http-intake.logs." + dc.Site + "/api/v2/logs"
+	}
+	if dc.Source == "" {
+		dc.Source = "go"
+	}
+	if dc.Hostname == "" {
+		dc.Hostname, _ = os.Hostname()
+	}
+	if dc.BatchSize < 0 || dc.BatchWait < 0 || dc.Timeout < 0 {
+		return errors.New("config BatchSize, BatchWait and Timeout cannot be negative!")
+	}
+	if dc.BatchSize > datadogBatchMaxSize {
+		return errors.New("config BatchSize cannot be more than " + strconv.Itoa(datadogBatchMaxSize) + "!")
+	}
+	if dc.BatchSize == 0 {
+		dc.BatchSize = datadogBatchMaxSize
+	}
+	if dc.BatchWait == 0 {
+		dc.BatchWait = 5 * time.Second
+	}
+	if dc.Timeout == 0 {
+		dc.Timeout = 10 * time.Second
+	}
+	compressor, err := compressorByName(dc.Compression)
+	if err != nil {
+		return err
+	}
+	adapterDatadog.compressor = compressor
+
+	names := make([]string, 0, len(dc.Tags))
+	for name := range dc.Tags {
+		if name == "" || strings.ContainsAny(name, ":,") {
+			return errors.New("config Tags name " + name + " is illegal!")
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	tags := make([]string, 0, len(names))
+	for _, name := range names {
+		tags = append(tags, name+":"+strings.Replace(dc.Tags[name], ",", "_", -1))
+	}
+	adapterDatadog.tags = strings.Join(tags, ",")
+	adapterDatadog.client = &http.Client{Timeout: dc.Timeout}
+	return nil
+}
+
+func (adapterDatadog *AdapterDatadog) Write(loggerMsg *loggerMessage) error {
+	config := adapterDatadog.config
+	entry := make(map[string]interface{}, len(loggerMsg.Fields)+8)
+	for key, value := range loggerMsg.Fields {
+		if !datadogReservedAttributes[key] {
+			entry[key] = value
+		}
+	}
+	entry["message"] = loggerMsg.Body
+	entry["status"] = strings.ToLower(loggerMsg.LevelString)
+	entry["timestamp"] = loggerMsg.Millisecond
+	entry["ddsource"] = config.Source
+	entry["hostname"] = config.Hostname
+	if config.Service != "" {
+		entry["service"] = config.Service
+	}
+	if adapterDatadog.tags != "" {
+		entry["ddtags"] = adapterDatadog.tags
+	}
+	entry["logger"] = map[string]interface{}{
+		"name":        loggerMsg.Name,
+		"file_name":   loggerMsg.File,
+		"line":        loggerMsg.Line,
+		"method_name": loggerMsg.Function,
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	adapterDatadog.lock.Lock()
+	if adapterDatadog.count > 0 {
+		adapterDatadog.entries.WriteByte(',')
+	}
+	adapterDatadog.entries.Write(data)
+	adapterDatadog.count++
+	full := adapterDatadog.count >= config.BatchSize || adapterDatadog.entries.Len() >= datadogBatchMaxBytes
+	// the first entry of a batch arms the post timer
+	if !full && adapterDatadog.timer == nil {
+		adapterDatadog.timer = time.AfterFunc(config.BatchWait, func() {
+			if err := adapterDatadog.push(); err != nil {
+				adapterDatadog.lock.Lock()
+				adapterDatadog.failed = err
+				adapterDatadog.lock.Unlock()
+				fmt.Fprintf(os.Stderr, "logger: unable post batch to adapter:%v, error: %v\n", DATADOG_ADAPTER_NAME, err)
+			}
+		})
+	}
+	adapterDatadog.lock.Unlock()
+
+	if full {
+		return adapterDatadog.push()
+	}
+	return nil
+}
+
+//post the pending batch, nothing if empty
+//return : error, the batch is dropped on error
+func (adapterDatadog *AdapterDatadog) push() error {
+	adapterDatadog.sendLock.Lock()
+	defer adapterDatadog.sendLock.Unlock()
+
+	adapterDatadog.lock.Lock()
+	if adapterDatadog.timer != nil {
+		adapterDatadog.timer.Stop()
+		adapterDatadog.timer = nil
+	}
+	if adapterDatadog.count == 0 {
+		adapterDatadog.lock.Unlock()
+		return nil
+	}
+	entries := adapterDatadog.entries
+	adapterDatadog.entries = &bytes.Buffer{}
+	adapterDatadog.count = 0
+	adapterDatadog.lock.Unlock()
+
+	body := make([]byte, 0, entries.Len()+2)
+	body = append(body, '[')
+	body = append(body, entries.Bytes()...)
+	return adapterDatadog.request(append(body, ']'))
+}
+
+//post a JSON array of entries, compressed if the compressor is not nil
+func (adapterDatadog *AdapterDatadog) request(body []byte) error {
+	config := adapterDatadog.config
+	if adapterDatadog.compressor != nil {
+		compressed, err := compressBytes(adapterDatadog.compressor, body)
+		if err != nil {
+			return err
+		}
+		body = compressed
+	}
+	request, err := http.NewRequest("POST", config.Url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("DD-API-KEY", config.ApiKey)
+	if adapterDatadog.compressor != nil {
+		request.Header.Set("Content-Encoding", adapterDatadog.compressor.Name())
+	}
+
+	response, err := adapterDatadog.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		message, _ := ioutil.ReadAll(io.LimitReader(response.Body, 512))
+		return errors.New("request " + config.Url + " failed, code=" + strconv.Itoa(response.StatusCode) + ", " + strings.TrimSpace(string(message)))
+	}
+	return nil
+}
+
+func (adapterDatadog *AdapterDatadog) Flush() {
+	adapterDatadog.FlushError()
+}
+
+//post the pending batch
+func (adapterDatadog *AdapterDatadog) FlushError() error {
+	return adapterDatadog.push()
+}
+
+//post the pending batch and wait for the intake response up to timeout, after the posts in flight
+//a failed timer post since the last FlushAck is reported too
+func (adapterDatadog *AdapterDatadog) FlushAck(timeout time.Duration) error {
+	err := waitAck(timeout, adapterDatadog.push)
+	adapterDatadog.lock.Lock()
+	if err == nil {
+		err = adapterDatadog.failed
+	}
+	adapterDatadog.failed = nil
+	adapterDatadog.lock.Unlock()
+	return err
+}
+
+//post the pending batch and stop the timer, the adapter must not be written after Close
+func (adapterDatadog *AdapterDatadog) Close() error {
+	return adapterDatadog.push()
+}
+
+func (adapterDatadog *AdapterDatadog) Name() string {
+	return DATADOG_ADAPTER_NAME
+}
+
+//new empty config of the adapter, describes the config fields
+func (adapterDatadog *AdapterDatadog) NewConfig() Config {
+	return &DatadogConfig{}
+}
+
+func init() {
+	Register(DATADOG_ADAPTER_NAME, NewAdapterDatadog)
+}
@@ -0,0 +1,163 @@
+//go:build !tinygo && !logger_tiny
+// +build !tinygo,!logger_tiny
+
+package go_logger
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// post received by a fake Datadog logs intake
+type datadogPost struct {
+	apiKey   string
+	encoding string
+	entries  []map[string]interface{}
+}
+
+// fake Datadog logs intake, posts with api key "reject" fail
+type datadogServer struct {
+	lock  sync.Mutex
+	posts []datadogPost
+}
+
+func (server *datadogServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var body io.Reader = r.Body
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		reader, err := gzip.NewReader(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		body = reader
+	}
+	received := datadogPost{apiKey: r.Header.Get("DD-API-KEY"), encoding: r.Header.Get("Content-Encoding")}
+	if err := json.NewDecoder(body).Decode(&received.entries); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	server.lock.Lock()
+	server.posts = append(server.posts, received)
+	server.lock.Unlock()
+	if received.apiKey == "reject" {
+		http.Error(w, `{"errors":[{"status":"403","title":"Forbidden"}]}`, http.StatusForbidden)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (server *datadogServer) received() []datadogPost {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+	return append([]datadogPost{}, server.posts...)
+}
+
+func TestAdapterDatadog_Init(t *testing.T) {
+
+	configs := []*DatadogConfig{
+		{},
+		{ApiKey: "key", BatchSize: 1001},
+		{ApiKey: "key", BatchWait: -time.Second},
+		{ApiKey: "key", Compression: "lz4"},
+		{ApiKey: "key", Tags: map[string]string{"env:prod": "1"}},
+	}
+	for _, config := range configs {
+		if NewAdapterDatadog().Init(config) == nil {
+			t.Errorf("datadog adapter config %+v must be error", config)
+		}
+	}
+
+	datadogAdapter := NewAdapterDatadog()
+	config := &DatadogConfig{ApiKey: "key", Site: "datadoghq.eu", Tags: map[string]string{"version": "1.4.2", "env": "prod"},
+		Compression: "gzip"}
+	if err := datadogAdapter.Init(config); err != nil {
+		t.Fatal(err.Error())
+	}
+	adapterDatadog := datadogAdapter.(*AdapterDatadog)
+	if config.Url != "https://http-intake.logs.datadoghq.eu/api/v2/logs" || config.Source != "go" || config.BatchSize != 1000 ||
+		adapterDatadog.tags != "env:prod,version:1.4.2" || adapterDatadog.compressor == nil || adapterDatadog.compressor.Name() != "gzip" {
+		t.Errorf("datadog adapter config %+v, tags %s", config, adapterDatadog.tags)
+	}
+}
+
+func TestAdapterDatadog_Write(t *testing.T) {
+
+	server := &datadogServer{}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	datadogAdapter := NewAdapterDatadog()
+	err := datadogAdapter.Init(&DatadogConfig{
+		ApiKey:      "key",
+		Url:         httpServer.URL,
+		Service:     "checkout-api",
+		Tags:        map[string]string{"env": "prod"},
+		Hostname:    "web-1",
+		BatchSize:   2,
+		BatchWait:   time.Hour,
+		Compression: "gzip",
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	messages := []*loggerMessage{
+		{Millisecond: 1500000000123, Level: LoggerLevelWarning, LevelString: "Warning", Body: "logger datadog 1", Name: "db",
+			File: "main.go", Line: 10, Function: "main.main", Fields: map[string]interface{}{"user_id": 42, "status": "ignored"}},
+		{Millisecond: 1500000000124, Level: LoggerLevelError, LevelString: "Error", Body: "logger datadog 2"},
+		{Millisecond: 1500000000125, Level: LoggerLevelInfo, LevelString: "Info", Body: "logger datadog 3"},
+	}
+	for _, loggerMsg := range messages {
+		if err := datadogAdapter.Write(loggerMsg); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+
+	posts := server.received()
+	if len(posts) != 1 || posts[0].apiKey != "key" || posts[0].encoding != "gzip" || len(posts[0].entries) != 2 {
+		t.Fatalf("datadog adapter must post a full batch of 2 entries: %+v", posts)
+	}
+	entry := posts[0].entries[0]
+	logger, _ := entry["logger"].(map[string]interface{})
+	if entry["message"] != "logger datadog 1" || entry["status"] != "warning" || entry["timestamp"] != float64(1500000000123) ||
+		entry["service"] != "checkout-api" || entry["ddsource"] != "go" || entry["ddtags"] != "env:prod" ||
+		entry["hostname"] != "web-1" || entry["user_id"] != float64(42) || logger["name"] != "db" ||
+		logger["method_name"] != "main.main" || logger["line"] != float64(10) {
+		t.Errorf("datadog adapter entry error: %v", entry)
+	}
+	if posts[0].entries[1]["status"] != "error" {
+		t.Errorf("datadog adapter entry status error: %v", posts[0].entries[1])
+	}
+
+	if err := datadogAdapter.(*AdapterDatadog).FlushError(); err != nil {
+		t.Fatal(err.Error())
+	}
+	posts = server.received()
+	if len(posts) != 2 || len(posts[1].entries) != 1 || posts[1].entries[0]["message"] != "logger datadog 3" {
+		t.Errorf("datadog adapter flush must post the pending batch: %+v", posts)
+	}
+}
+
+func TestAdapterDatadog_WriteError(t *testing.T) {
+
+	server := &datadogServer{}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	datadogAdapter := NewAdapterDatadog()
+	datadogAdapter.Init(&DatadogConfig{ApiKey: "reject", Url: httpServer.URL, BatchSize: 1})
+	err := datadogAdapter.Write(&loggerMessage{LevelString: "Info", Body: "logger datadog"})
+	if err == nil || !strings.Contains(err.Error(), "code=403") {
+		t.Errorf("datadog adapter must return the post error: %v", err)
+	}
+	if posts := server.received(); len(posts) != 1 || posts[0].encoding != "" {
+		t.Errorf("datadog adapter must post uncompressed: %+v", posts)
+	}
+}
@@ -0,0 +1,93 @@
+package go_logger
+
+import (
+	"fmt"
+	"sync/atomic"
+	"unsafe"
+)
+
+// package-level default logger (*Logger), nil until created on first use or set by SetDefault
+// an atomic pointer, atomic.Value has no compare-and-swap before Go 1.17
+var defaultLogger unsafe.Pointer
+
+//get the default logger, a NewLogger() with console output unless SetDefault is called
+//return : *Logger
+func Default() *Logger {
+	if logger := (*Logger)(atomic.LoadPointer(&defaultLogger)); logger != nil {
+		return logger
+	}
+	logger := NewLogger()
+	if defaultStartupBufferSize > 0 {
+		logger.SetStartupBuffer(defaultStartupBufferSize)
+	}
+	// a concurrent Default or SetDefault may have stored the default first
+	if !atomic.CompareAndSwapPointer(&defaultLogger, nil, unsafe.Pointer(logger)) {
+		logger.closeOutputs()
+		return (*Logger)(atomic.LoadPointer(&defaultLogger))
+	}
+	return logger
+}
+
+//replace the default logger used by the package-level functions,
+//startup records buffered by the previous default logger are written to logger
+//params : logger *Logger, nil is ignored
+func SetDefault(logger *Logger) {
+	if logger == nil {
+		return
+	}
+	previous := (*Logger)(atomic.SwapPointer(&defaultLogger, unsafe.Pointer(logger)))
+	if previous != nil && previous != logger {
+		previous.handOverStartup(logger)
+	}
+}
+
+//write the startup records buffered by the default logger, see Logger.Ready
+//return : error
+func Ready() error {
+	return Default().Ready()
+}
+
+//log fatal at emergency level by the default logger, then run its fatal policy
+func Fatal(msg string) {
+	logger := Default()
+	logger.writer(2, LoggerLevelEmergency, msg, nil)
+	logger.fatal()
+}
+
+//log fatal format at emergency level by the default logger, then run its fatal policy
+func Fatalf(format string, a ...interface{}) {
+	logger := Default()
+	msg := fmt.Sprintf(format, a...)
+	logger.writer(2, LoggerLevelEmergency, msg, nil)
+	logger.fatal()
+}
+
+//log panic at emergency level by the default logger, flush, then panic with msg
+func Panic(msg string) {
+	logger := Default()
+	logger.writer(2, LoggerLevelEmergency, msg, nil)
+	logger.Flush()
+	panic(msg)
+}
+
+//log panic format at emergency level by the default logger, flush, then panic with msg
+func Panicf(format string, a ...interface{}) {
+	logger := Default()
+	msg := fmt.Sprintf(format, a...)
+	logger.writer(2, LoggerLevelEmergency, msg, nil)
+	logger.Flush()
+	panic(msg)
+}
+
+//new entry with fields of the default logger
+//params : fields map[string]interface{}
+//return : *Entry
+func WithFields(fields map[string]interface{}) *Entry {
+	return Default().WithFields(fields)
+}
+
+//flush the default logger
+//return : error
+func Flush() error {
+	return Default().Flush()
+}
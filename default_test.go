@@ -0,0 +1,65 @@
+package go_logger
+
+import (
+	"bytes"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestSetDefault(t *testing.T) {
+
+	if Default() == nil || Default() != Default() {
+		t.Error("default logger must be created once")
+	}
+	previous := Default()
+	defer SetDefault(previous)
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("console", LoggerLevelDebug, &ConsoleConfig{
+		Format: "%file% [%level_string%] %body%",
+	})
+	buffer := &bytes.Buffer{}
+	logger.outputList()[0].LoggerAbstract.(*AdapterConsole).write.writer = buffer
+	SetDefault(logger)
+	SetDefault(nil)
+
+	Info("logger default test")
+	Errorf("logger default %s", "format")
+	Debugw("logger default key-values", "id", 1)
+	WithFields(map[string]interface{}{"user": "tom"}).Notice("logger default entry")
+
+	file := loggerTestCallerFile("default_test.go")
+	expected := file + " [Info] logger default test\n" +
+		file + " [Error] logger default format\n" +
+		file + " [Debug] logger default key-values id=1\n" +
+		file + " [Notice] logger default entry user=tom\n"
+	if buffer.String() != expected {
+		t.Error("default logger output error: " + buffer.String())
+	}
+}
+
+func TestDefault_ConcurrentSetDefault(t *testing.T) {
+
+	previous := atomic.LoadPointer(&defaultLogger)
+	defer atomic.StorePointer(&defaultLogger, previous)
+
+	for i := 0; i < 20; i++ {
+		atomic.StorePointer(&defaultLogger, nil)
+		logger := NewLogger()
+		wait := sync.WaitGroup{}
+		for j := 0; j < 4; j++ {
+			wait.Add(1)
+			go func() {
+				defer wait.Done()
+				Default()
+			}()
+		}
+		SetDefault(logger)
+		wait.Wait()
+		if Default() != logger {
+			t.Fatal("Default must not overwrite a concurrent SetDefault")
+		}
+	}
+}
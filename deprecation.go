@@ -0,0 +1,62 @@
+package go_logger
+
+import (
+	"path"
+	"strconv"
+	"sync/atomic"
+)
+
+//log a Warning the first time a deprecated feature is used, later uses are only counted
+//the message file and line are the DeprecationWarn call site, the caller field is the code calling it
+//uses of every feature are reported as the deprecations field of the shutdown summary, see Close
+//params : feature string, e.g. "Client.Send", "config.legacy_mode"
+func (logger *Logger) DeprecationWarn(feature string) {
+	logger.deprecationWarn(2, feature)
+}
+
+//log a deprecated feature warning by the default logger, see Logger DeprecationWarn
+//params : feature string
+func DeprecationWarn(feature string) {
+	Default().deprecationWarn(2, feature)
+}
+
+//log a deprecated feature warning with caller depth
+func (logger *Logger) deprecationWarn(callDepth int, feature string) {
+	if logger.Disabled() || !logger.stats.deprecate(feature) {
+		return
+	}
+	fields := map[string]interface{}{"deprecated_feature": feature}
+	if filePath, line, _ := loggerCaller(callDepth + 1); line > 0 {
+		_, filename := path.Split(filePath)
+		fields["caller"] = filename + ":" + strconv.Itoa(line)
+	}
+	logger.writer(callDepth+1, LoggerLevelWarning, "deprecated: "+feature, fields)
+}
+
+//count a use of a deprecated feature
+//return : true if it is the first use
+func (stats *loggerStats) deprecate(feature string) bool {
+	if count, ok := stats.deprecations.Load(feature); ok {
+		atomic.AddInt64(count.(*int64), 1)
+		return false
+	}
+	first := int64(1)
+	if count, loaded := stats.deprecations.LoadOrStore(feature, &first); loaded {
+		atomic.AddInt64(count.(*int64), 1)
+		return false
+	}
+	return true
+}
+
+//uses of deprecated features, nil if none
+func (stats *loggerStats) deprecationCounts() map[string]int64 {
+	var counts map[string]int64
+	stats.deprecations.Range(func(feature, count interface{}) bool {
+		if counts == nil {
+			counts = map[string]int64{}
+		}
+		counts[feature.(string)] = atomic.LoadInt64(count.(*int64))
+		return true
+	})
+	return counts
+}
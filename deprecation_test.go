@@ -0,0 +1,48 @@
+package go_logger
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+//deprecated path of a library, warns on use
+func deprecatedSend(logger *Logger) {
+	logger.DeprecationWarn("Client.Send")
+}
+
+func TestLogger_DeprecationWarn(t *testing.T) {
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("console", LoggerLevelDebug, &ConsoleConfig{
+		Format: "%level_string% %body% %file% %fields%",
+	})
+	buffer := &bytes.Buffer{}
+	logger.outputList()[0].LoggerAbstract.(*AdapterConsole).write.writer = buffer
+
+	_, _, line, _ := runtime.Caller(0)
+	deprecatedSend(logger)
+	deprecatedSend(logger)
+	deprecatedSend(logger)
+	logger.DeprecationWarn("config.legacy_mode")
+
+	// the caller field is omitted when callers are not looked up
+	file := loggerTestCallerFile("deprecation_test.go")
+	caller := " caller=deprecation_test.go:" + strconv.Itoa(line+1)
+	if file == "null" {
+		caller = ""
+	}
+	expected := "Warning deprecated: Client.Send " + file + caller + " deprecated_feature=Client.Send\n"
+	if !strings.HasPrefix(buffer.String(), expected) || strings.Count(buffer.String(), "\n") != 2 {
+		t.Errorf("deprecation warning must be logged once per feature: %q", buffer.String())
+	}
+
+	buffer.Reset()
+	logger.Close()
+	if !strings.Contains(buffer.String(), `deprecations="map[Client.Send:3 config.legacy_mode:1]"`) {
+		t.Errorf("shutdown summary must count deprecated feature uses: %q", buffer.String())
+	}
+}
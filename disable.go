@@ -0,0 +1,21 @@
+package go_logger
+
+import "sync/atomic"
+
+//disable the logger, every log call returns at once without formatting, fields, stats or writing
+//for benchmarking application code without logging, or shedding load in an emergency
+//queued messages are still written, Flush and Close work as usual
+func (logger *Logger) Disable() {
+	atomic.StoreInt32(&logger.disabled, 1)
+}
+
+//enable the logger disabled by Disable
+func (logger *Logger) Enable() {
+	atomic.StoreInt32(&logger.disabled, 0)
+}
+
+//is the logger disabled by Disable
+//return : bool
+func (logger *Logger) Disabled() bool {
+	return atomic.LoadInt32(&logger.disabled) == 1
+}
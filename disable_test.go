@@ -0,0 +1,36 @@
+package go_logger
+
+import (
+	"bytes"
+	"sync/atomic"
+	"testing"
+)
+
+func TestLogger_Disable(t *testing.T) {
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("console", LoggerLevelDebug, &ConsoleConfig{Format: "%body%"})
+	buffer := &bytes.Buffer{}
+	logger.outputList()[0].LoggerAbstract.(*AdapterConsole).write.writer = buffer
+
+	logger.Disable()
+	if !logger.Disabled() || !logger.Config().Disabled {
+		t.Error("logger must be disabled")
+	}
+	logger.Info("logger disabled")
+	logger.Errorf("logger disabled %d", 1)
+	logger.Warningw("logger disabled", "user", "u1")
+	logger.WithField("user", "u1").Info("logger disabled entry")
+	logger.Named("db").Debug("logger disabled named")
+	logger.Writer(LoggerLevelInfo, "logger disabled writer")
+	if buffer.Len() != 0 || atomic.LoadInt64(&logger.stats.levels[LoggerLevelInfo]) != 0 {
+		t.Errorf("disabled logger must not write or count: %q", buffer.String())
+	}
+
+	logger.Enable()
+	logger.Info("logger enabled")
+	if logger.Disabled() || buffer.String() != "logger enabled\n" {
+		t.Errorf("enabled logger must write: %q", buffer.String())
+	}
+}
@@ -0,0 +1,45 @@
+package go_logger
+
+// reasons of dropped records passed to drop hooks
+const (
+	// dropped by a route rule
+	DROP_REASON_ROUTE = "route"
+	// suppressed by the error sampler as a repeat of a recent error
+	DROP_REASON_SAMPLED = "sampled"
+	// not queued in the enqueue timeout with OVERFLOW_POLICY_DROP
+	DROP_REASON_OVERFLOW = "overflow"
+	// older than the queue TTL without a dead letter output
+	DROP_REASON_STALE = "stale"
+	// over the quota of its key, see SetQuota
+	DROP_REASON_QUOTA = "quota"
+)
+
+// hook called with a record dropped before the outputs and the drop reason, e.g. to count drops by
+// level or to write dropped records elsewhere; it is called by the goroutine dropping the record,
+// so it must be fast, and it must not change the record
+type DropHook func(loggerMsg *LoggerMessage, reason string)
+
+//register a hook called with every dropped record and its reason, see DROP_REASON_ROUTE
+//hooks are called in registration order
+//params : hook DropHook
+func (logger *Logger) OnDrop(hook DropHook) {
+	logger.lock.Lock()
+	defer logger.lock.Unlock()
+
+	hooks, _ := logger.dropHooks.Load().([]DropHook)
+	logger.dropHooks.Store(append(append([]DropHook{}, hooks...), hook))
+}
+
+//count a dropped record and call the drop hooks
+func (logger *Logger) drop(loggerMsg *loggerMessage, reason string) {
+	logger.stats.drop()
+	logger.notifyDrop(loggerMsg, reason)
+}
+
+//call the drop hooks
+func (logger *Logger) notifyDrop(loggerMsg *loggerMessage, reason string) {
+	hooks, _ := logger.dropHooks.Load().([]DropHook)
+	for _, hook := range hooks {
+		hook(loggerMsg, reason)
+	}
+}
@@ -0,0 +1,54 @@
+package go_logger
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLogger_OnDrop(t *testing.T) {
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("count", LoggerLevelDebug, &countConfig{})
+
+	lock := sync.Mutex{}
+	drops := []string{}
+	logger.OnDrop(func(loggerMsg *LoggerMessage, reason string) {
+		lock.Lock()
+		drops = append(drops, reason+" "+loggerMsg.Body)
+		lock.Unlock()
+	})
+	calls := 0
+	logger.OnDrop(func(loggerMsg *LoggerMessage, reason string) {
+		calls++
+	})
+	logger.SetRouteRules([]RouteRule{{BodyRegexp: "^health", Action: ROUTE_ACTION_DROP}})
+	logger.SetErrorSampling(time.Minute, 0)
+	logger.SetQueueTTL(time.Second, "")
+
+	logger.Info("healthcheck ok")
+	logger.Error("connection refused")
+	logger.Error("connection refused")
+	logger.writeQueued(&loggerMessage{Body: "stale", Millisecond: time.Now().Add(-time.Minute).UnixNano() / 1e6})
+	logger.overflow(&loggerMessage{Body: "overflowed"}, OVERFLOW_POLICY_DROP)
+	logger.WriteBatch([]*LoggerMessage{{Level: LoggerLevelInfo, Body: "health imported", Millisecond: 1500000000123}})
+
+	expected := "route healthcheck ok,sampled connection refused,stale stale,overflow overflowed,route health imported"
+	if strings.Join(drops, ",") != expected || calls != 5 {
+		t.Errorf("drop hooks %q, calls %d", drops, calls)
+	}
+	if logger.stats.dropped != 4 {
+		t.Errorf("dropped count %d, stale records are counted as stale", logger.stats.dropped)
+	}
+
+	clone, err := logger.Clone()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	clone.Info("healthcheck cloned")
+	if len(drops) != 6 || drops[5] != "route healthcheck cloned" {
+		t.Errorf("clone drop hooks %q", drops)
+	}
+}
@@ -0,0 +1,156 @@
+package go_logger
+
+import (
+	"context"
+	"fmt"
+)
+
+// exported name of the message type, for adapters outside this package
+// LoggerMessage.Fields has the structured fields of the message
+type LoggerMessage = loggerMessage
+
+// log entry with structured fields
+type Entry struct {
+	logger *Logger
+	fields map[string]interface{}
+	ctx    context.Context
+
+	name      string // named child name, "" is not named
+	level     int    // named child level, only if hasLevel
+	hasLevel  bool   // named child level is overridden
+	errorType string // type of the first error field, "" if none
+}
+
+//new entry with fields, fields are rendered by console and file outputs
+//and passed to adapters in LoggerMessage.Fields
+//params : fields map[string]interface{}
+//return : *Entry
+func (logger *Logger) WithFields(fields map[string]interface{}) *Entry {
+	entry := &Entry{logger: logger}
+	return entry.WithFields(fields)
+}
+
+//new entry with a field
+//params : key string, value interface{}
+//return : *Entry
+func (logger *Logger) WithField(key string, value interface{}) *Entry {
+	return logger.WithFields(map[string]interface{}{key: value})
+}
+
+//new entry with entry fields and fields, fields override entry fields of the same key
+//params : fields map[string]interface{}
+//return : *Entry
+func (entry *Entry) WithFields(fields map[string]interface{}) *Entry {
+	merged := make(map[string]interface{}, len(entry.fields)+len(fields))
+	for key, value := range entry.fields {
+		merged[key] = value
+	}
+	for key, value := range fields {
+		merged[key] = fieldValue(value)
+	}
+	child := *entry
+	child.fields = merged
+	if errorType := errorTypeOfFields(fields); errorType != "" {
+		child.errorType = errorType
+	}
+	return &child
+}
+
+//new entry with entry fields and a field
+//params : key string, value interface{}
+//return : *Entry
+func (entry *Entry) WithField(key string, value interface{}) *Entry {
+	return entry.WithFields(map[string]interface{}{key: value})
+}
+
+//copy of entry fields with context fields, the pipeline may change message fields
+func (entry *Entry) messageFields() map[string]interface{} {
+	fields := make(map[string]interface{}, len(entry.fields))
+	for key, value := range entry.fields {
+		fields[key] = value
+	}
+	entry.logger.contextFields(entry.ctx, fields)
+	return fields
+}
+
+//write message with entry name, dropped if less severe than the entry level or the named level, see SetNamedLevel
+//params : callDepth int, level int, msg string, fields map[string]interface{}
+//return : error
+func (entry *Entry) writer(callDepth int, level int, msg string, fields map[string]interface{}) error {
+	return entry.write(callDepth+1, entry.errorType, level, msg, fields)
+}
+
+//write message with entry name and error type
+func (entry *Entry) write(callDepth int, errorType string, level int, msg string, fields map[string]interface{}) error {
+	if entry.hasLevel {
+		if level > entry.level {
+			return nil
+		}
+	} else if entry.name != "" {
+		if namedLevel, ok := entry.logger.NamedLevel(entry.name); ok && level > namedLevel {
+			return nil
+		}
+	}
+	return entry.logger.writerNamed(callDepth+1, entry.name, errorType, level, msg, fields)
+}
+
+//write message with entry fields and key-value pairs
+//params : level int, msg string, keysAndValues []interface{}
+//return : error
+func (entry *Entry) writerw(level int, msg string, keysAndValues []interface{}) error {
+	fields := entry.messageFields()
+	pairs, misuseErr := keyValuesToFields(keysAndValues)
+	for key, value := range pairs {
+		fields[key] = value
+	}
+	errorType := errorTypeOf(keysAndValues)
+	if errorType == "" {
+		errorType = entry.errorType
+	}
+	err := entry.write(3, errorType, level, msg, fields)
+	if misuseErr != nil {
+		return entry.logger.misuse(misuseErr)
+	}
+	return err
+}
+
+//log fatal at emergency level with entry fields, then run fatal policy
+func (entry *Entry) Fatal(msg string) {
+	entry.writer(2, LoggerLevelEmergency, msg, entry.messageFields())
+	entry.logger.fatal()
+}
+
+//log fatal format at emergency level with entry fields, then run fatal policy
+func (entry *Entry) Fatalf(format string, a ...interface{}) {
+	msg := fmt.Sprintf(format, a...)
+	entry.writer(2, LoggerLevelEmergency, msg, entry.messageFields())
+	entry.logger.fatal()
+}
+
+//log fatal key-values at emergency level with entry fields, then run fatal policy
+func (entry *Entry) Fatalw(msg string, keysAndValues ...interface{}) {
+	entry.writerw(LoggerLevelEmergency, msg, keysAndValues)
+	entry.logger.fatal()
+}
+
+//log panic at emergency level with entry fields, flush, then panic with msg
+func (entry *Entry) Panic(msg string) {
+	entry.writer(2, LoggerLevelEmergency, msg, entry.messageFields())
+	entry.logger.Flush()
+	panic(msg)
+}
+
+//log panic format at emergency level with entry fields, flush, then panic with msg
+func (entry *Entry) Panicf(format string, a ...interface{}) {
+	msg := fmt.Sprintf(format, a...)
+	entry.writer(2, LoggerLevelEmergency, msg, entry.messageFields())
+	entry.logger.Flush()
+	panic(msg)
+}
+
+//log panic key-values at emergency level with entry fields, flush, then panic with msg
+func (entry *Entry) Panicw(msg string, keysAndValues ...interface{}) {
+	entry.writerw(LoggerLevelEmergency, msg, keysAndValues)
+	entry.logger.Flush()
+	panic(msg)
+}
@@ -0,0 +1,50 @@
+package go_logger
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestLogger_WithFields(t *testing.T) {
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("console", LoggerLevelDebug, &ConsoleConfig{
+		Format: "%file% [%level_string%] %body% %fields%",
+	})
+	buffer := &bytes.Buffer{}
+	logger.outputList()[0].LoggerAbstract.(*AdapterConsole).write.writer = buffer
+
+	entry := logger.WithFields(map[string]interface{}{"user": "tom", "err": errors.New("timeout")})
+	entry.Info("logger entry test")
+	entry.WithField("user", "jerry").Errorf("logger entry %s", "format")
+	entry.Debugw("logger entry key-values", "id", 1)
+
+	file := loggerTestCallerFile("entry_test.go")
+	expected := file + " [Info] logger entry test err=timeout user=tom\n" +
+		file + " [Error] logger entry format err=timeout user=jerry\n" +
+		file + " [Debug] logger entry key-values err=timeout id=1 user=tom\n"
+	if buffer.String() != expected {
+		t.Error("logger entry output error: " + buffer.String())
+	}
+	if len(entry.fields) != 2 || entry.fields["user"] != "tom" {
+		t.Error("derived entries must not change the parent entry")
+	}
+}
+
+func TestLogger_WithFieldsJson(t *testing.T) {
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("console", LoggerLevelDebug, &ConsoleConfig{
+		JsonFormat: true,
+	})
+	buffer := &bytes.Buffer{}
+	logger.outputList()[0].LoggerAbstract.(*AdapterConsole).write.writer = buffer
+
+	logger.WithField("request_id", "abc").Info("logger entry json test")
+	if !bytes.Contains(buffer.Bytes(), []byte(`"fields":{"request_id":"abc"}`)) {
+		t.Error("logger entry json output must have fields: " + buffer.String())
+	}
+}
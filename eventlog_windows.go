@@ -0,0 +1,167 @@
+//go:build windows
+// +build windows
+
+package go_logger
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"syscall"
+	"unsafe"
+)
+
+const EVENTLOG_ADAPTER_NAME = "eventlog"
+
+// windows event types
+const (
+	eventlogErrorType       = 0x0001
+	eventlogWarningType     = 0x0002
+	eventlogInformationType = 0x0004
+)
+
+var (
+	advapi32                  = syscall.NewLazyDLL("advapi32.dll")
+	procRegisterEventSourceW  = advapi32.NewProc("RegisterEventSourceW")
+	procDeregisterEventSource = advapi32.NewProc("DeregisterEventSource")
+	procReportEventW          = advapi32.NewProc("ReportEventW")
+)
+
+// event type of levels
+var levelEventlogTypes = map[int]uint16{
+	LoggerLevelEmergency: eventlogErrorType,
+	LoggerLevelAlert:     eventlogErrorType,
+	LoggerLevelCritical:  eventlogErrorType,
+	LoggerLevelError:     eventlogErrorType,
+	LoggerLevelWarning:   eventlogWarningType,
+	LoggerLevelNotice:    eventlogInformationType,
+	LoggerLevelInfo:      eventlogInformationType,
+	LoggerLevelDebug:     eventlogInformationType,
+}
+
+// adapter eventlog, write to the windows event log, for services deployed as windows services
+type AdapterEventlog struct {
+	lock   sync.Mutex
+	handle uintptr
+	config *EventlogConfig
+}
+
+// eventlog config
+type EventlogConfig struct {
+
+	// event source name, registered in the Application log, eg: by
+	// eventcreate /l APPLICATION /so "MyService" /t INFORMATION /id 1 /d "install"
+	Source string `config:"required"`
+
+	// event id of levels, default the level + 1, Emergency is 1 ... Debug is 8
+	EventIds map[int]uint32
+
+	// please input format string
+	// if format is empty, default format "%body%", the event log shows time and type itself
+	Format string
+}
+
+func (ec *EventlogConfig) Name() string {
+	return EVENTLOG_ADAPTER_NAME
+}
+
+func NewAdapterEventlog() LoggerAbstract {
+	return &AdapterEventlog{
+		config: &EventlogConfig{},
+	}
+}
+
+func (adapterEventlog *AdapterEventlog) Init(eventlogConfig Config) error {
+	if eventlogConfig.Name() != EVENTLOG_ADAPTER_NAME {
+		return errors.New("logger eventlog adapter init error, config must EventlogConfig")
+	}
+
+	ec := eventlogConfig.(*EventlogConfig)
+	adapterEventlog.config = ec
+
+	if ec.Source == "" {
+		return errors.New("config Source cannot be empty!")
+	}
+	for level := range ec.EventIds {
+		if _, ok := levelStringMapping[level]; !ok {
+			return errors.New("config EventIds key level is illegal!")
+		}
+	}
+	if ec.Format == "" {
+		ec.Format = "%body%"
+	}
+
+	source, err := syscall.UTF16PtrFromString(ec.Source)
+	if err != nil {
+		return err
+	}
+	handle, _, err := procRegisterEventSourceW.Call(0, uintptr(unsafe.Pointer(source)))
+	if handle == 0 {
+		return err
+	}
+	adapterEventlog.handle = handle
+	return nil
+}
+
+func (adapterEventlog *AdapterEventlog) Write(loggerMsg *loggerMessage) error {
+	eventType, ok := levelEventlogTypes[loggerMsg.Level]
+	if !ok {
+		eventType = eventlogInformationType
+	}
+	eventId, ok := adapterEventlog.config.EventIds[loggerMsg.Level]
+	if !ok {
+		eventId = uint32(loggerMsg.Level + 1)
+	}
+	// NUL cannot be in event strings
+	msg := strings.Replace(loggerMsg.formatText(adapterEventlog.config.Format), "\x00", " ", -1)
+	text, err := syscall.UTF16PtrFromString(msg)
+	if err != nil {
+		return err
+	}
+
+	adapterEventlog.lock.Lock()
+	defer adapterEventlog.lock.Unlock()
+	if adapterEventlog.handle == 0 {
+		return errors.New("logger eventlog adapter write error, event source is closed")
+	}
+	strs := []*uint16{text}
+	reported, _, err := procReportEventW.Call(adapterEventlog.handle, uintptr(eventType), 0, uintptr(eventId),
+		0, 1, 0, uintptr(unsafe.Pointer(&strs[0])), 0)
+	if reported == 0 {
+		return err
+	}
+	return nil
+}
+
+func (adapterEventlog *AdapterEventlog) Flush() {
+
+}
+
+//deregister the event source, the adapter must not be written after Close
+func (adapterEventlog *AdapterEventlog) Close() error {
+	adapterEventlog.lock.Lock()
+	defer adapterEventlog.lock.Unlock()
+
+	if adapterEventlog.handle == 0 {
+		return nil
+	}
+	ok, _, err := procDeregisterEventSource.Call(adapterEventlog.handle)
+	adapterEventlog.handle = 0
+	if ok == 0 {
+		return err
+	}
+	return nil
+}
+
+func (adapterEventlog *AdapterEventlog) Name() string {
+	return EVENTLOG_ADAPTER_NAME
+}
+
+//new empty config of the adapter, describes the config fields
+func (adapterEventlog *AdapterEventlog) NewConfig() Config {
+	return &EventlogConfig{}
+}
+
+func init() {
+	Register(EVENTLOG_ADAPTER_NAME, NewAdapterEventlog)
+}
@@ -0,0 +1,96 @@
+package go_logger
+
+import (
+	"errors"
+	"math/rand"
+)
+
+// field name of example records kept for suppressed or aggregated messages
+const exemplarsField = "exemplars"
+
+// up to k example records of a bucket: the first, random ones and the last
+type exemplarSet struct {
+	k         int
+	first     *loggerMessage
+	last      *loggerMessage
+	reservoir []*loggerMessage // random examples between first and last
+	displaced int              // messages displaced from last, reservoir candidates
+}
+
+func newExemplarSet(k int) *exemplarSet {
+	return &exemplarSet{k: k}
+}
+
+//add a message of the bucket
+func (set *exemplarSet) add(loggerMsg *loggerMessage) {
+	if set.first == nil {
+		set.first = loggerMsg
+		return
+	}
+	if set.k < 2 {
+		return
+	}
+	previous := set.last
+	set.last = loggerMsg
+	if previous == nil || set.k < 3 {
+		return
+	}
+	// reservoir sampling over the messages between first and last
+	set.displaced++
+	if len(set.reservoir) < set.k-2 {
+		set.reservoir = append(set.reservoir, previous)
+	} else if i := rand.Intn(set.displaced); i < len(set.reservoir) {
+		set.reservoir[i] = previous
+	}
+}
+
+//example records of the bucket, nil if empty
+func (set *exemplarSet) records() []map[string]interface{} {
+	if set == nil || set.first == nil {
+		return nil
+	}
+	records := []map[string]interface{}{exemplarRecord(set.first)}
+	for _, loggerMsg := range set.reservoir {
+		records = append(records, exemplarRecord(loggerMsg))
+	}
+	if set.last != nil {
+		records = append(records, exemplarRecord(set.last))
+	}
+	return records
+}
+
+//example record of a message
+func exemplarRecord(loggerMsg *loggerMessage) map[string]interface{} {
+	record := map[string]interface{}{
+		"millisecond":  loggerMsg.Millisecond,
+		"level_string": loggerMsg.LevelString,
+		"body":         loggerMsg.Body,
+		"file":         loggerMsg.File,
+		"line":         loggerMsg.Line,
+	}
+	if len(loggerMsg.Fields) > 0 {
+		record["fields"] = loggerMsg.Fields
+	}
+	return record
+}
+
+//keep up to k suppressed example records (first, random and last) per error sample,
+//written in the "exemplars" field with the repeated count
+//params : k int, 0 to disable
+//return : error
+func (logger *Logger) SetErrorSamplingExemplars(k int) error {
+	if k < 0 {
+		return errors.New("logger: error sampling exemplars cannot be negative!")
+	}
+	logger.lock.Lock()
+	defer logger.lock.Unlock()
+
+	sampler, _ := logger.errorSampler.Load().(*errorSampler)
+	if sampler == nil {
+		return errors.New("logger: error sampling is not enabled!")
+	}
+	sampler.lock.Lock()
+	sampler.exemplars = k
+	sampler.lock.Unlock()
+	return nil
+}
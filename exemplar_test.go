@@ -0,0 +1,63 @@
+package go_logger
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestExemplarSet_add(t *testing.T) {
+
+	set := newExemplarSet(4)
+	if set.records() != nil {
+		t.Error("empty exemplar set must have no records")
+	}
+	for i := 0; i < 100; i++ {
+		set.add(&loggerMessage{Body: strconv.Itoa(i)})
+	}
+	records := set.records()
+	if len(records) != 4 || records[0]["body"] != "0" || records[3]["body"] != "99" {
+		t.Errorf("exemplar set must keep first, random and last: %v", records)
+	}
+	for _, record := range records[1:3] {
+		i, _ := strconv.Atoi(record["body"].(string))
+		if i <= 0 || i >= 99 {
+			t.Errorf("exemplar set random record must be between first and last: %v", record)
+		}
+	}
+
+	set = newExemplarSet(1)
+	set.add(&loggerMessage{Body: "first"})
+	set.add(&loggerMessage{Body: "second"})
+	if records := set.records(); len(records) != 1 || records[0]["body"] != "first" {
+		t.Errorf("exemplar set of 1 must keep the first: %v", records)
+	}
+}
+
+func TestLogger_SetErrorSamplingExemplars(t *testing.T) {
+
+	logger := NewLogger()
+	if logger.SetErrorSamplingExemplars(3) == nil {
+		t.Error("exemplars without error sampling must be error")
+	}
+	logger.SetErrorSampling(time.Minute, 0)
+	if logger.SetErrorSamplingExemplars(-1) == nil {
+		t.Error("negative exemplars must be error")
+	}
+	if err := logger.SetErrorSamplingExemplars(2); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	sampler := logger.errorSampler.Load().(*errorSampler)
+	now := time.Now()
+	sampler.check(&loggerMessage{Level: LoggerLevelError, Body: "timeout"}, now)
+	for i := 1; i <= 3; i++ {
+		sampler.check(&loggerMessage{Level: LoggerLevelError, Body: "timeout", Line: i}, now.Add(time.Second))
+	}
+	loggerMsg := &loggerMessage{Level: LoggerLevelError, Body: "timeout"}
+	sampler.check(loggerMsg, now.Add(2*time.Minute))
+	records, _ := loggerMsg.Fields[exemplarsField].([]map[string]interface{})
+	if len(records) != 2 || records[0]["line"] != 1 || records[1]["line"] != 3 {
+		t.Errorf("error sampling exemplars error: %v", loggerMsg.Fields)
+	}
+}
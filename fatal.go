@@ -0,0 +1,138 @@
+package go_logger
+
+import (
+	"fmt"
+	"os"
+	"sync"
+)
+
+// exit function, replaced in tests
+var osExit = os.Exit
+
+// process exit handlers, run by the fatal policy of every logger
+var exitHandlers = struct {
+	lock     sync.Mutex
+	handlers []func()
+}{}
+
+// fatal policy, invoked by Fatal-level logging
+type FatalPolicy struct {
+
+	// process exit code
+	ExitCode int
+
+	// dump the recent buffer to stderr before exit, see SetRecentBuffer
+	DumpRecent bool
+
+	// run hooks registered by OnFatal and RegisterExitHandler before exit
+	RunHooks bool
+}
+
+// default fatal policy, exit code 1 and run hooks
+var DefaultFatalPolicy = FatalPolicy{
+	ExitCode: 1,
+	RunHooks: true,
+}
+
+//set fatal policy
+//params : policy FatalPolicy
+func (logger *Logger) SetFatalPolicy(policy FatalPolicy) {
+	logger.lock.Lock()
+	defer logger.lock.Unlock()
+
+	logger.fatalPolicy = policy
+}
+
+//register a cleanup hook run by fatal policy, hooks run in registration order
+//params : hook func()
+func (logger *Logger) OnFatal(hook func()) {
+	logger.lock.Lock()
+	defer logger.lock.Unlock()
+
+	logger.fatalHooks = append(logger.fatalHooks, hook)
+}
+
+//register a process exit handler run by Fatal of any logger, after the logger hooks
+//params : handler func()
+func RegisterExitHandler(handler func()) {
+	exitHandlers.lock.Lock()
+	defer exitHandlers.lock.Unlock()
+
+	exitHandlers.handlers = append(exitHandlers.handlers, handler)
+}
+
+//run fatal policy: hooks, flush, dump recent buffer, then exit
+func (logger *Logger) fatal() {
+	logger.lock.Lock()
+	policy := logger.fatalPolicy
+	hooks := append([]func(){}, logger.fatalHooks...)
+	logger.lock.Unlock()
+	exitHandlers.lock.Lock()
+	hooks = append(hooks, exitHandlers.handlers...)
+	exitHandlers.lock.Unlock()
+
+	if policy.RunHooks {
+		for _, hook := range hooks {
+			runFatalHook(hook)
+		}
+	}
+	logger.Flush()
+	if policy.DumpRecent {
+		recent, _ := logger.recent.Load().(*recentBuffer)
+		for _, loggerMsg := range recent.snapshot() {
+			fmt.Fprintln(os.Stderr, loggerMessageFormat(defaultLoggerMessageFormat, loggerMsg))
+		}
+	}
+	osExit(policy.ExitCode)
+}
+
+//run hook, a panic does not stop the fatal policy
+func runFatalHook(hook func()) {
+	defer func() {
+		if e := recover(); e != nil {
+			fmt.Fprintf(os.Stderr, "logger: fatal hook panic, error: %v\n", e)
+		}
+	}()
+	hook()
+}
+
+//log fatal at emergency level, then run fatal policy
+func (logger *Logger) Fatal(msg string) {
+	logger.writer(2, LoggerLevelEmergency, msg, nil)
+	logger.fatal()
+}
+
+//log fatal format at emergency level, then run fatal policy
+func (logger *Logger) Fatalf(format string, a ...interface{}) {
+	msg := fmt.Sprintf(format, a...)
+	logger.writer(2, LoggerLevelEmergency, msg, nil)
+	logger.fatal()
+}
+
+//log fatal key-values at emergency level, then run fatal policy
+func (logger *Logger) Fatalw(msg string, keysAndValues ...interface{}) {
+	logger.writerw(LoggerLevelEmergency, msg, keysAndValues)
+	logger.fatal()
+}
+
+//log panic at emergency level, flush, then panic with msg
+func (logger *Logger) Panic(msg string) {
+	logger.writer(2, LoggerLevelEmergency, msg, nil)
+	logger.Flush()
+	panic(msg)
+}
+
+//log panic format at emergency level, flush, then panic with msg
+func (logger *Logger) Panicf(format string, a ...interface{}) {
+	msg := fmt.Sprintf(format, a...)
+	logger.writer(2, LoggerLevelEmergency, msg, nil)
+	logger.Flush()
+	panic(msg)
+}
+
+//log panic key-values at emergency level, flush, then panic with msg
+func (logger *Logger) Panicw(msg string, keysAndValues ...interface{}) {
+	logger.writerw(LoggerLevelEmergency, msg, keysAndValues)
+	logger.Flush()
+	panic(msg)
+}
@@ -0,0 +1,117 @@
+package go_logger
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestLogger_Fatal(t *testing.T) {
+
+	exitCode := -1
+	osExit = func(code int) {
+		exitCode = code
+	}
+	defer func() {
+		osExit = os.Exit
+	}()
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("console", LoggerLevelDebug, &ConsoleConfig{
+		Format: "[%level_string%] %body%",
+	})
+	buffer := &bytes.Buffer{}
+	logger.outputList()[0].LoggerAbstract.(*AdapterConsole).write.writer = buffer
+
+	hooks := []string{}
+	logger.OnFatal(func() {
+		hooks = append(hooks, "first")
+		panic("logger fatal hook panic")
+	})
+	logger.OnFatal(func() {
+		hooks = append(hooks, "second")
+		logger.Info("logger fatal cleanup")
+	})
+
+	logger.Fatal("logger fatal test")
+	if exitCode != 1 {
+		t.Errorf("default fatal policy exit code must be 1, got %d", exitCode)
+	}
+	if len(hooks) != 2 || hooks[1] != "second" {
+		t.Error("default fatal policy must run all hooks in order")
+	}
+	if buffer.String() != "[Emergency] logger fatal test\n[Info] logger fatal cleanup\n" {
+		t.Error("fatal output error: " + buffer.String())
+	}
+
+	hooks = hooks[:0]
+	logger.SetFatalPolicy(FatalPolicy{ExitCode: 3})
+	logger.Fatalf("logger fatal %s", "test")
+	if exitCode != 3 || len(hooks) != 0 {
+		t.Error("fatal policy exit code and hooks error")
+	}
+}
+
+func TestRegisterExitHandler(t *testing.T) {
+
+	osExit = func(code int) {}
+	defer func() {
+		osExit = os.Exit
+	}()
+
+	handled := false
+	RegisterExitHandler(func() {
+		handled = true
+	})
+	defer func() {
+		exitHandlers.handlers = nil
+	}()
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Fatal("logger exit handler test")
+	if !handled {
+		t.Error("fatal must run registered exit handlers")
+	}
+}
+
+func TestLogger_Panic(t *testing.T) {
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("count", LoggerLevelDebug, &countConfig{})
+	count := logger.outputList()[0].LoggerAbstract.(*adapterCount)
+	logger.SetAsync()
+
+	defer func() {
+		if recover() != "logger panic test" {
+			t.Error("Panicf must panic with the message")
+		}
+		if count.writes != 1 {
+			t.Error("Panicf must flush the message before panic")
+		}
+	}()
+	logger.Panicf("logger panic %s", "test")
+}
+
+func TestEntry_Panicw(t *testing.T) {
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("console", LoggerLevelDebug, &ConsoleConfig{
+		Format: "[%level_string%] %body% %fields%",
+	})
+	buffer := &bytes.Buffer{}
+	logger.outputList()[0].LoggerAbstract.(*AdapterConsole).write.writer = buffer
+
+	defer func() {
+		if recover() != "logger entry panic" {
+			t.Error("entry Panicw must panic with the message")
+		}
+		if buffer.String() != "[Emergency] logger entry panic id=1 user=tom\n" {
+			t.Error("entry Panicw output error: " + buffer.String())
+		}
+	}()
+	logger.WithField("user", "tom").Panicw("logger entry panic", "id", 1)
+}
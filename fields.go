@@ -0,0 +1,75 @@
+package go_logger
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// key prefix of the value without a string key, followed by the index of the key
+const badFieldKey = "!BADKEY"
+
+//convert alternating key-value pairs to fields
+//a value without string key is saved with key "!BADKEY" and the index of the key, e.g. "!BADKEY2"
+//params : keysAndValues []interface{}
+//return : fields, misuse error of the first bad pair
+func keyValuesToFields(keysAndValues []interface{}) (map[string]interface{}, error) {
+	if len(keysAndValues) == 0 {
+		return nil, nil
+	}
+	var err error
+	fields := make(map[string]interface{}, (len(keysAndValues)+1)/2)
+	for i := 0; i < len(keysAndValues); i += 2 {
+		key, ok := keysAndValues[i].(string)
+		if i+1 >= len(keysAndValues) {
+			if err == nil {
+				err = fmt.Errorf("logger: key-value pairs count is odd, key %v has no value!", keysAndValues[i])
+			}
+			fields[badFieldKey+strconv.Itoa(i)] = fieldValue(keysAndValues[i])
+			break
+		}
+		if !ok {
+			if err == nil {
+				err = fmt.Errorf("logger: key-value key %v(%T) is not a string!", keysAndValues[i], keysAndValues[i])
+			}
+			fields[badFieldKey+strconv.Itoa(i)] = fieldValue(keysAndValues[i+1])
+			continue
+		}
+		fields[key] = fieldValue(keysAndValues[i+1])
+	}
+	return fields, err
+}
+
+//error value is saved as error string
+func fieldValue(value interface{}) interface{} {
+	if err, ok := value.(error); ok {
+		return err.Error()
+	}
+	return value
+}
+
+//format fields to "key=value key=value", keys are sorted
+func loggerFieldsFormat(fields map[string]interface{}) string {
+	if len(fields) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		value, ok := formatValue(fields[key])
+		if !ok {
+			value = fmt.Sprint(fields[key])
+		}
+		if value == "" || strings.ContainsAny(value, " =\"") {
+			value = strconv.Quote(value)
+		}
+		pairs = append(pairs, key+"="+value)
+	}
+	return strings.Join(pairs, " ")
+}
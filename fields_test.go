@@ -0,0 +1,62 @@
+package go_logger
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestKeyValuesToFields(t *testing.T) {
+
+	fields, err := keyValuesToFields([]interface{}{"user", "tom", "error", errors.New("timeout")})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if fields["user"] != "tom" || fields["error"] != "timeout" {
+		t.Error("key-values to fields error")
+	}
+
+	fields, err = keyValuesToFields([]interface{}{"user", "tom", 10})
+	if err == nil || fields[badFieldKey+"2"] != 10 {
+		t.Error("key-values odd count error")
+	}
+
+	fields, err = keyValuesToFields([]interface{}{10, "tom", "user", "tom", 20, "jerry", "id"})
+	if err == nil || err.Error() != "logger: key-value key 10(int) is not a string!" {
+		t.Errorf("key-values non-string key must report the first bad key: %v", err)
+	}
+	if fields[badFieldKey+"0"] != "tom" || fields[badFieldKey+"4"] != "jerry" || fields[badFieldKey+"6"] != "id" || fields["user"] != "tom" {
+		t.Errorf("key-values non-string keys must keep every value: %v", fields)
+	}
+}
+
+func TestLoggerFieldsFormat(t *testing.T) {
+
+	str := loggerFieldsFormat(map[string]interface{}{
+		"b":    2,
+		"a":    "one",
+		"desc": "hello world",
+	})
+	if str != `a=one b=2 desc="hello world"` {
+		t.Error("logger fields format error: " + str)
+	}
+}
+
+func TestLoggerMessage_JsonFields(t *testing.T) {
+
+	loggerMsg := &loggerMessage{
+		Body:   "logger json fields test",
+		Fields: map[string]interface{}{"user": "tom"},
+	}
+	jsonByte, err := loggerMsg.MarshalJSON()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	decodeMsg := &loggerMessage{}
+	err = decodeMsg.UnmarshalJSON(jsonByte)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if decodeMsg.Fields["user"] != "tom" {
+		t.Error("logger message json fields error: " + string(jsonByte))
+	}
+}
@@ -2,10 +2,14 @@ package go_logger
 
 import (
 	"errors"
+	"fmt"
 	"github.com/qjyoung/go-logger/utils"
+	"io/ioutil"
 	"os"
 	"path"
-	"reflect"
+	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -21,6 +25,12 @@ const (
 	FILE_SLICE_DATE_HOUR  = "h"
 )
 
+const (
+	FILE_ROTATE_PERIOD_NULL   = ""
+	FILE_ROTATE_PERIOD_DAILY  = "daily"
+	FILE_ROTATE_PERIOD_HOURLY = "hourly"
+)
+
 const (
 	FILE_ACCESS_LEVEL = 1000
 )
@@ -29,6 +39,9 @@ const (
 type AdapterFile struct {
 	write  map[int]*FileWriter
 	config *FileConfig
+
+	levelTemplate bool           // Filename has %level_string%, write map keys are levels
+	hupChan       chan os.Signal // SIGHUP notifications of ReopenOnHUP, nil if not notified
 }
 
 // file writer
@@ -37,7 +50,22 @@ type FileWriter struct {
 	writer    *os.File
 	startLine int64
 	startTime int64
+	size      int64 // current file size bytes
 	filename  string
+	base      string // configured filename of period files, "" before the first period
+	periodKey string // time of the current period file
+
+	maxBackups int // rotated files kept, 0 keeps all
+	maxAge     int // rotated files kept days, 0 keeps all
+
+	compressor   Compressor     // rotated files compressor, nil is not compressed
+	compressing  sync.WaitGroup // rotated files being compressed
+	compressLock sync.Mutex     // rotated files are compressed one at a time
+
+	fileMode   os.FileMode // mode of created files, 0 is 0666 before umask
+	dirMode    os.FileMode // mode of created parent directories
+	createDirs bool        // create missing parent directories
+	symlink    bool        // base is a symlink to the current period file
 }
 
 func NewFileWrite(fn string) *FileWriter {
@@ -50,12 +78,19 @@ func NewFileWrite(fn string) *FileWriter {
 type FileConfig struct {
 
 	// log filename
+	// with "%level_string%" each level is written to its own file, "app.%level_string%.log" is app.error.log, app.info.log ...
 	Filename string
 
+	// Filename "%level_string%" replacement of levels, levels of the same group share a file
+	// e.g. {LoggerLevelEmergency: "error", LoggerLevelAlert: "error", LoggerLevelCritical: "error"}
+	// default the lower case level string
+	LevelGroup map[int]string
+
 	// level log filename
 	LevelFileName map[int]string
 
-	// max file size
+	// max file size (KB), the file is rotated before a write would exceed it
+	// rotated file is renamed to file.time.log, with an index if the name exists
 	MaxSize int64
 
 	// max file line
@@ -68,9 +103,50 @@ type FileConfig struct {
 	// "h" Log files are cut through hour
 	DateSlice string
 
+	// start a new file at each period boundary, app.log is written to app-2024-05-01.log
+	// "daily" app-2024-05-01.log
+	// "hourly" app-2024-05-01-15.log
+	// DateSlice must be empty
+	RotatePeriod string
+
+	// RotatePeriod boundaries are in UTC, default local time
+	RotateUTC bool
+
+	// keep Filename a symlink to the current RotatePeriod file, tailing tools follow the live file
+	// Filename must not be a regular file, symlinks need privileges on windows
+	LatestSymlink bool
+
+	// rotated files kept, the oldest are deleted on rotation, 0 keeps all
+	MaxBackups int
+
+	// days rotated files are kept, older ones are deleted on rotation, 0 keeps all
+	MaxAge int
+
+	// rotated files compression, a registered compressor name e.g. "gzip", default none
+	// rotated files are compressed in the background and get the compressor extension, app.2024-05-01.log.gz
+	Compression string
+
+	// mode of created files, e.g. 0640, applied regardless of umask
+	// default 0 is 0666 before umask
+	FileMode os.FileMode
+
+	// create missing parent directories of the files
+	CreateDirs bool
+
+	// mode of created parent directories, default 0755 (before umask)
+	DirMode os.FileMode
+
+	// close and reopen the files on SIGHUP, for an external logrotate moving the files
+	// not supported on windows
+	ReopenOnHUP bool
+
 	// is json format
 	JsonFormat bool
 
+	// jsonFormat is true, JSON field mapping profile
+	// "" default, "gelf" Graylog GELF, "ecs" Elastic Common Schema, "otel" OpenTelemetry
+	JsonProfile string
+
 	// jsonFormat is false, please input format string
 	// if format is empty, default format "%millisecond_format% [%level_string%] %body%"
 	//
@@ -84,6 +160,8 @@ type FileConfig struct {
 	//	File string "%file%"
 	//	Line int "%line%"
 	//	Function "%function%"
+	//	Name "%name%", named child logger name
+	//	Fields "%fields%", if not in format, non-empty fields are appended
 	//
 	// example: format = "%millisecond_format% [%level_string%] %body%"
 	Format string
@@ -93,7 +171,15 @@ func (fc *FileConfig) Name() string {
 	return FILE_ADAPTER_NAME
 }
 
+// time layout of the period file names
+var fileRotatePeriodLayouts = map[string]string{
+	FILE_ROTATE_PERIOD_NULL:   "",
+	FILE_ROTATE_PERIOD_DAILY:  "2006-01-02",
+	FILE_ROTATE_PERIOD_HOURLY: "2006-01-02-15",
+}
+
 var fileSliceDateMapping = map[string]int{
+	FILE_SLICE_DATE_NULL:  -1,
 	FILE_SLICE_DATE_YEAR:  0,
 	FILE_SLICE_DATE_MONTH: 1,
 	FILE_SLICE_DATE_DAY:   2,
@@ -113,13 +199,15 @@ func (adapterFile *AdapterFile) Init(fileConfig Config) error {
 		return errors.New("logger file adapter init error, config must FileConfig")
 	}
 
-	vc := reflect.ValueOf(fileConfig)
-	fc := vc.Interface().(*FileConfig)
+	fc := fileConfig.(*FileConfig)
 	adapterFile.config = fc
 
 	if fc.JsonFormat == false && fc.Format == "" {
 		fc.Format = defaultLoggerMessageFormat
 	}
+	if err := checkJsonProfile(fc.JsonProfile); err != nil {
+		return err
+	}
 
 	if len(adapterFile.config.LevelFileName) == 0 {
 		if adapterFile.config.Filename == "" {
@@ -130,6 +218,33 @@ func (adapterFile *AdapterFile) Init(fileConfig Config) error {
 	if !ok {
 		return errors.New("config DateSlice must be one of the 'y', 'd', 'm','h'!")
 	}
+	_, ok = fileRotatePeriodLayouts[adapterFile.config.RotatePeriod]
+	if !ok {
+		return errors.New("config RotatePeriod must be one of the 'daily', 'hourly'!")
+	}
+	if adapterFile.config.RotatePeriod != "" && adapterFile.config.DateSlice != "" {
+		return errors.New("config RotatePeriod and DateSlice cannot be both set!")
+	}
+	if adapterFile.config.LatestSymlink && adapterFile.config.RotatePeriod == "" {
+		return errors.New("config LatestSymlink needs RotatePeriod!")
+	}
+	if adapterFile.config.MaxBackups < 0 || adapterFile.config.MaxAge < 0 {
+		return errors.New("config MaxBackups and MaxAge cannot be negative!")
+	}
+	if _, err := compressorByName(adapterFile.config.Compression); err != nil {
+		return err
+	}
+	if adapterFile.config.FileMode&^os.ModePerm != 0 || adapterFile.config.DirMode&^os.ModePerm != 0 {
+		return errors.New("config FileMode and DirMode must be permission bits!")
+	}
+	if strings.Contains(adapterFile.config.Filename, "%level_string%") && len(adapterFile.config.LevelFileName) > 0 {
+		return errors.New("config LevelFileName cannot be used with a %level_string% Filename!")
+	}
+	for level := range adapterFile.config.LevelGroup {
+		if _, ok := levelStringMapping[level]; !ok {
+			return errors.New("config LevelGroup key level is illegal!")
+		}
+	}
 
 	// init FileWriter
 	if len(adapterFile.config.LevelFileName) > 0 {
@@ -140,18 +255,49 @@ func (adapterFile *AdapterFile) Init(fileConfig Config) error {
 				return errors.New("config LevelFileName key level is illegal!")
 			}
 			fw := NewFileWrite(filename)
-			fw.initFile()
+			fw.open(adapterFile.config)
 			fileWriters[level] = fw
 		}
 		adapterFile.write = fileWriters
 	}
 
-	if adapterFile.config.Filename != "" {
+	if strings.Contains(adapterFile.config.Filename, "%level_string%") {
+		err := adapterFile.initLevelTemplate()
+		if err != nil {
+			return err
+		}
+	} else if adapterFile.config.Filename != "" {
 		fw := NewFileWrite(adapterFile.config.Filename)
-		fw.initFile()
+		fw.open(adapterFile.config)
 		adapterFile.write[FILE_ACCESS_LEVEL] = fw
 	}
 
+	if adapterFile.config.ReopenOnHUP {
+		return adapterFile.reopenOnHUP()
+	}
+	return nil
+}
+
+// init a FileWriter per level group of the %level_string% Filename, files are created at init
+func (adapterFile *AdapterFile) initLevelTemplate() error {
+	groupWriters := map[string]*FileWriter{}
+	for level, levelString := range levelStringMapping {
+		group := strings.ToLower(levelString)
+		if levelGroup, ok := adapterFile.config.LevelGroup[level]; ok {
+			group = levelGroup
+		}
+		if group == "" {
+			return errors.New("config LevelGroup group cannot be empty!")
+		}
+		fw, ok := groupWriters[group]
+		if !ok {
+			fw = NewFileWrite(strings.Replace(adapterFile.config.Filename, "%level_string%", group, -1))
+			fw.open(adapterFile.config)
+			groupWriters[group] = fw
+		}
+		adapterFile.write[level] = fw
+	}
+	adapterFile.levelTemplate = true
 	return nil
 }
 
@@ -164,7 +310,11 @@ func (adapterFile *AdapterFile) Write(loggerMsg *loggerMessage) error {
 	// access file write
 	if adapterFile.config.Filename != "" {
 		go func() {
-			accessFileWrite, ok := adapterFile.write[FILE_ACCESS_LEVEL]
+			accessLevel := FILE_ACCESS_LEVEL
+			if adapterFile.levelTemplate {
+				accessLevel = loggerMsg.Level
+			}
+			accessFileWrite, ok := adapterFile.write[accessLevel]
 			if !ok {
 				accessChan <- nil
 				return
@@ -214,9 +364,64 @@ func (adapterFile *AdapterFile) Write(loggerMsg *loggerMessage) error {
 
 // Flush
 func (adapterFile *AdapterFile) Flush() {
+	adapterFile.FlushError()
+}
+
+// Flush and return the first error, files are synced to disk and kept open
+func (adapterFile *AdapterFile) FlushError() error {
+	var err error
+	for _, fileWrite := range adapterFile.write {
+		fileWrite.lock.Lock()
+		if fileWrite.writer != nil {
+			if syncErr := fileWrite.writer.Sync(); syncErr != nil && err == nil {
+				err = syncErr
+			}
+		}
+		fileWrite.lock.Unlock()
+	}
+	return err
+}
+
+// Close and reopen the files by path, files moved by an external logrotate are recreated
+func (adapterFile *AdapterFile) Reopen() error {
+	var err error
 	for _, fileWrite := range adapterFile.write {
-		fileWrite.writer.Close()
+		if reopenErr := fileWrite.reopen(); reopenErr != nil && err == nil {
+			err = reopenErr
+		}
+	}
+	return err
+}
+
+// Rotate the files now, the current files are renamed to file.time.log and new files are opened
+// empty files are not rotated
+func (adapterFile *AdapterFile) Rotate() error {
+	var err error
+	for _, fileWrite := range adapterFile.write {
+		if rotateErr := fileWrite.forceRotate(); rotateErr != nil && err == nil {
+			err = rotateErr
+		}
+	}
+	return err
+}
+
+// Close files and return the first error, the adapter must not be written after Close
+func (adapterFile *AdapterFile) Close() error {
+	adapterFile.stopReopenOnHUP()
+	var err error
+	for _, fileWrite := range adapterFile.write {
+		fileWrite.lock.Lock()
+		if fileWrite.writer != nil {
+			if closeErr := fileWrite.writer.Close(); closeErr != nil && err == nil {
+				err = closeErr
+			}
+			fileWrite.writer = nil
+		}
+		fileWrite.lock.Unlock()
+		// rotated files are compressed before Close returns
+		fileWrite.compressing.Wait()
 	}
+	return err
 }
 
 // Name
@@ -224,13 +429,18 @@ func (adapterFile *AdapterFile) Name() string {
 	return FILE_ADAPTER_NAME
 }
 
+//new empty config of the adapter, describes the config fields
+func (adapterFile *AdapterFile) NewConfig() Config {
+	return &FileConfig{}
+}
+
 // init file
 func (fw *FileWriter) initFile() error {
 
 	//check file exits, otherwise create a file
 	ok, _ := utils.UtilFile.PathExists(fw.filename)
 	if ok == false {
-		err := utils.UtilFile.CreateFile(fw.filename)
+		err := fw.createFile()
 		if err != nil {
 			return err
 		}
@@ -246,6 +456,13 @@ func (fw *FileWriter) initFile() error {
 	}
 	fw.startLine = nowLines
 
+	// get file start size
+	fileInfo, err := os.Stat(fw.filename)
+	if err != nil {
+		return err
+	}
+	fw.size = fileInfo.Size()
+
 	//get a file pointer
 	file, err := fw.getFileObject(fw.filename)
 	if err != nil {
@@ -255,12 +472,82 @@ func (fw *FileWriter) initFile() error {
 	return nil
 }
 
+// open the file, or the current period file
+func (fw *FileWriter) open(config *FileConfig) error {
+	fw.maxBackups = config.MaxBackups
+	fw.maxAge = config.MaxAge
+	fw.compressor, _ = compressorByName(config.Compression)
+	fw.fileMode = config.FileMode
+	fw.dirMode = config.DirMode
+	if fw.dirMode == 0 {
+		fw.dirMode = 0755
+	}
+	fw.createDirs = config.CreateDirs
+	fw.symlink = config.LatestSymlink
+	if config.RotatePeriod != "" {
+		return fw.slicePeriod(config.RotatePeriod, config.RotateUTC)
+	}
+	return fw.initFile()
+}
+
+// create the file with the configured mode, and its parent directories if createDirs
+func (fw *FileWriter) createFile() error {
+	if fw.createDirs {
+		err := os.MkdirAll(filepath.Dir(fw.filename), fw.dirMode)
+		if err != nil {
+			return err
+		}
+	}
+	if fw.fileMode == 0 {
+		return utils.UtilFile.CreateFile(fw.filename)
+	}
+	file, err := os.OpenFile(fw.filename, os.O_RDWR|os.O_CREATE|os.O_EXCL, fw.fileMode)
+	if err != nil {
+		return err
+	}
+	file.Close()
+	// the mode of OpenFile is masked by umask
+	return os.Chmod(fw.filename, fw.fileMode)
+}
+
+// close and reopen the file by path, the file is created if it was moved, nothing after Close
+func (fw *FileWriter) reopen() error {
+	fw.lock.Lock()
+	defer fw.lock.Unlock()
+
+	if fw.writer == nil {
+		return nil
+	}
+	fw.writer.Close()
+	return fw.initFile()
+}
+
+// rotate the file if not empty, nothing after Close
+func (fw *FileWriter) forceRotate() error {
+	fw.lock.Lock()
+	defer fw.lock.Unlock()
+
+	if fw.writer == nil || fw.size == 0 {
+		return nil
+	}
+	filenameSuffix := path.Ext(fw.filename)
+	timeFlag := time.Now().Format("2006-01-02-15.04.05.9999")
+	return fw.rotate(strings.TrimSuffix(fw.filename, filenameSuffix) + "." + timeFlag + filenameSuffix)
+}
+
 // write by config
 func (fw *FileWriter) writeByConfig(config *FileConfig, loggerMsg *loggerMessage) error {
 
 	fw.lock.Lock()
 	defer fw.lock.Unlock()
 
+	if config.RotatePeriod != "" {
+		// new file per period
+		err := fw.slicePeriod(config.RotatePeriod, config.RotateUTC)
+		if err != nil {
+			return err
+		}
+	}
 	if config.DateSlice != "" {
 		// file slice by date
 		err := fw.sliceByDate(config.DateSlice)
@@ -275,24 +562,28 @@ func (fw *FileWriter) writeByConfig(config *FileConfig, loggerMsg *loggerMessage
 			return err
 		}
 	}
+
+	msg := ""
+	if config.JsonFormat == true {
+		//jsonByte, _ := json.Marshal(loggerMsg)
+		msg = loggerMsg.formatJson(config.JsonProfile) + "\r\n"
+	} else {
+		msg = loggerMsg.formatText(config.Format) + "\r\n"
+	}
+
 	if config.MaxSize != 0 {
 		// file slice by size
-		err := fw.sliceByFileSize(config.MaxSize)
+		err := fw.sliceByFileSize(config.MaxSize, int64(len(msg)))
 		if err != nil {
 			return err
 		}
 	}
 
-	msg := ""
-	if config.JsonFormat == true {
-		//jsonByte, _ := json.Marshal(loggerMsg)
-		jsonByte, _ := loggerMsg.MarshalJSON()
-		msg = string(jsonByte) + "\r\n"
-	} else {
-		msg = loggerMessageFormat(config.Format, loggerMsg) + "\r\n"
+	n, err := fw.writer.Write([]byte(msg))
+	fw.size += int64(n)
+	if err != nil {
+		return err
 	}
-
-	fw.writer.Write([]byte(msg))
 	if config.MaxLine != 0 {
 		if config.JsonFormat == true {
 			fw.startLine += 1
@@ -335,21 +626,61 @@ func (fw *FileWriter) sliceByDate(dataSlice string) error {
 	}
 
 	if isHaveSlice == true {
-		//close file handle
-		fw.writer.Close()
-		err := os.Rename(fw.filename, oldFilename)
-		if err != nil {
-			return err
-		}
-		err = fw.initFile()
-		if err != nil {
-			return err
-		}
+		return fw.rotate(oldFilename)
 	}
 
 	return nil
 }
 
+//open the file of the current period if the period changed, app.log is app-2024-05-01.log daily
+func (fw *FileWriter) slicePeriod(period string, utc bool) error {
+	now := time.Now()
+	if utc {
+		now = now.UTC()
+	}
+	periodKey := now.Format(fileRotatePeriodLayouts[period])
+	if periodKey == fw.periodKey && fw.writer != nil {
+		return nil
+	}
+
+	rotated := fw.base != ""
+	rotateFilename := fw.filename
+	if fw.base == "" {
+		fw.base = fw.filename
+	}
+	if fw.writer != nil {
+		fw.writer.Close()
+		fw.writer = nil
+	}
+	filenameSuffix := path.Ext(fw.base)
+	fw.filename = strings.TrimSuffix(fw.base, filenameSuffix) + "-" + periodKey + filenameSuffix
+	fw.periodKey = periodKey
+	err := fw.initFile()
+	if err == nil && fw.symlink {
+		err = fw.linkLatest()
+	}
+	if rotated {
+		fw.afterRotate(rotateFilename)
+	}
+	return err
+}
+
+//point the base symlink to the current period file, replaced atomically by rename
+func (fw *FileWriter) linkLatest() error {
+	fileInfo, err := os.Lstat(fw.base)
+	if err == nil && fileInfo.Mode()&os.ModeSymlink == 0 {
+		return errors.New("logger: " + fw.base + " is not a symlink!")
+	}
+	tmpLink := fw.base + ".link"
+	os.Remove(tmpLink)
+	// the target is relative, period files are in the directory of the symlink
+	err = os.Symlink(filepath.Base(fw.filename), tmpLink)
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmpLink, fw.base)
+}
+
 //slice file by line, if maxLine < fileLine, rename file is file_line_maxLine_time.log and recreate file
 func (fw *FileWriter) sliceByFileLines(maxLine int64) error {
 
@@ -358,46 +689,131 @@ func (fw *FileWriter) sliceByFileLines(maxLine int64) error {
 	startLine := fw.startLine
 
 	if startLine >= maxLine {
-		//close file handle
-		fw.writer.Close()
 		timeFlag := time.Now().Format("2006-01-02-15.04.05.9999")
 		oldFilename := strings.Replace(filename, filenameSuffix, "", 1) + "." + timeFlag + filenameSuffix
-		err := os.Rename(filename, oldFilename)
-		if err != nil {
-			return err
-		}
-		err = fw.initFile()
-		if err != nil {
-			return err
-		}
+		return fw.rotate(oldFilename)
 	}
 
 	return nil
 }
 
-//slice file by size, if maxSize < fileSize, rename file is file_size_maxSize_time.log and recreate file
-func (fw *FileWriter) sliceByFileSize(maxSize int64) error {
+//slice file by size, if the file size would exceed maxSize (KB) after writing msgSize bytes,
+//rename file is file.time.log and recreate file
+func (fw *FileWriter) sliceByFileSize(maxSize int64, msgSize int64) error {
 
 	filename := fw.filename
 	filenameSuffix := path.Ext(filename)
-	nowSize, _ := fw.getFileSize(filename)
 
-	if nowSize >= maxSize {
-		//close file handle
-		fw.writer.Close()
+	// a message larger than maxSize is written to an empty file
+	if fw.size > 0 && fw.size+msgSize > maxSize*1024 {
 		timeFlag := time.Now().Format("2006-01-02-15.04.05.9999")
 		oldFilename := strings.Replace(filename, filenameSuffix, "", 1) + "." + timeFlag + filenameSuffix
-		err := os.Rename(filename, oldFilename)
-		if err != nil {
-			return err
+		return fw.rotate(oldFilename)
+	}
+
+	return nil
+}
+
+//rename the current file to oldFilename and open a new file, called with lock held
+//an existing oldFilename gets an index, oldFilename.1.log, oldFilename.2.log ...
+//if rename fails, the current file is reopened and writes continue
+func (fw *FileWriter) rotate(oldFilename string) error {
+	filenameSuffix := path.Ext(oldFilename)
+	rotateFilename := oldFilename
+	for i := 1; ; i++ {
+		ok, _ := utils.UtilFile.PathExists(rotateFilename)
+		if !ok && fw.compressor != nil {
+			// the compressed file of an earlier rotation is not replaced
+			ok, _ = utils.UtilFile.PathExists(rotateFilename + fw.compressor.Extension())
 		}
-		err = fw.initFile()
-		if err != nil {
-			return err
+		if !ok {
+			break
 		}
+		rotateFilename = strings.TrimSuffix(oldFilename, filenameSuffix) + "." + strconv.Itoa(i) + filenameSuffix
 	}
 
-	return nil
+	//close file handle, open files cannot be renamed on windows
+	fw.writer.Close()
+	renameErr := os.Rename(fw.filename, rotateFilename)
+	err := fw.initFile()
+	if renameErr != nil {
+		return renameErr
+	}
+	fw.afterRotate(rotateFilename)
+	return err
+}
+
+//compress the rotated file in the background if compressed, then delete the old rotated files, called with lock held
+func (fw *FileWriter) afterRotate(rotateFilename string) {
+	if fw.compressor == nil {
+		fw.cleanup()
+		return
+	}
+	compressor := fw.compressor
+	fw.compressing.Add(1)
+	go func() {
+		defer fw.compressing.Done()
+		fw.compressLock.Lock()
+		defer fw.compressLock.Unlock()
+		// a rotated file deleted by the cleanup of an earlier rotation is not an error
+		if err := compressFile(compressor, rotateFilename); err != nil && !os.IsNotExist(err) {
+			fmt.Fprintf(os.Stderr, "logger: unable compress rotated file %v of adapter:%v, error: %v\n", rotateFilename, FILE_ADAPTER_NAME, err)
+		}
+		// cleanup after compression, a file being compressed is not counted twice
+		fw.lock.Lock()
+		fw.cleanup()
+		fw.lock.Unlock()
+	}()
+}
+
+//delete rotated files beyond maxBackups or older than maxAge days, called with lock held
+//rotated files are file_time.log, file.time.log and file-time.log, time starts with a digit,
+//with the compressor extension if compressed
+func (fw *FileWriter) cleanup() {
+	if fw.maxBackups == 0 && fw.maxAge == 0 {
+		return
+	}
+	base := fw.base
+	if base == "" {
+		base = fw.filename
+	}
+	dir, name := filepath.Split(base)
+	if dir == "" {
+		dir = "."
+	}
+	filenameSuffix := path.Ext(name)
+	prefix := strings.TrimSuffix(name, filenameSuffix)
+	_, current := filepath.Split(fw.filename)
+	compressedSuffix := filenameSuffix
+	if fw.compressor != nil {
+		compressedSuffix += fw.compressor.Extension()
+	}
+
+	fileInfos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return
+	}
+	backups := []os.FileInfo{}
+	for _, fileInfo := range fileInfos {
+		backup := fileInfo.Name()
+		if fileInfo.IsDir() || backup == current || backup == name ||
+			!strings.HasPrefix(backup, prefix) || (!strings.HasSuffix(backup, filenameSuffix) && !strings.HasSuffix(backup, compressedSuffix)) ||
+			len(backup) < len(prefix)+2 || !strings.ContainsRune("._-", rune(backup[len(prefix)])) ||
+			backup[len(prefix)+1] < '0' || backup[len(prefix)+1] > '9' {
+			continue
+		}
+		backups = append(backups, fileInfo)
+	}
+	// newest first
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].ModTime().After(backups[j].ModTime())
+	})
+	expiry := time.Now().AddDate(0, 0, -fw.maxAge)
+	for i, backup := range backups {
+		if (fw.maxBackups > 0 && i >= fw.maxBackups) || (fw.maxAge > 0 && backup.ModTime().Before(expiry)) {
+			os.Remove(filepath.Join(dir, backup.Name()))
+		}
+	}
 }
 
 //get file object
@@ -0,0 +1,14 @@
+//go:build windows || js || plan9
+// +build windows js plan9
+
+package go_logger
+
+import "errors"
+
+// SIGHUP is not delivered on this platform, use AdapterFile.Reopen
+func (adapterFile *AdapterFile) reopenOnHUP() error {
+	return errors.New("config ReopenOnHUP is not supported on this platform!")
+}
+
+func (adapterFile *AdapterFile) stopReopenOnHUP() {
+}
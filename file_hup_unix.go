@@ -0,0 +1,36 @@
+//go:build !windows && !js && !plan9
+// +build !windows,!js,!plan9
+
+package go_logger
+
+import (
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// reopen the files on SIGHUP until Close
+func (adapterFile *AdapterFile) reopenOnHUP() error {
+	hupChan := make(chan os.Signal, 1)
+	signal.Notify(hupChan, syscall.SIGHUP)
+	adapterFile.hupChan = hupChan
+	go func() {
+		for range hupChan {
+			if err := adapterFile.Reopen(); err != nil {
+				fmt.Fprintf(os.Stderr, "logger: unable reopen file adapter files on SIGHUP, error: %v\n", err)
+			}
+		}
+	}()
+	return nil
+}
+
+// stop SIGHUP notifications
+func (adapterFile *AdapterFile) stopReopenOnHUP() {
+	if adapterFile.hupChan == nil {
+		return
+	}
+	signal.Stop(adapterFile.hupChan)
+	close(adapterFile.hupChan)
+	adapterFile.hupChan = nil
+}
@@ -0,0 +1,51 @@
+//go:build !windows && !js && !plan9
+// +build !windows,!js,!plan9
+
+package go_logger
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestAdapterFile_ReopenOnHUP(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "go_logger")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "app.log")
+	fileAdapter := NewAdapterFile()
+	err = fileAdapter.Init(&FileConfig{Filename: filename, ReopenOnHUP: true, Format: "%body%"})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer fileAdapter.(*AdapterFile).Close()
+	fileAdapter.Write(&loggerMessage{Level: LoggerLevelInfo, Body: "logger before rotate"})
+
+	// logrotate moves the file, then sends SIGHUP
+	os.Rename(filename, filename+".1")
+	syscall.Kill(os.Getpid(), syscall.SIGHUP)
+	for i := 0; i < 100; i++ {
+		if _, err := os.Stat(filename); err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	fileAdapter.Write(&loggerMessage{Level: LoggerLevelInfo, Body: "logger after rotate"})
+
+	data, _ := ioutil.ReadFile(filename)
+	if string(data) != "logger after rotate\r\n" {
+		t.Errorf("file adapter reopen on SIGHUP error: %q", string(data))
+	}
+	data, _ = ioutil.ReadFile(filename + ".1")
+	if string(data) != "logger before rotate\r\n" {
+		t.Errorf("file adapter rotated file error: %q", string(data))
+	}
+}
@@ -1,6 +1,12 @@
 package go_logger
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -91,3 +97,389 @@ func TestAdapterFile_WriteLevelFile(t *testing.T) {
 	loggerMsg.Level = LoggerLevelError
 	fileAdapter.Write(loggerMsg)
 }
+
+func TestAdapterFile_WriteMaxSize(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "go_logger")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer os.RemoveAll(dir)
+
+	fileAdapter := NewAdapterFile()
+	err = fileAdapter.Init(&FileConfig{
+		Filename: filepath.Join(dir, "size.log"),
+		MaxSize:  1,
+		Format:   "%body%",
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// 100 bytes per line, 10 lines per 1KB file
+	body := strings.Repeat("x", 98)
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fileAdapter.Write(&loggerMessage{Level: LoggerLevelInfo, Body: body})
+		}()
+	}
+	wg.Wait()
+	fileAdapter.(*AdapterFile).Close()
+
+	files, _ := ioutil.ReadDir(dir)
+	if len(files) != 5 {
+		t.Errorf("file adapter MaxSize must rotate to 5 files, got %d", len(files))
+	}
+	lines := 0
+	for _, file := range files {
+		if file.Size() > 1024 {
+			t.Errorf("file adapter MaxSize exceeded: %s %d", file.Name(), file.Size())
+		}
+		data, _ := ioutil.ReadFile(filepath.Join(dir, file.Name()))
+		lines += strings.Count(string(data), "\n")
+	}
+	if lines != 50 {
+		t.Errorf("file adapter rotation lost writes, %d lines", lines)
+	}
+}
+
+func TestAdapterFile_WriteRotatePeriod(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "go_logger")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer os.RemoveAll(dir)
+
+	fileAdapter := NewAdapterFile()
+	if fileAdapter.Init(&FileConfig{Filename: filepath.Join(dir, "app.log"), RotatePeriod: "weekly"}) == nil {
+		t.Error("file adapter unknown RotatePeriod must be error")
+	}
+	fileAdapter = NewAdapterFile()
+	if fileAdapter.Init(&FileConfig{Filename: filepath.Join(dir, "app.log"), RotatePeriod: "daily", DateSlice: "d"}) == nil {
+		t.Error("file adapter RotatePeriod with DateSlice must be error")
+	}
+
+	fileAdapter = NewAdapterFile()
+	err = fileAdapter.Init(&FileConfig{
+		Filename:     filepath.Join(dir, "app.log"),
+		RotatePeriod: FILE_ROTATE_PERIOD_HOURLY,
+		RotateUTC:    true,
+		Format:       "%body%",
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	fileAdapter.Write(&loggerMessage{Level: LoggerLevelInfo, Body: "logger period current"})
+
+	// the previous period file is kept, writes go to the current period file
+	fw := fileAdapter.(*AdapterFile).write[FILE_ACCESS_LEVEL]
+	fw.lock.Lock()
+	fw.writer.Close()
+	fw.filename = filepath.Join(dir, "app-2000-01-01-00.log")
+	fw.periodKey = "2000-01-01-00"
+	fw.initFile()
+	fw.lock.Unlock()
+	fileAdapter.Write(&loggerMessage{Level: LoggerLevelInfo, Body: "logger period next"})
+	fileAdapter.(*AdapterFile).Close()
+
+	current := filepath.Join(dir, "app-"+time.Now().UTC().Format("2006-01-02-15")+".log")
+	data, _ := ioutil.ReadFile(current)
+	if string(data) != "logger period current\r\nlogger period next\r\n" {
+		t.Errorf("file adapter period file error: %q", string(data))
+	}
+	if _, err := os.Stat(filepath.Join(dir, "app.log")); !os.IsNotExist(err) {
+		t.Error("file adapter RotatePeriod must not write the configured filename")
+	}
+}
+
+func TestAdapterFile_Retention(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "go_logger")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer os.RemoveAll(dir)
+
+	// existing backups, the oldest is 10 days old
+	now := time.Now()
+	backups := []string{"app.2024-05-01-10.00.00.log", "app_20240502.log", "app-2024-05-03.log", "app.2024-05-04-10.00.00.1.log"}
+	for i, backup := range backups {
+		filename := filepath.Join(dir, backup)
+		ioutil.WriteFile(filename, []byte("backup\n"), 0644)
+		modTime := now.Add(time.Duration(i-len(backups)) * time.Hour)
+		if i == 0 {
+			modTime = now.AddDate(0, 0, -10)
+		}
+		os.Chtimes(filename, modTime, modTime)
+	}
+	// not backups of app.log
+	others := []string{"app.error.log", "application.log", "app.txt"}
+	for _, other := range others {
+		ioutil.WriteFile(filepath.Join(dir, other), []byte("other\n"), 0644)
+	}
+
+	fileAdapter := NewAdapterFile()
+	if fileAdapter.Init(&FileConfig{Filename: filepath.Join(dir, "app.log"), MaxAge: -1}) == nil {
+		t.Error("file adapter negative MaxAge must be error")
+	}
+	// app.log is full, the first write rotates it
+	ioutil.WriteFile(filepath.Join(dir, "app.log"), []byte("old\n"), 0644)
+	fileAdapter = NewAdapterFile()
+	err = fileAdapter.Init(&FileConfig{
+		Filename:   filepath.Join(dir, "app.log"),
+		MaxLine:    1,
+		MaxBackups: 2,
+		MaxAge:     7,
+		Format:     "%body%",
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	fileAdapter.Write(&loggerMessage{Level: LoggerLevelInfo, Body: "logger retention"})
+	fileAdapter.(*AdapterFile).Close()
+
+	names := map[string]bool{}
+	fileInfos, _ := ioutil.ReadDir(dir)
+	for _, fileInfo := range fileInfos {
+		names[fileInfo.Name()] = true
+	}
+	// app.log, the new backup and the newest existing backup are kept
+	if len(names) != 3+len(others) || !names["app.log"] || !names["app.2024-05-04-10.00.00.1.log"] {
+		t.Errorf("file adapter retention error: %v", names)
+	}
+	for _, other := range others {
+		if !names[other] {
+			t.Errorf("file adapter retention must keep %s", other)
+		}
+	}
+}
+
+func TestAdapterFile_WriteLevelTemplate(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "go_logger")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer os.RemoveAll(dir)
+
+	fileAdapter := NewAdapterFile()
+	err = fileAdapter.Init(&FileConfig{
+		Filename:      filepath.Join(dir, "app.%level_string%.log"),
+		LevelFileName: map[int]string{LoggerLevelError: filepath.Join(dir, "error.log")},
+	})
+	if err == nil {
+		t.Error("file adapter LevelFileName with a %level_string% Filename must be error")
+	}
+	fileAdapter = NewAdapterFile()
+	err = fileAdapter.Init(&FileConfig{
+		Filename:   filepath.Join(dir, "app.%level_string%.log"),
+		LevelGroup: map[int]string{100: "error"},
+	})
+	if err == nil {
+		t.Error("file adapter illegal LevelGroup level must be error")
+	}
+
+	fileAdapter = NewAdapterFile()
+	err = fileAdapter.Init(&FileConfig{
+		Filename: filepath.Join(dir, "app.%level_string%.log"),
+		LevelGroup: map[int]string{
+			LoggerLevelEmergency: "error",
+			LoggerLevelAlert:     "error",
+			LoggerLevelCritical:  "error",
+		},
+		Format: "%body%",
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	fileAdapter.Write(&loggerMessage{Level: LoggerLevelCritical, Body: "logger critical"})
+	fileAdapter.Write(&loggerMessage{Level: LoggerLevelError, Body: "logger error"})
+	fileAdapter.Write(&loggerMessage{Level: LoggerLevelInfo, Body: "logger info"})
+	fileAdapter.(*AdapterFile).Close()
+
+	expected := map[string]string{
+		"app.error.log":   "logger critical\r\nlogger error\r\n",
+		"app.info.log":    "logger info\r\n",
+		"app.debug.log":   "",
+		"app.warning.log": "",
+	}
+	for name, content := range expected {
+		data, err := ioutil.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Errorf("file adapter level file %s error: %s", name, err.Error())
+			continue
+		}
+		if string(data) != content {
+			t.Errorf("file adapter level file %s error: %q", name, string(data))
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "app.critical.log")); !os.IsNotExist(err) {
+		t.Error("file adapter grouped level must not have its own file")
+	}
+}
+
+func TestAdapterFile_Reopen(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "go_logger")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "app.log")
+	fileAdapter := NewAdapterFile()
+	err = fileAdapter.Init(&FileConfig{Filename: filename, Format: "%body%"})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	fileAdapter.Write(&loggerMessage{Level: LoggerLevelInfo, Body: "logger before reopen"})
+	os.Remove(filename)
+	if err := fileAdapter.(*AdapterFile).Reopen(); err != nil {
+		t.Fatal(err.Error())
+	}
+	fileAdapter.Write(&loggerMessage{Level: LoggerLevelInfo, Body: "logger after reopen"})
+	fileAdapter.(*AdapterFile).Close()
+
+	data, _ := ioutil.ReadFile(filename)
+	if string(data) != "logger after reopen\r\n" {
+		t.Errorf("file adapter reopen error: %q", string(data))
+	}
+}
+
+func TestAdapterFile_Rotate(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "go_logger")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "app.log")
+	fileAdapter := NewAdapterFile()
+	err = fileAdapter.Init(&FileConfig{Filename: filename, Format: "%body%"})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer fileAdapter.(*AdapterFile).Close()
+
+	fileAdapter.Write(&loggerMessage{Level: LoggerLevelInfo, Body: "logger before rotate"})
+	if err := fileAdapter.(*AdapterFile).Rotate(); err != nil {
+		t.Fatal(err.Error())
+	}
+	// an empty file is not rotated
+	if err := fileAdapter.(*AdapterFile).Rotate(); err != nil {
+		t.Fatal(err.Error())
+	}
+	fileAdapter.Write(&loggerMessage{Level: LoggerLevelInfo, Body: "logger after rotate"})
+
+	files, _ := ioutil.ReadDir(dir)
+	if len(files) != 2 {
+		t.Fatalf("file adapter rotate must keep 2 files, got %d", len(files))
+	}
+	for _, file := range files {
+		data, _ := ioutil.ReadFile(filepath.Join(dir, file.Name()))
+		expected := "logger before rotate\r\n"
+		if file.Name() == "app.log" {
+			expected = "logger after rotate\r\n"
+		} else if !strings.HasPrefix(file.Name(), "app.") {
+			t.Error("file adapter rotated file name error: " + file.Name())
+		}
+		if string(data) != expected {
+			t.Errorf("file adapter rotate error: %s %q", file.Name(), string(data))
+		}
+	}
+}
+
+func TestAdapterFile_CreateDirs(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "go_logger")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer os.RemoveAll(dir)
+
+	fileAdapter := NewAdapterFile()
+	if fileAdapter.Init(&FileConfig{Filename: filepath.Join(dir, "app.log"), FileMode: os.ModeDir | 0644}) == nil {
+		t.Error("file adapter FileMode with type bits must be error")
+	}
+
+	filename := filepath.Join(dir, "logs", "2024", "app.log")
+	fileAdapter = NewAdapterFile()
+	err = fileAdapter.Init(&FileConfig{
+		Filename:   filename,
+		FileMode:   0600,
+		CreateDirs: true,
+		DirMode:    0700,
+		Format:     "%body%",
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	fileAdapter.Write(&loggerMessage{Level: LoggerLevelInfo, Body: "logger create dirs"})
+	fileAdapter.(*AdapterFile).Close()
+
+	data, _ := ioutil.ReadFile(filename)
+	if string(data) != "logger create dirs\r\n" {
+		t.Errorf("file adapter create dirs error: %q", string(data))
+	}
+	if runtime.GOOS == "windows" {
+		return
+	}
+	if fileInfo, err := os.Stat(filename); err != nil || fileInfo.Mode().Perm() != 0600 {
+		t.Errorf("file adapter FileMode error: %v", fileInfo.Mode())
+	}
+	if dirInfo, err := os.Stat(filepath.Dir(filename)); err != nil || dirInfo.Mode().Perm() != 0700 {
+		t.Errorf("file adapter DirMode error: %v", dirInfo.Mode())
+	}
+}
+
+func TestAdapterFile_LatestSymlink(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "go_logger")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "app.log")
+	fileAdapter := NewAdapterFile()
+	if fileAdapter.Init(&FileConfig{Filename: filename, LatestSymlink: true}) == nil {
+		t.Error("file adapter LatestSymlink without RotatePeriod must be error")
+	}
+
+	if runtime.GOOS == "windows" {
+		return
+	}
+	fileAdapter = NewAdapterFile()
+	err = fileAdapter.Init(&FileConfig{
+		Filename:      filename,
+		RotatePeriod:  FILE_ROTATE_PERIOD_DAILY,
+		LatestSymlink: true,
+		Format:        "%body%",
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	fileAdapter.Write(&loggerMessage{Level: LoggerLevelInfo, Body: "logger latest"})
+
+	// the next period moves the symlink
+	fw := fileAdapter.(*AdapterFile).write[FILE_ACCESS_LEVEL]
+	fw.lock.Lock()
+	fw.periodKey = "2000-01-01"
+	fw.lock.Unlock()
+	fileAdapter.Write(&loggerMessage{Level: LoggerLevelInfo, Body: "logger latest next"})
+	fileAdapter.(*AdapterFile).Close()
+
+	target, err := os.Readlink(filename)
+	if err != nil || target != "app-"+time.Now().Format("2006-01-02")+".log" {
+		t.Errorf("file adapter latest symlink error: %s %v", target, err)
+	}
+	data, _ := ioutil.ReadFile(filename)
+	if string(data) != "logger latest\r\nlogger latest next\r\n" {
+		t.Errorf("file adapter latest symlink content error: %q", string(data))
+	}
+}
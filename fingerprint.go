@@ -0,0 +1,78 @@
+package go_logger
+
+import (
+	"hash/fnv"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// field name of Error+ messages fingerprint
+const fingerprintField = "fingerprint"
+
+// variable parts of messages: uuids, hex ids and numbers
+var fingerprintVariableRegexp = regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}|0x[0-9a-fA-F]+|\b[0-9a-fA-F]*[0-9][0-9a-fA-F]*\b`)
+
+//add a stable "fingerprint" field to Error+ messages for grouping occurrences of the same failure
+//the fingerprint hashes the type of the first error field, the message with numbers and ids
+//replaced, and the caller function and file
+//params : enabled bool
+func (logger *Logger) SetErrorFingerprint(enabled bool) {
+	logger.lock.Lock()
+	defer logger.lock.Unlock()
+
+	logger.errorFingerprint.Store(enabled)
+}
+
+//add fingerprint field to Error+ message
+func (logger *Logger) fingerprint(loggerMsg *loggerMessage) {
+	if loggerMsg.Level > LoggerLevelError || !logger.errorFingerprint.Load().(bool) {
+		return
+	}
+	if loggerMsg.Fields == nil {
+		loggerMsg.Fields = map[string]interface{}{}
+	}
+	loggerMsg.Fields[fingerprintField] = errorFingerprint(loggerMsg)
+}
+
+//fingerprint of error type, normalized message and top stack frame, 16 hex digits
+func errorFingerprint(loggerMsg *loggerMessage) string {
+	h := fnv.New64a()
+	h.Write([]byte(loggerMsg.errorType))
+	h.Write([]byte{0})
+	h.Write([]byte(fingerprintVariableRegexp.ReplaceAllString(loggerMsg.Body, "#")))
+	h.Write([]byte{0})
+	h.Write([]byte(loggerMsg.Function))
+	h.Write([]byte{0})
+	h.Write([]byte(loggerMsg.File))
+	fingerprint := strconv.FormatUint(h.Sum64(), 16)
+	for len(fingerprint) < 16 {
+		fingerprint = "0" + fingerprint
+	}
+	return fingerprint
+}
+
+//type of the first error value of key-value pairs, "" if none
+func errorTypeOf(keysAndValues []interface{}) string {
+	for _, value := range keysAndValues {
+		if err, ok := value.(error); ok {
+			return errorTypeName(err)
+		}
+	}
+	return ""
+}
+
+//type of the error value of the first key, "" if none
+func errorTypeOfFields(fields map[string]interface{}) string {
+	keys := make([]string, 0, len(fields))
+	for key, value := range fields {
+		if _, ok := value.(error); ok {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return ""
+	}
+	sort.Strings(keys)
+	return errorTypeName(fields[keys[0]])
+}
@@ -0,0 +1,50 @@
+package go_logger
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestLogger_SetErrorFingerprint(t *testing.T) {
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("count", LoggerLevelDebug, &countConfig{})
+	logger.SetRecentBuffer(10)
+
+	logger.Errorw("connection 10.0.0.1:5432 refused after 3 retries", "error", errors.New("refused"))
+	logger.Error("logger not fingerprinted")
+	logger.SetErrorFingerprint(true)
+	fingerprints := []string{}
+	for _, host := range []string{"10.0.0.1:5432", "10.0.0.2:6543"} {
+		logger.Errorw("connection "+host+" refused", "error", errors.New("refused"))
+	}
+	logger.Errorw("connection 10.0.0.3:5432 refused", "error", &os.PathError{Op: "open", Path: "/tmp", Err: errors.New("refused")})
+	logger.WithField("error", errors.New("refused")).Error("connection 10.0.0.4:5432 refused")
+	logger.Info("logger info not fingerprinted")
+
+	messages := logger.recent.Load().(*recentBuffer).snapshot()
+	if _, ok := messages[1].Fields[fingerprintField]; ok {
+		t.Error("fingerprint must be disabled by default")
+	}
+	for _, loggerMsg := range messages[2:6] {
+		fingerprint, _ := loggerMsg.Fields[fingerprintField].(string)
+		if len(fingerprint) != 16 {
+			t.Errorf("fingerprint error: %v", loggerMsg.Fields)
+		}
+		fingerprints = append(fingerprints, fingerprint)
+	}
+	if fingerprints[0] != fingerprints[1] {
+		t.Error("fingerprint must ignore numbers in the message")
+	}
+	if fingerprints[0] == fingerprints[2] {
+		t.Error("fingerprint must differ by error type")
+	}
+	if _, ok := messages[6].Fields[fingerprintField]; ok {
+		t.Error("info message must not be fingerprinted")
+	}
+	if messages[5].errorType != "*errors.errorString" {
+		t.Error("entry error type error: " + messages[5].errorType)
+	}
+}
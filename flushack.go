@@ -0,0 +1,46 @@
+package go_logger
+
+import (
+	"errors"
+	"time"
+)
+
+// optional interface of adapters with delivery acknowledgements (HTTP 2xx, broker acks),
+// FlushAck is called instead of FlushError when the logger flushes with acknowledgements, see SetFlushAck
+type AckFlusher interface {
+
+	// send the pending messages and wait until every message written before is acknowledged
+	// by the destination, or timeout; the error reports messages not delivered since the last FlushAck,
+	// including background sends
+	FlushAck(timeout time.Duration) error
+}
+
+//set Flush and Close to wait for the delivery acknowledgements of the outputs, so flushed means delivered
+//outputs of AckFlusher adapters (loki, clickhouse, datadog, nats) wait up to timeout, other outputs flush as usual;
+//an output not acknowledged in time is reported by the AdapterErrors of Flush
+//params : timeout time.Duration, 0 flushes without waiting for acknowledgements
+//return : error
+func (logger *Logger) SetFlushAck(timeout time.Duration) error {
+	if timeout < 0 {
+		return errors.New("logger: flush ack timeout cannot be negative!")
+	}
+	logger.flushAckTimeout.Store(timeout)
+	return nil
+}
+
+//run send and wait for its acknowledgement up to timeout, send keeps running after a timeout
+//return : error of send, or a timeout error
+func waitAck(timeout time.Duration, send func() error) error {
+	done := make(chan error, 1)
+	go func() {
+		done <- send()
+	}()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case err := <-done:
+		return err
+	case <-timer.C:
+		return errors.New("logger: delivery is not acknowledged in " + timeout.String())
+	}
+}
@@ -0,0 +1,79 @@
+//go:build !tinygo && !logger_tiny
+// +build !tinygo,!logger_tiny
+
+package go_logger
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLogger_SetFlushAck(t *testing.T) {
+
+	release := make(chan struct{})
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer httpServer.Close()
+	defer close(release)
+
+	logger := NewLogger()
+	if logger.SetFlushAck(-time.Second) == nil {
+		t.Error("negative flush ack timeout must be error")
+	}
+	err := logger.Attach(LOKI_ADAPTER_NAME, LoggerLevelDebug, &LokiConfig{Url: httpServer.URL, BatchWait: time.Hour})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := logger.SetFlushAck(50 * time.Millisecond); err != nil {
+		t.Fatal(err.Error())
+	}
+	logger.Info("logger flush ack")
+
+	err = logger.Flush()
+	adapterErrors, ok := err.(AdapterErrors)
+	if !ok || len(adapterErrors) != 1 || !strings.Contains(adapterErrors[LOKI_ADAPTER_NAME].Error(), "not acknowledged in 50ms") {
+		t.Errorf("flush not acknowledged error %v", err)
+	}
+}
+
+func TestAdapterLoki_FlushAck(t *testing.T) {
+
+	failed := int32(1)
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&failed) == 1 {
+			http.Error(w, "ingester unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer httpServer.Close()
+
+	lokiAdapter := NewAdapterLoki()
+	err := lokiAdapter.Init(&LokiConfig{Url: httpServer.URL, BatchWait: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer lokiAdapter.(*AdapterLoki).Close()
+
+	loggerMsg := &loggerMessage{Millisecond: 1500000000123, Level: LoggerLevelInfo, LevelString: "Info", Body: "logger loki ack"}
+	if err := lokiAdapter.Write(loggerMsg); err != nil {
+		t.Fatal(err.Error())
+	}
+	// the timer push fails in the background
+	time.Sleep(200 * time.Millisecond)
+	atomic.StoreInt32(&failed, 0)
+
+	err = lokiAdapter.(*AdapterLoki).FlushAck(time.Second)
+	if err == nil || !strings.Contains(err.Error(), "code=503") {
+		t.Errorf("loki flush ack background error %v", err)
+	}
+	if err := lokiAdapter.(*AdapterLoki).FlushAck(time.Second); err != nil {
+		t.Errorf("loki flush ack error %v must be reported once", err)
+	}
+}
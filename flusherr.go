@@ -0,0 +1,73 @@
+package go_logger
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// optional interface of adapters, FlushError is called instead of Flush
+// and its error is reported by logger Flush and Close
+type ErrorFlusher interface {
+	FlushError() error
+}
+
+// errors of outputs failed to flush, key is adapter name
+type AdapterErrors map[string]error
+
+func (adapterErrors AdapterErrors) Error() string {
+	names := make([]string, 0, len(adapterErrors))
+	for name := range adapterErrors {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	messages := make([]string, 0, len(names))
+	for _, name := range names {
+		messages = append(messages, "adapter "+name+": "+adapterErrors[name].Error())
+	}
+	return "logger: flush failed, " + strings.Join(messages, "; ")
+}
+
+// async flush request, err is set before done is closed
+type flushRequest struct {
+	done chan struct{}
+	err  error
+}
+
+//flush all outputs
+//return : AdapterErrors, nil if all flushed
+func (logger *Logger) flushOutputs() error {
+	ackTimeout, _ := logger.flushAckTimeout.Load().(time.Duration)
+	adapterErrors := AdapterErrors{}
+	for _, output := range logger.outputList() {
+		if err := output.flush(ackTimeout); err != nil {
+			adapterErrors[output.Name] = err
+		}
+	}
+	if len(adapterErrors) == 0 {
+		return nil
+	}
+	return adapterErrors
+}
+
+//flush output, waiting for the acknowledgements up to ackTimeout if it is not 0, a panic is returned as error
+func (output *outputLogger) flush(ackTimeout time.Duration) (err error) {
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("flush panic: %v", e)
+		}
+	}()
+	if offload := output.offload; offload != nil {
+		offload.wait()
+	}
+	if flusher, ok := output.LoggerAbstract.(AckFlusher); ok && ackTimeout > 0 {
+		return flusher.FlushAck(ackTimeout)
+	}
+	if flusher, ok := output.LoggerAbstract.(ErrorFlusher); ok {
+		return flusher.FlushError()
+	}
+	output.Flush()
+	return nil
+}
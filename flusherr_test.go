@@ -0,0 +1,96 @@
+package go_logger
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+)
+
+// flush error adapter
+type adapterFlushError struct {
+	adapterCount
+}
+
+func (adapter *adapterFlushError) FlushError() error {
+	return errors.New("disk full")
+}
+
+func (adapter *adapterFlushError) Name() string {
+	return "flush_error"
+}
+
+// flush panic adapter
+type adapterFlushPanic struct {
+	adapterCount
+}
+
+func (adapter *adapterFlushPanic) Flush() {
+	panic("flush panic")
+}
+
+func (adapter *adapterFlushPanic) Name() string {
+	return "flush_panic"
+}
+
+func init() {
+	Register("flush_error", func() LoggerAbstract {
+		return &adapterFlushError{}
+	})
+	Register("flush_panic", func() LoggerAbstract {
+		return &adapterFlushPanic{}
+	})
+}
+
+func TestLogger_FlushErrors(t *testing.T) {
+
+	for _, async := range []bool{false, true} {
+		logger := NewLogger()
+		logger.Detach("console")
+		logger.Attach("count", LoggerLevelDebug, &countConfig{})
+		if async {
+			logger.SetAsync()
+		}
+		if err := logger.Flush(); err != nil {
+			t.Error("flush without failed outputs must return nil, got " + err.Error())
+		}
+
+		logger.Attach("flush_error", LoggerLevelDebug, &countConfig{})
+		logger.Attach("flush_panic", LoggerLevelDebug, &countConfig{})
+		err := logger.Flush()
+		adapterErrors, ok := err.(AdapterErrors)
+		if !ok || len(adapterErrors) != 2 || adapterErrors["count"] != nil {
+			t.Errorf("async %v, flush must return AdapterErrors of failed outputs, got %v", async, err)
+			continue
+		}
+		if err.Error() != "logger: flush failed, adapter flush_error: disk full; adapter flush_panic: flush panic: flush panic" {
+			t.Error("flush AdapterErrors message error: " + err.Error())
+		}
+		if logger.Close() == nil {
+			t.Error("close must return flush errors")
+		}
+	}
+}
+
+func TestAdapterFile_FlushKeepsFileOpen(t *testing.T) {
+
+	fileAdapter := NewAdapterFile()
+	fileAdapter.Init(&FileConfig{
+		Filename: "./test_flush.log",
+		Format:   "%body%",
+	})
+	defer os.Remove("./test_flush.log")
+
+	fileAdapter.Write(&loggerMessage{Body: "logger file flush 1"})
+	if err := fileAdapter.(ErrorFlusher).FlushError(); err != nil {
+		t.Error(err.Error())
+	}
+	fileAdapter.Write(&loggerMessage{Body: "logger file flush 2"})
+	fileAdapter.Flush()
+
+	data, _ := ioutil.ReadFile("./test_flush.log")
+	if strings.Count(string(data), "logger file flush") != 2 {
+		t.Error("file adapter must keep writing after flush: " + string(data))
+	}
+}
@@ -0,0 +1,62 @@
+//go:build go1.18
+// +build go1.18
+
+package go_logger
+
+import (
+	"strings"
+	"testing"
+)
+
+//run with go test -fuzz FuzzLoggerMessageFormat
+func FuzzLoggerMessageFormat(f *testing.F) {
+	f.Add("%millisecond_format% [%level_string%] %body%", "logger body", "key", "value")
+	f.Add("%timestamp% %file%:%line% %function% %name% %body% %fields%", "", "k", "")
+	f.Add("%body%%body% %%fields%%", "%level%", "a b", "c=d")
+	f.Add("%unknown% %BODY% %", "\x00\xff", "", "\"")
+
+	f.Fuzz(func(t *testing.T, format string, body string, key string, value string) {
+		loggerMsg := &loggerMessage{
+			Level:       LoggerLevelInfo,
+			LevelString: "Info",
+			Body:        body,
+			File:        "format_fuzz_test.go",
+			Function:    "FuzzLoggerMessageFormat",
+			Fields:      map[string]interface{}{key: value},
+		}
+		message := loggerMessageFormat(format, loggerMsg)
+
+		fields := loggerFieldsFormat(loggerMsg.Fields)
+		if !strings.Contains(format, "%fields%") && !strings.HasSuffix(message, " "+fields) {
+			t.Errorf("fields %q are not appended to %q", fields, message)
+		}
+		if !strings.Contains(format, "%") && message != format+" "+fields {
+			t.Errorf("format without tokens %q is changed to %q", format, message)
+		}
+		// tokens in the body are not substituted
+		if format == "%body%" && message != body+" "+fields {
+			t.Errorf("body %q is changed to %q", body, message)
+		}
+
+		// the format check reports a misuse or nothing, never panics
+		checkConfigFormat(&ConsoleConfig{Format: format})
+	})
+}
+
+//run with go test -fuzz FuzzLoggerFieldsFormat
+func FuzzLoggerFieldsFormat(f *testing.F) {
+	f.Add("key", "value", "other", int64(1))
+	f.Add("", "", "a b", int64(-1))
+	f.Add("k=v", "\"quoted\"", "\n", int64(0))
+
+	f.Fuzz(func(t *testing.T, key string, value string, otherKey string, otherValue int64) {
+		fields := map[string]interface{}{key: value, otherKey: otherValue}
+		formatted := loggerFieldsFormat(fields)
+		if key != otherKey && strings.Count(formatted, "=") < 2 {
+			t.Errorf("fields %v are formatted to %q", fields, formatted)
+		}
+		if formatted != loggerFieldsFormat(fields) {
+			t.Errorf("fields %v format is not stable", fields)
+		}
+	})
+}
@@ -0,0 +1,49 @@
+package go_logger
+
+import "sync"
+
+// formatted text of a dispatched message, outputs with the same format share one formatting
+// copies of the message (e.g. normalized by an output) are not the owner and are formatted again
+type formatCache struct {
+	owner *loggerMessage
+	lock  sync.Mutex
+	texts map[string]string
+}
+
+//message formatted by format, see loggerMessageFormat
+func (loggerMsg *loggerMessage) formatText(format string) string {
+	return loggerMsg.formatted("text "+format, func() (string, bool) {
+		return loggerMessageFormat(format, loggerMsg), true
+	})
+}
+
+//message marshaled to JSON by profile, "" on error
+func (loggerMsg *loggerMessage) formatJson(profile string) string {
+	return loggerMsg.formatted("json "+profile, func() (string, bool) {
+		jsonByte, err := loggerMessageJson(loggerMsg, profile)
+		return string(jsonByte), err == nil
+	})
+}
+
+//formatted text of key, format is called once per message and key, errors are not cached
+func (loggerMsg *loggerMessage) formatted(key string, format func() (string, bool)) string {
+	cache := loggerMsg.formats
+	if cache == nil || cache.owner != loggerMsg {
+		text, _ := format()
+		return text
+	}
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+
+	if text, ok := cache.texts[key]; ok {
+		return text
+	}
+	text, ok := format()
+	if ok {
+		if cache.texts == nil {
+			cache.texts = map[string]string{}
+		}
+		cache.texts[key] = text
+	}
+	return text
+}
@@ -0,0 +1,42 @@
+package go_logger
+
+import (
+	"testing"
+)
+
+func TestLoggerMessage_formatted(t *testing.T) {
+
+	loggerMsg := &loggerMessage{Level: LoggerLevelInfo, LevelString: "Info", Body: "logger format once"}
+	loggerMsg.formats = &formatCache{owner: loggerMsg}
+
+	calls := 0
+	format := func() (string, bool) {
+		calls++
+		return "formatted", true
+	}
+	loggerMsg.formatted("text %body%", format)
+	if text := loggerMsg.formatted("text %body%", format); text != "formatted" || calls != 1 {
+		t.Errorf("format cache must format once per key, calls %d", calls)
+	}
+	loggerMsg.formatted("json ", format)
+	if calls != 2 {
+		t.Error("format cache keys must be formatted separately")
+	}
+
+	// a copy changed by an output is not the owner
+	outputMsg := *loggerMsg
+	outputMsg.Body = "logger output copy"
+	if outputMsg.formatText("%body%") != "logger output copy" {
+		t.Error("format cache must not be shared by message copies")
+	}
+
+	failed := func() (string, bool) {
+		calls++
+		return "", false
+	}
+	loggerMsg.formatted("json gelf", failed)
+	loggerMsg.formatted("json gelf", failed)
+	if calls != 4 {
+		t.Error("format cache must not cache errors")
+	}
+}
@@ -0,0 +1,298 @@
+//go:build !tinygo && !logger_tiny
+// +build !tinygo,!logger_tiny
+
+package go_logger
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const GELF_ADAPTER_NAME = "gelf"
+
+// max chunks of a GELF udp message
+const gelfMaxChunks = 128
+
+// adapter gelf, Graylog GELF 1.1 messages over udp (chunked) or tcp (null byte framed)
+// logger levels are syslog severities, Emergency is 0 ... Debug is 7
+type AdapterGelf struct {
+	lock   sync.Mutex
+	conn   net.Conn
+	config *GelfConfig
+	host   string
+}
+
+// gelf config
+type GelfConfig struct {
+
+	// network, udp or tcp, default udp
+	Network string
+
+	// graylog input address, "127.0.0.1:12201"
+	Address string `config:"required"`
+
+	// udp compression, a registered compressor name, graylog decodes "gzip" and "deflate" (zlib), default none
+	// graylog tcp inputs do not support compression
+	Compression string
+
+	// max udp datagram bytes, larger messages are chunked, default 1420
+	ChunkSize int
+
+	// host field, default os.Hostname()
+	Host string
+
+	// short_message format, see ConsoleConfig Format, default the body without fields
+	// fields are additional fields of the message
+	Format string
+}
+
+func (gc *GelfConfig) Name() string {
+	return GELF_ADAPTER_NAME
+}
+
+func NewAdapterGelf() LoggerAbstract {
+	return &AdapterGelf{
+		config: &GelfConfig{},
+	}
+}
+
+func (adapterGelf *AdapterGelf) Init(gelfConfig Config) error {
+	if gelfConfig.Name() != GELF_ADAPTER_NAME {
+		return errors.New("logger gelf adapter init error, config must GelfConfig")
+	}
+
+	gc := gelfConfig.(*GelfConfig)
+	adapterGelf.config = gc
+
+	if gc.Network == "" {
+		gc.Network = "udp"
+	}
+	if gc.Network != "udp" && gc.Network != "tcp" {
+		return errors.New("config Network must be one of the 'udp', 'tcp'!")
+	}
+	if gc.Address == "" {
+		return errors.New("config Address cannot be empty!")
+	}
+	if _, err := compressorByName(gc.Compression); err != nil {
+		return err
+	}
+	if gc.Compression != "" && gc.Network == "tcp" {
+		return errors.New("config Compression needs udp Network!")
+	}
+	if gc.ChunkSize == 0 {
+		gc.ChunkSize = 1420
+	}
+	// a chunk has a 12 bytes header
+	if gc.ChunkSize <= 12 || gc.ChunkSize > 65507 {
+		return errors.New("config ChunkSize must between 13 and 65507!")
+	}
+	adapterGelf.host = gc.Host
+	if adapterGelf.host == "" {
+		adapterGelf.host, _ = os.Hostname()
+	}
+	if adapterGelf.host == "" {
+		adapterGelf.host = "localhost"
+	}
+
+	adapterGelf.lock.Lock()
+	defer adapterGelf.lock.Unlock()
+	return adapterGelf.connect()
+}
+
+//dial the graylog input, called with lock held
+func (adapterGelf *AdapterGelf) connect() error {
+	conn, err := net.DialTimeout(adapterGelf.config.Network, adapterGelf.config.Address, 5*time.Second)
+	if err != nil {
+		return err
+	}
+	adapterGelf.conn = conn
+	return nil
+}
+
+func (adapterGelf *AdapterGelf) Write(loggerMsg *loggerMessage) error {
+	payload, err := adapterGelf.message(loggerMsg)
+	if err != nil {
+		return err
+	}
+	if adapterGelf.config.Network == "tcp" {
+		return adapterGelf.write([][]byte{append(payload, 0)})
+	}
+	payload, err = adapterGelf.compress(payload)
+	if err != nil {
+		return err
+	}
+	if len(payload) <= adapterGelf.config.ChunkSize {
+		return adapterGelf.write([][]byte{payload})
+	}
+	chunks, err := adapterGelf.chunks(payload)
+	if err != nil {
+		return err
+	}
+	return adapterGelf.write(chunks)
+}
+
+//write datagrams or a tcp message, reconnect once, e.g. a restarted tcp input
+func (adapterGelf *AdapterGelf) write(packets [][]byte) error {
+	adapterGelf.lock.Lock()
+	defer adapterGelf.lock.Unlock()
+
+	if adapterGelf.conn != nil {
+		err := adapterGelf.writePackets(packets)
+		if err == nil {
+			return nil
+		}
+		adapterGelf.conn.Close()
+		adapterGelf.conn = nil
+	}
+	if err := adapterGelf.connect(); err != nil {
+		return err
+	}
+	return adapterGelf.writePackets(packets)
+}
+
+//write packets to the connection, called with lock held
+func (adapterGelf *AdapterGelf) writePackets(packets [][]byte) error {
+	for _, packet := range packets {
+		if _, err := adapterGelf.conn.Write(packet); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//GELF 1.1 JSON message, fields are additional fields, file, line and function are _file, _line and _function
+func (adapterGelf *AdapterGelf) message(loggerMsg *loggerMessage) ([]byte, error) {
+	format := adapterGelf.config.Format
+	shortMessage := loggerMsg.Body
+	if format != "" {
+		shortMessage = loggerMsg.formatText(format)
+	}
+	severity := loggerMsg.Level
+	if severity < LoggerLevelEmergency || severity > LoggerLevelDebug {
+		severity = LoggerLevelDebug
+	}
+
+	message := map[string]interface{}{
+		"version":       "1.1",
+		"host":          adapterGelf.host,
+		"short_message": shortMessage,
+		"timestamp":     float64(loggerMsg.Millisecond) / 1000,
+		"level":         severity,
+		"_file":         loggerMsg.File,
+		"_line":         loggerMsg.Line,
+		"_function":     loggerMsg.Function,
+		"_level_string": loggerMsg.LevelString,
+	}
+	if loggerMsg.Name != "" {
+		message["_logger"] = loggerMsg.Name
+	}
+	for key, value := range loggerMsg.Fields {
+		message["_"+gelfFieldName(key)] = gelfFieldValue(value)
+	}
+	return json.Marshal(message)
+}
+
+//GELF additional field name, [\w.-] characters, "id" is reserved
+func gelfFieldName(name string) string {
+	b := []byte(name)
+	for i, c := range b {
+		if c != '_' && c != '.' && c != '-' && (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			b[i] = '_'
+		}
+	}
+	if len(b) == 0 || string(b) == "id" {
+		return "field_" + string(b)
+	}
+	return string(b)
+}
+
+//GELF additional field value, a number or a string
+func gelfFieldValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return v
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	if text, ok := formatValue(value); ok {
+		return text
+	}
+	return fmt.Sprint(value)
+}
+
+//compress the udp payload by Compression
+func (adapterGelf *AdapterGelf) compress(payload []byte) ([]byte, error) {
+	compressor, err := compressorByName(adapterGelf.config.Compression)
+	if compressor == nil || err != nil {
+		return payload, err
+	}
+	return compressBytes(compressor, payload)
+}
+
+//split the udp payload into GELF chunks:
+//magic 0x1e 0x0f, 8 bytes message id, sequence number, sequence count, data
+func (adapterGelf *AdapterGelf) chunks(payload []byte) ([][]byte, error) {
+	dataSize := adapterGelf.config.ChunkSize - 12
+	count := (len(payload) + dataSize - 1) / dataSize
+	if count > gelfMaxChunks {
+		return nil, errors.New("logger gelf adapter message of " + strconv.Itoa(len(payload)) + " bytes exceeds " +
+			strconv.Itoa(gelfMaxChunks) + " chunks!")
+	}
+	messageId := make([]byte, 8)
+	if _, err := rand.Read(messageId); err != nil {
+		return nil, err
+	}
+	chunks := make([][]byte, 0, count)
+	for i := 0; i < count; i++ {
+		end := (i + 1) * dataSize
+		if end > len(payload) {
+			end = len(payload)
+		}
+		chunk := make([]byte, 0, 12+end-i*dataSize)
+		chunk = append(chunk, 0x1e, 0x0f)
+		chunk = append(chunk, messageId...)
+		chunk = append(chunk, byte(i), byte(count))
+		chunk = append(chunk, payload[i*dataSize:end]...)
+		chunks = append(chunks, chunk)
+	}
+	return chunks, nil
+}
+
+func (adapterGelf *AdapterGelf) Flush() {
+
+}
+
+//close the connection, the adapter must not be written after Close
+func (adapterGelf *AdapterGelf) Close() error {
+	adapterGelf.lock.Lock()
+	defer adapterGelf.lock.Unlock()
+
+	if adapterGelf.conn == nil {
+		return nil
+	}
+	err := adapterGelf.conn.Close()
+	adapterGelf.conn = nil
+	return err
+}
+
+func (adapterGelf *AdapterGelf) Name() string {
+	return GELF_ADAPTER_NAME
+}
+
+//new empty config of the adapter, describes the config fields
+func (adapterGelf *AdapterGelf) NewConfig() Config {
+	return &GelfConfig{}
+}
+
+func init() {
+	Register(GELF_ADAPTER_NAME, NewAdapterGelf)
+}
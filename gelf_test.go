@@ -0,0 +1,146 @@
+//go:build !tinygo && !logger_tiny
+// +build !tinygo,!logger_tiny
+
+package go_logger
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestAdapterGelf_Init(t *testing.T) {
+
+	configs := []*GelfConfig{
+		{},
+		{Address: "127.0.0.1:12201", Network: "http"},
+		{Address: "127.0.0.1:12201", Compression: "lz4"},
+		{Address: "127.0.0.1:12201", Network: "tcp", Compression: "gzip"},
+		{Address: "127.0.0.1:12201", ChunkSize: 12},
+	}
+	for _, config := range configs {
+		if NewAdapterGelf().Init(config) == nil {
+			t.Errorf("gelf adapter config %+v must be error", config)
+		}
+	}
+}
+
+func TestAdapterGelf_WriteUdp(t *testing.T) {
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer conn.Close()
+
+	gelfAdapter := NewAdapterGelf()
+	err = gelfAdapter.Init(&GelfConfig{
+		Address:     conn.LocalAddr().String(),
+		Host:        "host",
+		Compression: "gzip",
+		ChunkSize:   100,
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer gelfAdapter.(*AdapterGelf).Close()
+
+	// numbers are not compressed below the chunk size
+	body := "logger gelf chunked"
+	for i := 0; i < 60; i++ {
+		body += " " + strconv.Itoa(i*7919%1000)
+	}
+	err = gelfAdapter.Write(&loggerMessage{
+		Millisecond: 1500,
+		Level:       LoggerLevelError,
+		LevelString: "Error",
+		Body:        body,
+		File:        "main.go",
+		Line:        12,
+		Function:    "main.main",
+		Fields:      map[string]interface{}{"user": "u1", "id": 7, "took ms": 1.5},
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	// chunks of one message, in order over loopback
+	payload := []byte{}
+	buffer := make([]byte, 1024)
+	for count, sequence := 1, 0; sequence < count; sequence++ {
+		conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		n, _, err := conn.ReadFrom(buffer)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		if n > 100 || buffer[0] != 0x1e || buffer[1] != 0x0f || int(buffer[10]) != sequence {
+			t.Fatalf("gelf adapter chunk error: %v", buffer[:12])
+		}
+		count = int(buffer[11])
+		if count < 2 {
+			t.Fatalf("gelf adapter message must be chunked, %d chunks", count)
+		}
+		payload = append(payload, buffer[12:n]...)
+	}
+
+	gzipReader, err := gzip.NewReader(bytes.NewReader(payload))
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	data, _ := ioutil.ReadAll(gzipReader)
+	message := map[string]interface{}{}
+	if err := json.Unmarshal(data, &message); err != nil {
+		t.Fatal(err.Error())
+	}
+	if message["version"] != "1.1" || message["host"] != "host" || message["short_message"] != body ||
+		message["timestamp"] != 1.5 || message["level"] != float64(3) || message["_file"] != "main.go" ||
+		message["_line"] != float64(12) || message["_function"] != "main.main" || message["_user"] != "u1" ||
+		message["_field_id"] != float64(7) || message["_took_ms"] != 1.5 {
+		t.Errorf("gelf adapter message error: %s", string(data))
+	}
+}
+
+func TestAdapterGelf_WriteTcp(t *testing.T) {
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer listener.Close()
+
+	gelfAdapter := NewAdapterGelf()
+	err = gelfAdapter.Init(&GelfConfig{Network: "tcp", Address: listener.Addr().String(), Format: "[%level_string%] %body%"})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer gelfAdapter.(*AdapterGelf).Close()
+
+	server, err := listener.Accept()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer server.Close()
+
+	gelfAdapter.Write(&loggerMessage{Level: LoggerLevelInfo, LevelString: "Info", Body: "logger gelf 1"})
+	gelfAdapter.Write(&loggerMessage{Level: LoggerLevelInfo, LevelString: "Info", Body: "logger gelf 2"})
+
+	server.SetReadDeadline(time.Now().Add(5 * time.Second))
+	reader := bufio.NewReader(server)
+	for _, expected := range []string{"[Info] logger gelf 1", "[Info] logger gelf 2"} {
+		data, err := reader.ReadBytes(0)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		message := map[string]interface{}{}
+		json.Unmarshal(data[:len(data)-1], &message)
+		if message["short_message"] != expected {
+			t.Errorf("gelf adapter tcp message error: %q", string(data))
+		}
+	}
+}
@@ -0,0 +1,153 @@
+//go:build ignore
+// +build ignore
+
+// generates level_methods.go, the level method set of Logger, Entry and the default logger
+//
+//	go generate
+//
+// a level added to levels gets all variants: X, Xf, Xw, XCtx, XCtxf, Entry X, Xf, Xw and package-level X, Xf, Xw
+// every variant returns first if the logger is disabled, before formatting or copying fields
+
+package main
+
+import (
+	"bytes"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"strings"
+	"text/template"
+)
+
+// level method name and level constant, most severe first
+var levels = []struct {
+	Method   string
+	Constant string
+}{
+	{"Emergency", "LoggerLevelEmergency"},
+	{"Alert", "LoggerLevelAlert"},
+	{"Critical", "LoggerLevelCritical"},
+	{"Error", "LoggerLevelError"},
+	{"Warning", "LoggerLevelWarning"},
+	{"Notice", "LoggerLevelNotice"},
+	{"Info", "LoggerLevelInfo"},
+	{"Debug", "LoggerLevelDebug"},
+}
+
+var levelMethods = template.Must(template.New("level_methods").Funcs(template.FuncMap{
+	"lower": strings.ToLower,
+}).Parse(`// Code generated by gen_levels.go; DO NOT EDIT.
+
+package go_logger
+
+import (
+	"context"
+	"fmt"
+)
+{{range .}}
+//log {{lower .Method}} level
+func (logger *Logger) {{.Method}}(msg string) {
+	if logger.Disabled() {
+		return
+	}
+	logger.writer(2, {{.Constant}}, msg, nil)
+}
+
+//log {{lower .Method}} format
+func (logger *Logger) {{.Method}}f(format string, a ...interface{}) {
+	if logger.Disabled() {
+		return
+	}
+	msg := fmt.Sprintf(format, a...)
+	logger.writer(2, {{.Constant}}, msg, nil)
+}
+
+//log {{lower .Method}} key-values
+func (logger *Logger) {{.Method}}w(msg string, keysAndValues ...interface{}) {
+	if logger.Disabled() {
+		return
+	}
+	logger.writerw({{.Constant}}, msg, keysAndValues)
+}
+
+//log {{lower .Method}} level with context fields
+func (logger *Logger) {{.Method}}Ctx(ctx context.Context, msg string) {
+	if logger.Disabled() {
+		return
+	}
+	logger.writer(2, {{.Constant}}, msg, logger.messageContextFields(ctx))
+}
+
+//log {{lower .Method}} format with context fields
+func (logger *Logger) {{.Method}}Ctxf(ctx context.Context, format string, a ...interface{}) {
+	if logger.Disabled() {
+		return
+	}
+	msg := fmt.Sprintf(format, a...)
+	logger.writer(2, {{.Constant}}, msg, logger.messageContextFields(ctx))
+}
+
+//log {{lower .Method}} level with entry fields
+func (entry *Entry) {{.Method}}(msg string) {
+	if entry.logger.Disabled() {
+		return
+	}
+	entry.writer(2, {{.Constant}}, msg, entry.messageFields())
+}
+
+//log {{lower .Method}} format with entry fields
+func (entry *Entry) {{.Method}}f(format string, a ...interface{}) {
+	if entry.logger.Disabled() {
+		return
+	}
+	msg := fmt.Sprintf(format, a...)
+	entry.writer(2, {{.Constant}}, msg, entry.messageFields())
+}
+
+//log {{lower .Method}} key-values with entry fields
+func (entry *Entry) {{.Method}}w(msg string, keysAndValues ...interface{}) {
+	if entry.logger.Disabled() {
+		return
+	}
+	entry.writerw({{.Constant}}, msg, keysAndValues)
+}
+
+//log {{lower .Method}} level by the default logger
+func {{.Method}}(msg string) {
+	if Default().Disabled() {
+		return
+	}
+	Default().writer(2, {{.Constant}}, msg, nil)
+}
+
+//log {{lower .Method}} format by the default logger
+func {{.Method}}f(format string, a ...interface{}) {
+	if Default().Disabled() {
+		return
+	}
+	msg := fmt.Sprintf(format, a...)
+	Default().writer(2, {{.Constant}}, msg, nil)
+}
+
+//log {{lower .Method}} key-values by the default logger
+func {{.Method}}w(msg string, keysAndValues ...interface{}) {
+	if Default().Disabled() {
+		return
+	}
+	Default().writerw({{.Constant}}, msg, keysAndValues)
+}
+{{end}}`))
+
+func main() {
+	buffer := &bytes.Buffer{}
+	if err := levelMethods.Execute(buffer, levels); err != nil {
+		log.Fatal(err)
+	}
+	source, err := format.Source(buffer.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile("level_methods.go", source, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
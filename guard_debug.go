@@ -0,0 +1,93 @@
+//go:build logger_debug
+// +build logger_debug
+
+package go_logger
+
+// record guard of debug builds, selected by build tag
+//
+//	go test -tags logger_debug
+//
+// the record is shared by the outputs, an adapter must not change it or keep it after Write
+// every Write gets a private copy, the guard panics if the adapter changed it during Write,
+// or changed a copy it kept after Write; kept copies are poisoned, written later as guardPoisonBody
+
+import (
+	"fmt"
+	"sync"
+)
+
+// body and fields of records after Write
+const guardPoisonBody = "logger: record used after Write"
+
+// written records checked for changes after Write
+const guardRecords = 64
+
+// record written by an adapter
+type guardRecord struct {
+	adapterName string
+	loggerMsg   *loggerMessage
+	digest      string
+}
+
+// records written by adapters, checked before every Write
+var recordGuard = struct {
+	lock    sync.Mutex
+	records []guardRecord
+	next    int
+}{records: make([]guardRecord, 0, guardRecords)}
+
+//write a private copy of the record, guarded against changes
+func guardWriter(adapterName string, write func(loggerMsg *loggerMessage) error) func(loggerMsg *loggerMessage) error {
+	return func(loggerMsg *loggerMessage) error {
+		guardCheck()
+
+		guardMsg := *loggerMsg
+		if loggerMsg.Fields != nil {
+			guardMsg.Fields = make(map[string]interface{}, len(loggerMsg.Fields))
+			for key, value := range loggerMsg.Fields {
+				guardMsg.Fields[key] = value
+			}
+		}
+		digest := guardDigest(&guardMsg)
+		err := write(&guardMsg)
+		if changed := guardDigest(&guardMsg); changed != digest {
+			panic("logger: adapter " + adapterName + " changed the record in Write\nbefore: " + digest + "\nafter:  " + changed)
+		}
+
+		guardMsg.Body = guardPoisonBody
+		guardMsg.Fields = map[string]interface{}{"logger_guard": guardPoisonBody}
+		guardAdd(guardRecord{adapterName: adapterName, loggerMsg: &guardMsg, digest: guardDigest(&guardMsg)})
+		return err
+	}
+}
+
+//content of a record, fields are printed in key order
+func guardDigest(loggerMsg *loggerMessage) string {
+	return fmt.Sprintf("%+v", *loggerMsg)
+}
+
+//add a written record, the oldest is not checked any more
+func guardAdd(record guardRecord) {
+	recordGuard.lock.Lock()
+	defer recordGuard.lock.Unlock()
+
+	if len(recordGuard.records) < guardRecords {
+		recordGuard.records = append(recordGuard.records, record)
+		return
+	}
+	recordGuard.records[recordGuard.next] = record
+	recordGuard.next = (recordGuard.next + 1) % guardRecords
+}
+
+//panic if a written record is changed after Write
+func guardCheck() {
+	recordGuard.lock.Lock()
+	for i, record := range recordGuard.records {
+		if changed := guardDigest(record.loggerMsg); changed != record.digest {
+			recordGuard.records[i].digest = changed
+			recordGuard.lock.Unlock()
+			panic("logger: adapter " + record.adapterName + " changed the record after Write\nrecord: " + changed)
+		}
+	}
+	recordGuard.lock.Unlock()
+}
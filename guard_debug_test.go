@@ -0,0 +1,94 @@
+//go:build logger_debug
+// +build logger_debug
+
+package go_logger
+
+import (
+	"strings"
+	"testing"
+)
+
+// adapter changing the records, or keeping the last record to change it later
+type adapterGuarded struct {
+	change bool
+	last   *loggerMessage
+}
+
+type guardedConfig struct {
+	change bool
+}
+
+func (gc *guardedConfig) Name() string {
+	return "guarded"
+}
+
+func (adapter *adapterGuarded) Init(config Config) error {
+	adapter.change = config.(*guardedConfig).change
+	return nil
+}
+
+func (adapter *adapterGuarded) Write(loggerMsg *loggerMessage) error {
+	if adapter.change {
+		loggerMsg.Fields["guarded"] = true
+	}
+	adapter.last = loggerMsg
+	return nil
+}
+
+func (adapter *adapterGuarded) Flush() {
+}
+
+func (adapter *adapterGuarded) Name() string {
+	return "guarded"
+}
+
+func init() {
+	Register("guarded", func() LoggerAbstract {
+		return &adapterGuarded{}
+	})
+}
+
+//panic message of f, "" if f does not panic
+func guardPanic(f func()) (message string) {
+	defer func() {
+		if e := recover(); e != nil {
+			message = e.(string)
+		}
+	}()
+	f()
+	return ""
+}
+
+func TestGuardWriter(t *testing.T) {
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("guarded", LoggerLevelDebug, &guardedConfig{change: true})
+	message := guardPanic(func() {
+		logger.Infow("logger guard changed", "user", "u1")
+	})
+	if !strings.HasPrefix(message, "logger: adapter guarded changed the record in Write") {
+		t.Errorf("changed record panic %q", message)
+	}
+
+	logger = NewLogger()
+	logger.Detach("console")
+	logger.Attach("guarded", LoggerLevelDebug, &guardedConfig{})
+	logger.Infow("logger guard kept", "user", "u1")
+	output, _ := logger.Output("guarded")
+	last := output.(*adapterGuarded).last
+	if last.Body != guardPoisonBody || last.Fields["user"] != nil {
+		t.Errorf("kept record %+v must be poisoned", last)
+	}
+
+	last.Body = "logger guard kept and changed"
+	message = guardPanic(func() {
+		logger.Info("logger guard next")
+	})
+	if !strings.HasPrefix(message, "logger: adapter guarded changed the record after Write") {
+		t.Errorf("kept record panic %q", message)
+	}
+	if message := guardPanic(func() { logger.Info("logger guard reported once") }); message != "" {
+		t.Errorf("kept record panic %q must be reported once", message)
+	}
+}
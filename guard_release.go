@@ -0,0 +1,9 @@
+//go:build !logger_debug
+// +build !logger_debug
+
+package go_logger
+
+// records are not guarded without the logger_debug build tag
+func guardWriter(adapterName string, write func(loggerMsg *loggerMessage) error) func(loggerMsg *loggerMessage) error {
+	return write
+}
@@ -0,0 +1,144 @@
+package go_logger
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// process stdout/stderr hijacked through a logger, at most one at a time
+var stdioHijack = struct {
+	lock      sync.Mutex
+	active    bool
+	originals atomic.Value // map[io.Writer]io.Writer, hijacked file to the original file
+}{}
+
+// maximum bytes of a record of a hijacked stream, longer lines are split
+const hijackLineBytes = 1024 * 1024
+
+// stdout or stderr redirected to a pipe read by the logger
+type hijackedStream struct {
+	name  string
+	file  *os.File // os.Stdout or os.Stderr
+	saved *os.File // duplicate of the original file descriptor
+	pipe  *os.File // write end of the pipe, file descriptor of file
+	done  chan struct{}
+}
+
+//redirect the file descriptors of os.Stdout and os.Stderr through pipes to the logger, so fmt.Println
+//and messages of dependencies are records with a "stream" field, one record per line
+//the console adapter keeps writing to the original stdout and stderr
+//a crash of the process may lose the last lines, the pipes are read by a goroutine; unix only
+//params : stdoutLevel int, stderrLevel int
+//return : restore func() error, restores the file descriptors after the pipes are drained; error
+func (logger *Logger) HijackStdio(stdoutLevel int, stderrLevel int) (func() error, error) {
+	for _, level := range []int{stdoutLevel, stderrLevel} {
+		if _, ok := levelStringMapping[level]; !ok {
+			return nil, errors.New("logger: level " + strconv.Itoa(level) + " is illegal!")
+		}
+	}
+	stdioHijack.lock.Lock()
+	defer stdioHijack.lock.Unlock()
+
+	if stdioHijack.active {
+		return nil, errors.New("logger: stdio is already hijacked!")
+	}
+	streams := []*hijackedStream{}
+	originals := map[io.Writer]io.Writer{}
+	for _, stdio := range []struct {
+		name  string
+		file  *os.File
+		level int
+	}{{"stdout", os.Stdout, stdoutLevel}, {"stderr", os.Stderr, stderrLevel}} {
+		stream, err := logger.hijackStream(stdio.name, stdio.file, stdio.level)
+		if err != nil {
+			for _, stream := range streams {
+				stream.restore()
+			}
+			return nil, err
+		}
+		streams = append(streams, stream)
+		originals[stream.file] = stream.saved
+	}
+	stdioHijack.originals.Store(originals)
+	stdioHijack.active = true
+
+	once := sync.Once{}
+	restore := func() error {
+		var err error
+		once.Do(func() {
+			stdioHijack.lock.Lock()
+			defer stdioHijack.lock.Unlock()
+
+			for _, stream := range streams {
+				if restoreErr := stream.restore(); restoreErr != nil && err == nil {
+					err = restoreErr
+				}
+			}
+			stdioHijack.originals.Store(map[io.Writer]io.Writer{})
+			stdioHijack.active = false
+		})
+		return err
+	}
+	return restore, nil
+}
+
+//redirect file to a pipe, lines are written to the logger at level
+func (logger *Logger) hijackStream(name string, file *os.File, level int) (*hijackedStream, error) {
+	savedFd, err := stdioDup(int(file.Fd()))
+	if err != nil {
+		return nil, err
+	}
+	saved := os.NewFile(uintptr(savedFd), file.Name())
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		saved.Close()
+		return nil, err
+	}
+	if err := stdioRedirect(int(writer.Fd()), int(file.Fd())); err != nil {
+		saved.Close()
+		reader.Close()
+		writer.Close()
+		return nil, err
+	}
+
+	stream := &hijackedStream{name: name, file: file, saved: saved, pipe: writer, done: make(chan struct{})}
+	go func() {
+		defer close(stream.done)
+		defer reader.Close()
+		// a line longer than the buffer is written in chunks, the pipe is read until closed,
+		// a stopped reader would block or break the writers of the redirected descriptor
+		lines := bufio.NewReaderSize(reader, hijackLineBytes)
+		for {
+			line, _, err := lines.ReadLine()
+			if err != nil {
+				return
+			}
+			logger.writer(1, level, string(line), map[string]interface{}{"stream": name})
+		}
+	}()
+	return stream, nil
+}
+
+//restore the file descriptor, then wait for the pipe to be drained
+func (stream *hijackedStream) restore() error {
+	err := stdioRedirect(int(stream.saved.Fd()), int(stream.file.Fd()))
+	// the file descriptor of file was the last other write end of the pipe
+	stream.pipe.Close()
+	<-stream.done
+	stream.saved.Close()
+	return err
+}
+
+//original file of a hijacked os.Stdout or os.Stderr, for the console adapter
+func stdioWriter(writer io.Writer) io.Writer {
+	originals, _ := stdioHijack.originals.Load().(map[io.Writer]io.Writer)
+	if original, ok := originals[writer]; ok {
+		return original
+	}
+	return writer
+}
@@ -0,0 +1,16 @@
+//go:build darwin || dragonfly || freebsd || netbsd || openbsd || (linux && !arm64 && !riscv64 && !loong64)
+// +build darwin dragonfly freebsd netbsd openbsd linux,!arm64,!riscv64,!loong64
+
+package go_logger
+
+import "syscall"
+
+// duplicate a file descriptor
+func stdioDup(fd int) (int, error) {
+	return syscall.Dup(fd)
+}
+
+// make newFd a copy of oldFd
+func stdioRedirect(oldFd int, newFd int) error {
+	return syscall.Dup2(oldFd, newFd)
+}
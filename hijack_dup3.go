@@ -0,0 +1,17 @@
+//go:build linux && (arm64 || riscv64 || loong64)
+// +build linux
+// +build arm64 riscv64 loong64
+
+package go_logger
+
+import "syscall"
+
+// duplicate a file descriptor
+func stdioDup(fd int) (int, error) {
+	return syscall.Dup(fd)
+}
+
+// make newFd a copy of oldFd, these architectures have dup3 only
+func stdioRedirect(oldFd int, newFd int) error {
+	return syscall.Dup3(oldFd, newFd, 0)
+}
@@ -0,0 +1,15 @@
+//go:build !darwin && !dragonfly && !freebsd && !netbsd && !openbsd && !linux
+// +build !darwin,!dragonfly,!freebsd,!netbsd,!openbsd,!linux
+
+package go_logger
+
+import "errors"
+
+// file descriptors cannot be redirected on this platform
+func stdioDup(fd int) (int, error) {
+	return 0, errors.New("logger: stdio hijacking is not supported on this platform!")
+}
+
+func stdioRedirect(oldFd int, newFd int) error {
+	return errors.New("logger: stdio hijacking is not supported on this platform!")
+}
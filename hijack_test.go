@@ -0,0 +1,75 @@
+//go:build darwin || dragonfly || freebsd || netbsd || openbsd || linux
+// +build darwin dragonfly freebsd netbsd openbsd linux
+
+package go_logger
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestLogger_HijackStdio(t *testing.T) {
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("console", LoggerLevelDebug, &ConsoleConfig{
+		Format: "[%level_string%] %body% %fields%",
+	})
+	buffer := &lockedBuffer{}
+	logger.outputList()[0].LoggerAbstract.(*AdapterConsole).write.writer = buffer
+
+	if _, err := logger.HijackStdio(100, LoggerLevelError); err == nil {
+		t.Error("hijack stdio illegal level must return error")
+	}
+	restore, err := logger.HijackStdio(LoggerLevelInfo, LoggerLevelError)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if _, err := logger.HijackStdio(LoggerLevelInfo, LoggerLevelError); err == nil {
+		restore()
+		t.Fatal("hijack stdio twice must return error")
+	}
+	fmt.Println("logger hijack stdout")
+	fmt.Fprintln(os.Stderr, "logger hijack stderr")
+	if err := restore(); err != nil {
+		t.Fatal(err.Error())
+	}
+	restore()
+
+	output := buffer.String()
+	if !strings.Contains(output, "[Info] logger hijack stdout stream=stdout\n") ||
+		!strings.Contains(output, "[Error] logger hijack stderr stream=stderr\n") {
+		t.Error("hijack stdio output error: " + output)
+	}
+}
+
+func TestLogger_HijackStdioLongLine(t *testing.T) {
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("console", LoggerLevelDebug, &ConsoleConfig{
+		Format: "%body%",
+	})
+	buffer := &lockedBuffer{}
+	logger.outputList()[0].LoggerAbstract.(*AdapterConsole).write.writer = buffer
+
+	restore, err := logger.HijackStdio(LoggerLevelInfo, LoggerLevelError)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	fmt.Println(strings.Repeat("x", hijackLineBytes+10))
+	fmt.Println("logger hijack after long line")
+	if err := restore(); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	suffix := " stream=stdout"
+	lines := strings.Split(buffer.String(), "\n")
+	// the tiny core truncates message bodies
+	if len(lines) != 4 || (loggerBodyMaxBytes == 0 && len(lines[0]) != hijackLineBytes+len(suffix)) ||
+		lines[1] != strings.Repeat("x", 10)+suffix || lines[2] != "logger hijack after long line"+suffix {
+		t.Errorf("hijack stdio long line must be split and reading continued: %d lines", len(lines))
+	}
+}
@@ -0,0 +1,92 @@
+//go:build !tinygo && !logger_tiny
+// +build !tinygo,!logger_tiny
+
+package go_logger
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// headers removed from dumped requests and responses
+var HttpDumpRedactHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+}
+
+//log http request at debug level with "request.*" fields
+//the body is restored after read, nothing is read or logged if no output writes debug messages
+//params : msg string, req *http.Request, maxBody int, max bytes of body in fields, 0 is no body
+func (logger *Logger) DebugRequest(msg string, req *http.Request, maxBody int) {
+	if !logger.levelEnabled(LoggerLevelDebug) {
+		return
+	}
+	fields := map[string]interface{}{
+		"request.method":  req.Method,
+		"request.url":     req.URL.String(),
+		"request.proto":   req.Proto,
+		"request.host":    req.Host,
+		"request.headers": dumpHeaders(req.Header),
+	}
+	if maxBody > 0 && req.Body != nil {
+		var body []byte
+		body, req.Body = dumpBody(req.Body, maxBody)
+		fields["request.body"] = string(body)
+	}
+	logger.writer(2, LoggerLevelDebug, msg, fields)
+}
+
+//log http response at debug level with "response.*" fields
+//the body is restored after read, nothing is read or logged if no output writes debug messages
+//params : msg string, resp *http.Response, maxBody int, max bytes of body in fields, 0 is no body
+func (logger *Logger) DebugResponse(msg string, resp *http.Response, maxBody int) {
+	if !logger.levelEnabled(LoggerLevelDebug) {
+		return
+	}
+	fields := map[string]interface{}{
+		"response.status":  resp.StatusCode,
+		"response.proto":   resp.Proto,
+		"response.headers": dumpHeaders(resp.Header),
+	}
+	if resp.Request != nil {
+		fields["response.url"] = resp.Request.URL.String()
+	}
+	if maxBody > 0 && resp.Body != nil {
+		var body []byte
+		body, resp.Body = dumpBody(resp.Body, maxBody)
+		fields["response.body"] = string(body)
+	}
+	logger.writer(2, LoggerLevelDebug, msg, fields)
+}
+
+//dump headers without redact headers
+func dumpHeaders(header http.Header) map[string]string {
+	headers := make(map[string]string, len(header))
+	for key, values := range header {
+		headers[key] = strings.Join(values, ", ")
+	}
+	for _, key := range HttpDumpRedactHeaders {
+		delete(headers, http.CanonicalHeaderKey(key))
+	}
+	return headers
+}
+
+//read up to maxBody bytes of body, return the read bytes and a body to replace the original
+func dumpBody(body io.ReadCloser, maxBody int) ([]byte, io.ReadCloser) {
+	data, _ := ioutil.ReadAll(io.LimitReader(body, int64(maxBody)))
+	return data, &dumpedBody{
+		Reader: io.MultiReader(bytes.NewReader(data), body),
+		Closer: body,
+	}
+}
+
+// body restored after dump
+type dumpedBody struct {
+	io.Reader
+	io.Closer
+}
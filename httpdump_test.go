@@ -0,0 +1,75 @@
+//go:build !tinygo && !logger_tiny
+// +build !tinygo,!logger_tiny
+
+package go_logger
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLogger_DebugRequest(t *testing.T) {
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("console", LoggerLevelDebug, &ConsoleConfig{
+		JsonFormat: true,
+	})
+	buffer := &bytes.Buffer{}
+	logger.outputList()[0].LoggerAbstract.(*AdapterConsole).write.writer = buffer
+
+	req := httptest.NewRequest("POST", "http://example.com/login", strings.NewReader("user=tom&password=secret"))
+	req.Header.Set("Authorization", "Bearer token")
+	req.Header.Set("Cookie", "session=abc")
+	req.Header.Set("User-Agent", "go-logger")
+
+	logger.DebugRequest("request", req, 8)
+
+	output := buffer.String()
+	if strings.Contains(output, "Bearer") || strings.Contains(output, "session") {
+		t.Error("logger debug request must remove Authorization and Cookie: " + output)
+	}
+	if !strings.Contains(output, `"request.body":"user=tom"`) || !strings.Contains(output, "go-logger") {
+		t.Error("logger debug request fields error: " + output)
+	}
+	body, _ := ioutil.ReadAll(req.Body)
+	if string(body) != "user=tom&password=secret" {
+		t.Error("logger debug request must restore body")
+	}
+}
+
+// reader fails the test if the body is read
+type httpDumpUnreadBody struct {
+	t *testing.T
+}
+
+func (body httpDumpUnreadBody) Read(p []byte) (int, error) {
+	body.t.Error("logger debug request must not read the body without a debug output")
+	return 0, io.EOF
+}
+
+func TestLogger_DebugRequestDisabled(t *testing.T) {
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("console", LoggerLevelInfo, &ConsoleConfig{})
+	buffer := &bytes.Buffer{}
+	logger.outputList()[0].LoggerAbstract.(*AdapterConsole).write.writer = buffer
+
+	body := ioutil.NopCloser(httpDumpUnreadBody{t})
+	req := httptest.NewRequest("POST", "http://example.com/login", nil)
+	req.Body = body
+
+	logger.DebugRequest("request", req, 8)
+
+	if buffer.Len() != 0 {
+		t.Error("logger debug request must not write below the output level: " + buffer.String())
+	}
+	if req.Body != body {
+		t.Error("logger debug request must keep the body")
+	}
+}
@@ -0,0 +1,53 @@
+package go_logger
+
+// message key field name when the body is translated by catalog
+const messageKeyField = "message_key"
+
+//set level display strings of text output, e.g. map[int]string{LoggerLevelError: "错误"}
+//JSON level_string keeps the default level string
+//params : levelStrings map[int]string, nil to reset
+func (logger *Logger) SetLevelDisplayStrings(levelStrings map[int]string) {
+	logger.lock.Lock()
+	defer logger.lock.Unlock()
+
+	displayStrings := make(map[int]string, len(levelStrings))
+	for level, levelString := range levelStrings {
+		displayStrings[level] = levelString
+	}
+	logger.levelDisplayStrings.Store(displayStrings)
+}
+
+//set message catalog, message body equal to a catalog key is translated
+//and the original key is kept in the "message_key" field
+//params : catalog map[string]string, nil to reset
+func (logger *Logger) SetMessageCatalog(catalog map[string]string) {
+	logger.lock.Lock()
+	defer logger.lock.Unlock()
+
+	messageCatalog := make(map[string]string, len(catalog))
+	for key, message := range catalog {
+		messageCatalog[key] = message
+	}
+	logger.messageCatalog.Store(messageCatalog)
+}
+
+//translate level display string and message body
+func (logger *Logger) translate(loggerMsg *loggerMessage) {
+	levelDisplayStrings, _ := logger.levelDisplayStrings.Load().(map[int]string)
+	if levelString, ok := levelDisplayStrings[loggerMsg.Level]; ok {
+		loggerMsg.LevelDisplayString = levelString
+	}
+
+	messageCatalog, _ := logger.messageCatalog.Load().(map[string]string)
+	message, ok := messageCatalog[loggerMsg.Body]
+	if !ok {
+		return
+	}
+	fields := make(map[string]interface{}, len(loggerMsg.Fields)+1)
+	for key, value := range loggerMsg.Fields {
+		fields[key] = value
+	}
+	fields[messageKeyField] = loggerMsg.Body
+	loggerMsg.Fields = fields
+	loggerMsg.Body = message
+}
@@ -0,0 +1,25 @@
+package go_logger
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestLogger_SetLevelDisplayStrings(t *testing.T) {
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("console", LoggerLevelDebug, &ConsoleConfig{
+		Format: "[%level_string%] %body%",
+	})
+	buffer := &bytes.Buffer{}
+	logger.outputList()[0].LoggerAbstract.(*AdapterConsole).write.writer = buffer
+
+	logger.SetLevelDisplayStrings(map[int]string{LoggerLevelError: "错误"})
+	logger.SetMessageCatalog(map[string]string{"db.timeout": "数据库超时"})
+
+	logger.Error("db.timeout")
+	if buffer.String() != "[错误] 数据库超时 message_key=db.timeout\n" {
+		t.Error("logger level display strings error: " + buffer.String())
+	}
+}
@@ -0,0 +1,87 @@
+package go_logger
+
+import (
+	"errors"
+	"hash/fnv"
+	"strconv"
+	"sync"
+)
+
+// field name of the interned record id
+const internIdField = "intern_id"
+
+// optional interface of adapters, a message identical to a recent one is written
+// by WriteRepeat with the intern id of the first one, instead of the full record
+type RepeatWriter interface {
+	WriteRepeat(loggerMsg *loggerMessage, internId string) error
+}
+
+// recent records of an output, FIFO of record keys
+type internTable struct {
+	lock sync.Mutex
+	keys []uint64
+	next int
+	seen map[uint64]bool
+}
+
+//set interning of an attached output, a message with the same level, body and fields
+//as one of the last size distinct records is written by the adapter WriteRepeat;
+//full records have the "intern_id" field
+//params : adapterName string, size int, 0 to disable
+//return : error
+func (logger *Logger) SetInterning(adapterName string, size int) error {
+	if size < 0 {
+		return errors.New("logger: interning size cannot be negative!")
+	}
+	logger.lock.Lock()
+	defer logger.lock.Unlock()
+
+	return logger.updateOutput(adapterName, func(output *outputLogger) error {
+		if size == 0 {
+			output.intern = nil
+			return nil
+		}
+		if _, ok := output.LoggerAbstract.(RepeatWriter); !ok {
+			return errors.New("logger: adapter " + adapterName + " does not support repeat encoding!")
+		}
+		output.intern = &internTable{
+			keys: make([]uint64, size),
+			seen: make(map[uint64]bool, size),
+		}
+		return nil
+	})
+}
+
+//intern message, return the message to write and whether it is a repeat
+func (table *internTable) intern(loggerMsg *loggerMessage) (*loggerMessage, string, bool) {
+	h := fnv.New64a()
+	h.Write([]byte(strconv.Itoa(loggerMsg.Level)))
+	h.Write([]byte{0})
+	h.Write([]byte(loggerMsg.Body))
+	h.Write([]byte{0})
+	h.Write([]byte(loggerFieldsFormat(loggerMsg.Fields)))
+	key := h.Sum64()
+	internId := strconv.FormatUint(key, 16)
+
+	table.lock.Lock()
+	defer table.lock.Unlock()
+
+	if table.seen[key] {
+		return loggerMsg, internId, true
+	}
+	if len(table.seen) == len(table.keys) {
+		delete(table.seen, table.keys[table.next])
+	}
+	table.keys[table.next] = key
+	table.next = (table.next + 1) % len(table.keys)
+	table.seen[key] = true
+
+	internMsg := *loggerMsg
+	fields := make(map[string]interface{}, len(loggerMsg.Fields)+1)
+	for key, value := range loggerMsg.Fields {
+		fields[key] = value
+	}
+	fields[internIdField] = internId
+	internMsg.Fields = fields
+	return &internMsg, internId, false
+}
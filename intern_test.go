@@ -0,0 +1,63 @@
+//go:build !tinygo && !logger_tiny
+// +build !tinygo,!logger_tiny
+
+package go_logger
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestLogger_SetInterning(t *testing.T) {
+
+	lock := sync.Mutex{}
+	requests := []map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		request := map[string]string{}
+		for key := range r.Form {
+			request[key] = r.Form.Get(key)
+		}
+		lock.Lock()
+		requests = append(requests, request)
+		lock.Unlock()
+	}))
+	defer server.Close()
+
+	logger := NewLogger()
+	if logger.SetInterning("console", 10) == nil {
+		t.Error("adapter without repeat encoding must be error")
+	}
+	logger.Detach("console")
+	logger.Attach("api", LoggerLevelDebug, &ApiConfig{
+		Url:    server.URL,
+		Method: "POST",
+	})
+	logger.SetInterning("api", 1)
+
+	logger.Infow("logger heartbeat", "node", 1)
+	logger.Infow("logger heartbeat", "node", 1)
+	logger.Infow("logger heartbeat", "node", 2)
+	logger.Infow("logger heartbeat", "node", 1)
+
+	lock.Lock()
+	defer lock.Unlock()
+	if len(requests) != 4 {
+		t.Fatalf("interning must write every message, got %d requests", len(requests))
+	}
+	if !strings.Contains(requests[0]["fields"], `"intern_id":"`) || requests[0]["body"] != "logger heartbeat" {
+		t.Error("full record must have intern_id field")
+	}
+	if requests[1]["repeat"] == "" || requests[1]["body"] != "" {
+		t.Error("identical message must be written as repeat")
+	}
+	if requests[2]["body"] != "logger heartbeat" || requests[3]["body"] != "logger heartbeat" {
+		t.Error("message evicted from interning window must be written in full")
+	}
+	if !strings.Contains(requests[0]["fields"], requests[1]["repeat"]) {
+		t.Error("repeat must reference the intern_id of the full record")
+	}
+}
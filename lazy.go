@@ -0,0 +1,177 @@
+package go_logger
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// adapter initialized in the background, messages are buffered until Init succeeds
+type lazyAdapter struct {
+	ready    int32 // adapter initialized, atomic
+	lock     sync.Mutex
+	adapter  LoggerAbstract
+	config   Config
+	interval time.Duration
+	buffer   []*loggerMessage
+	size     int
+	dropped  int64
+	err      error // last Init error
+	stopChan chan struct{}
+	stopOnce sync.Once
+}
+
+//attach an adapter whose Init may fail at startup, e.g. kafka, database or socket not reachable
+//a failed Init is retried every retryInterval in the background instead of exiting the process,
+//meanwhile at most bufferSize messages are buffered, the oldest are dropped,
+//and written in order once Init succeeds
+//params : adapterName string, level int, config Config, retryInterval time.Duration, bufferSize int
+//return : error
+func (logger *Logger) AttachLazy(adapterName string, level int, config Config, retryInterval time.Duration, bufferSize int) error {
+	if retryInterval <= 0 {
+		return errors.New("logger: lazy adapter retry interval must be greater than 0!")
+	}
+	if bufferSize < 0 {
+		return errors.New("logger: lazy adapter buffer size cannot be negative!")
+	}
+	logger.lock.Lock()
+	defer logger.lock.Unlock()
+
+	if logger.output(adapterName) != nil {
+		return errors.New("logger: adapter " + adapterName + " already attached!")
+	}
+	logFun, ok := adapters[adapterName]
+	if !ok {
+		return errors.New("logger: adapter " + adapterName + " is not registered!")
+	}
+	if err := checkConfigFormat(config); err != nil {
+		if err = logger.misuse(err); err != nil {
+			return err
+		}
+	}
+	lazy := &lazyAdapter{
+		adapter:  logFun(),
+		interval: retryInterval,
+		size:     bufferSize,
+		stopChan: make(chan struct{}),
+	}
+	lazy.Init(config)
+
+	logger.addOutput(&outputLogger{
+		outputState:    &outputState{level: int64(level)},
+		Name:           adapterName,
+		LoggerAbstract: lazy,
+		config:         config,
+	})
+	return nil
+}
+
+//init adapter, retry in the background if failed
+func (lazy *lazyAdapter) Init(config Config) error {
+	lazy.config = config
+	err := lazy.adapter.Init(config)
+	if err == nil {
+		atomic.StoreInt32(&lazy.ready, 1)
+		return nil
+	}
+	lazy.err = err
+	go lazy.retry()
+	return nil
+}
+
+//retry Init until succeeded or stopped, then write buffered messages
+func (lazy *lazyAdapter) retry() {
+	ticker := time.NewTicker(lazy.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-lazy.stopChan:
+			return
+		case <-ticker.C:
+		}
+		err := lazy.adapter.Init(lazy.config)
+
+		lazy.lock.Lock()
+		if err != nil {
+			lazy.err = err
+			lazy.lock.Unlock()
+			continue
+		}
+		for _, loggerMsg := range lazy.buffer {
+			lazy.adapter.Write(loggerMsg)
+		}
+		lazy.buffer = nil
+		lazy.err = nil
+		atomic.StoreInt32(&lazy.ready, 1)
+		lazy.lock.Unlock()
+		return
+	}
+}
+
+//stop retrying, buffered messages are discarded
+func (lazy *lazyAdapter) stop() {
+	lazy.stopOnce.Do(func() {
+		close(lazy.stopChan)
+	})
+}
+
+func (lazy *lazyAdapter) Write(loggerMsg *loggerMessage) error {
+	if atomic.LoadInt32(&lazy.ready) == 1 {
+		return lazy.adapter.Write(loggerMsg)
+	}
+	lazy.lock.Lock()
+	// initialized while waiting for the lock, buffer is written
+	if atomic.LoadInt32(&lazy.ready) == 1 {
+		lazy.lock.Unlock()
+		return lazy.adapter.Write(loggerMsg)
+	}
+	defer lazy.lock.Unlock()
+
+	if lazy.size == 0 {
+		lazy.dropped++
+		return nil
+	}
+	if len(lazy.buffer) >= lazy.size {
+		lazy.buffer = lazy.buffer[1:]
+		lazy.dropped++
+	}
+	// the record is shared by the outputs, a copy is buffered
+	bufferMsg := *loggerMsg
+	lazy.buffer = append(lazy.buffer, &bufferMsg)
+	return nil
+}
+
+func (lazy *lazyAdapter) Flush() {
+	lazy.FlushError()
+}
+
+//not initialized adapter reports the last Init error
+func (lazy *lazyAdapter) FlushError() error {
+	if atomic.LoadInt32(&lazy.ready) == 1 {
+		if flusher, ok := lazy.adapter.(ErrorFlusher); ok {
+			return flusher.FlushError()
+		}
+		lazy.adapter.Flush()
+		return nil
+	}
+	lazy.lock.Lock()
+	defer lazy.lock.Unlock()
+	return errors.New("not initialized, " + strconv.Itoa(len(lazy.buffer)) + " messages buffered, " +
+		strconv.FormatInt(lazy.dropped, 10) + " dropped, error: " + lazy.err.Error())
+}
+
+//initialized adapter waits for its acknowledgements if it is an AckFlusher
+func (lazy *lazyAdapter) FlushAck(timeout time.Duration) error {
+	if atomic.LoadInt32(&lazy.ready) == 1 {
+		if flusher, ok := lazy.adapter.(AckFlusher); ok {
+			return flusher.FlushAck(timeout)
+		}
+	}
+	return lazy.FlushError()
+}
+
+func (lazy *lazyAdapter) Name() string {
+	return lazy.adapter.Name()
+}
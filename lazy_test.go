@@ -0,0 +1,110 @@
+package go_logger
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// adapter connects only when available
+type adapterFlaky struct {
+	lock   sync.Mutex
+	bodies []string
+}
+
+var flakyAvailable int32
+
+func (adapter *adapterFlaky) Init(config Config) error {
+	if atomic.LoadInt32(&flakyAvailable) == 0 {
+		return errors.New("connection refused")
+	}
+	return nil
+}
+
+func (adapter *adapterFlaky) Write(loggerMsg *loggerMessage) error {
+	adapter.lock.Lock()
+	defer adapter.lock.Unlock()
+	adapter.bodies = append(adapter.bodies, loggerMsg.Body)
+	return nil
+}
+
+func (adapter *adapterFlaky) Flush() {
+}
+
+func (adapter *adapterFlaky) Name() string {
+	return "flaky"
+}
+
+func (adapter *adapterFlaky) written() []string {
+	adapter.lock.Lock()
+	defer adapter.lock.Unlock()
+	return append([]string{}, adapter.bodies...)
+}
+
+func init() {
+	Register("flaky", func() LoggerAbstract {
+		return &adapterFlaky{}
+	})
+}
+
+func TestLogger_AttachLazy(t *testing.T) {
+
+	atomic.StoreInt32(&flakyAvailable, 0)
+	logger := NewLogger()
+	logger.Detach("console")
+	err := logger.AttachLazy("flaky", LoggerLevelDebug, &countConfig{}, 5*time.Millisecond, 2)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	flaky := logger.outputList()[0].LoggerAbstract.(*lazyAdapter).adapter.(*adapterFlaky)
+
+	logger.Info("logger lazy 1")
+	logger.Info("logger lazy 2")
+	logger.Info("logger lazy 3")
+	if logger.Flush() == nil {
+		t.Error("not initialized lazy adapter flush must be error")
+	}
+	if len(flaky.written()) != 0 {
+		t.Error("not initialized lazy adapter must buffer messages")
+	}
+
+	atomic.StoreInt32(&flakyAvailable, 1)
+	deadline := time.Now().Add(time.Second)
+	for len(flaky.written()) < 2 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	logger.Info("logger lazy 4")
+	bodies := flaky.written()
+	if len(bodies) != 3 || bodies[0] != "logger lazy 2" || bodies[1] != "logger lazy 3" || bodies[2] != "logger lazy 4" {
+		t.Errorf("lazy adapter buffered messages error: %v", bodies)
+	}
+	if err := logger.Flush(); err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func TestLogger_AttachLazyError(t *testing.T) {
+
+	logger := NewLogger()
+	if logger.AttachLazy("console", LoggerLevelDebug, &ConsoleConfig{}, time.Second, 10) == nil {
+		t.Error("attached adapter must be error")
+	}
+	if logger.AttachLazy("not_registered", LoggerLevelDebug, &ConsoleConfig{}, time.Second, 10) == nil {
+		t.Error("not registered adapter must be error")
+	}
+	if logger.AttachLazy("flaky", LoggerLevelDebug, &countConfig{}, 0, 10) == nil {
+		t.Error("zero retry interval must be error")
+	}
+
+	atomic.StoreInt32(&flakyAvailable, 0)
+	logger.AttachLazy("flaky", LoggerLevelDebug, &countConfig{}, time.Millisecond, 10)
+	lazy := logger.output("flaky").LoggerAbstract.(*lazyAdapter)
+	logger.Detach("flaky")
+	select {
+	case <-lazy.stopChan:
+	default:
+		t.Error("detached lazy adapter must stop retrying")
+	}
+}
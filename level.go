@@ -0,0 +1,59 @@
+package go_logger
+
+import (
+	"errors"
+	"strconv"
+	"sync/atomic"
+)
+
+// optional interface of adapters, notified when the output level changes at runtime
+type LevelChangeListener interface {
+	OnLevelChange(oldLevel int, newLevel int)
+}
+
+//set level of an attached output at runtime
+//the adapter is notified if it implements LevelChangeListener and the level changes
+//params : adapterName string, level int
+//return : error
+func (logger *Logger) SetLevel(adapterName string, level int) error {
+	if _, ok := levelStringMapping[level]; !ok {
+		return errors.New("logger: level " + strconv.Itoa(level) + " is illegal!")
+	}
+	logger.lock.Lock()
+	output := logger.output(adapterName)
+	logger.lock.Unlock()
+	if output == nil {
+		return errors.New("logger: adapter " + adapterName + " is not attached!")
+	}
+
+	oldLevel := int(atomic.SwapInt64(&output.level, int64(level)))
+	if oldLevel == level {
+		return nil
+	}
+	if listener, ok := output.LoggerAbstract.(LevelChangeListener); ok {
+		listener.OnLevelChange(oldLevel, level)
+	}
+	return nil
+}
+
+//output level
+func (output *outputLogger) getLevel() int {
+	return int(atomic.LoadInt64(&output.level))
+}
+
+//an output writes messages of level, or records are buffered until Ready
+//return : false if the logger is disabled or every output has a more severe level
+func (logger *Logger) levelEnabled(level int) bool {
+	if logger.Disabled() {
+		return false
+	}
+	if buffer, _ := logger.startup.Load().(*startupBuffer); buffer != nil && !buffer.isReady() {
+		return true
+	}
+	for _, output := range logger.outputList() {
+		if output.getLevel() >= level {
+			return true
+		}
+	}
+	return false
+}
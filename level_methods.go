@@ -0,0 +1,744 @@
+// Code generated by gen_levels.go; DO NOT EDIT.
+
+package go_logger
+
+import (
+	"context"
+	"fmt"
+)
+
+// log emergency level
+func (logger *Logger) Emergency(msg string) {
+	if logger.Disabled() {
+		return
+	}
+	logger.writer(2, LoggerLevelEmergency, msg, nil)
+}
+
+// log emergency format
+func (logger *Logger) Emergencyf(format string, a ...interface{}) {
+	if logger.Disabled() {
+		return
+	}
+	msg := fmt.Sprintf(format, a...)
+	logger.writer(2, LoggerLevelEmergency, msg, nil)
+}
+
+// log emergency key-values
+func (logger *Logger) Emergencyw(msg string, keysAndValues ...interface{}) {
+	if logger.Disabled() {
+		return
+	}
+	logger.writerw(LoggerLevelEmergency, msg, keysAndValues)
+}
+
+// log emergency level with context fields
+func (logger *Logger) EmergencyCtx(ctx context.Context, msg string) {
+	if logger.Disabled() {
+		return
+	}
+	logger.writer(2, LoggerLevelEmergency, msg, logger.messageContextFields(ctx))
+}
+
+// log emergency format with context fields
+func (logger *Logger) EmergencyCtxf(ctx context.Context, format string, a ...interface{}) {
+	if logger.Disabled() {
+		return
+	}
+	msg := fmt.Sprintf(format, a...)
+	logger.writer(2, LoggerLevelEmergency, msg, logger.messageContextFields(ctx))
+}
+
+// log emergency level with entry fields
+func (entry *Entry) Emergency(msg string) {
+	if entry.logger.Disabled() {
+		return
+	}
+	entry.writer(2, LoggerLevelEmergency, msg, entry.messageFields())
+}
+
+// log emergency format with entry fields
+func (entry *Entry) Emergencyf(format string, a ...interface{}) {
+	if entry.logger.Disabled() {
+		return
+	}
+	msg := fmt.Sprintf(format, a...)
+	entry.writer(2, LoggerLevelEmergency, msg, entry.messageFields())
+}
+
+// log emergency key-values with entry fields
+func (entry *Entry) Emergencyw(msg string, keysAndValues ...interface{}) {
+	if entry.logger.Disabled() {
+		return
+	}
+	entry.writerw(LoggerLevelEmergency, msg, keysAndValues)
+}
+
+// log emergency level by the default logger
+func Emergency(msg string) {
+	if Default().Disabled() {
+		return
+	}
+	Default().writer(2, LoggerLevelEmergency, msg, nil)
+}
+
+// log emergency format by the default logger
+func Emergencyf(format string, a ...interface{}) {
+	if Default().Disabled() {
+		return
+	}
+	msg := fmt.Sprintf(format, a...)
+	Default().writer(2, LoggerLevelEmergency, msg, nil)
+}
+
+// log emergency key-values by the default logger
+func Emergencyw(msg string, keysAndValues ...interface{}) {
+	if Default().Disabled() {
+		return
+	}
+	Default().writerw(LoggerLevelEmergency, msg, keysAndValues)
+}
+
+// log alert level
+func (logger *Logger) Alert(msg string) {
+	if logger.Disabled() {
+		return
+	}
+	logger.writer(2, LoggerLevelAlert, msg, nil)
+}
+
+// log alert format
+func (logger *Logger) Alertf(format string, a ...interface{}) {
+	if logger.Disabled() {
+		return
+	}
+	msg := fmt.Sprintf(format, a...)
+	logger.writer(2, LoggerLevelAlert, msg, nil)
+}
+
+// log alert key-values
+func (logger *Logger) Alertw(msg string, keysAndValues ...interface{}) {
+	if logger.Disabled() {
+		return
+	}
+	logger.writerw(LoggerLevelAlert, msg, keysAndValues)
+}
+
+// log alert level with context fields
+func (logger *Logger) AlertCtx(ctx context.Context, msg string) {
+	if logger.Disabled() {
+		return
+	}
+	logger.writer(2, LoggerLevelAlert, msg, logger.messageContextFields(ctx))
+}
+
+// log alert format with context fields
+func (logger *Logger) AlertCtxf(ctx context.Context, format string, a ...interface{}) {
+	if logger.Disabled() {
+		return
+	}
+	msg := fmt.Sprintf(format, a...)
+	logger.writer(2, LoggerLevelAlert, msg, logger.messageContextFields(ctx))
+}
+
+// log alert level with entry fields
+func (entry *Entry) Alert(msg string) {
+	if entry.logger.Disabled() {
+		return
+	}
+	entry.writer(2, LoggerLevelAlert, msg, entry.messageFields())
+}
+
+// log alert format with entry fields
+func (entry *Entry) Alertf(format string, a ...interface{}) {
+	if entry.logger.Disabled() {
+		return
+	}
+	msg := fmt.Sprintf(format, a...)
+	entry.writer(2, LoggerLevelAlert, msg, entry.messageFields())
+}
+
+// log alert key-values with entry fields
+func (entry *Entry) Alertw(msg string, keysAndValues ...interface{}) {
+	if entry.logger.Disabled() {
+		return
+	}
+	entry.writerw(LoggerLevelAlert, msg, keysAndValues)
+}
+
+// log alert level by the default logger
+func Alert(msg string) {
+	if Default().Disabled() {
+		return
+	}
+	Default().writer(2, LoggerLevelAlert, msg, nil)
+}
+
+// log alert format by the default logger
+func Alertf(format string, a ...interface{}) {
+	if Default().Disabled() {
+		return
+	}
+	msg := fmt.Sprintf(format, a...)
+	Default().writer(2, LoggerLevelAlert, msg, nil)
+}
+
+// log alert key-values by the default logger
+func Alertw(msg string, keysAndValues ...interface{}) {
+	if Default().Disabled() {
+		return
+	}
+	Default().writerw(LoggerLevelAlert, msg, keysAndValues)
+}
+
+// log critical level
+func (logger *Logger) Critical(msg string) {
+	if logger.Disabled() {
+		return
+	}
+	logger.writer(2, LoggerLevelCritical, msg, nil)
+}
+
+// log critical format
+func (logger *Logger) Criticalf(format string, a ...interface{}) {
+	if logger.Disabled() {
+		return
+	}
+	msg := fmt.Sprintf(format, a...)
+	logger.writer(2, LoggerLevelCritical, msg, nil)
+}
+
+// log critical key-values
+func (logger *Logger) Criticalw(msg string, keysAndValues ...interface{}) {
+	if logger.Disabled() {
+		return
+	}
+	logger.writerw(LoggerLevelCritical, msg, keysAndValues)
+}
+
+// log critical level with context fields
+func (logger *Logger) CriticalCtx(ctx context.Context, msg string) {
+	if logger.Disabled() {
+		return
+	}
+	logger.writer(2, LoggerLevelCritical, msg, logger.messageContextFields(ctx))
+}
+
+// log critical format with context fields
+func (logger *Logger) CriticalCtxf(ctx context.Context, format string, a ...interface{}) {
+	if logger.Disabled() {
+		return
+	}
+	msg := fmt.Sprintf(format, a...)
+	logger.writer(2, LoggerLevelCritical, msg, logger.messageContextFields(ctx))
+}
+
+// log critical level with entry fields
+func (entry *Entry) Critical(msg string) {
+	if entry.logger.Disabled() {
+		return
+	}
+	entry.writer(2, LoggerLevelCritical, msg, entry.messageFields())
+}
+
+// log critical format with entry fields
+func (entry *Entry) Criticalf(format string, a ...interface{}) {
+	if entry.logger.Disabled() {
+		return
+	}
+	msg := fmt.Sprintf(format, a...)
+	entry.writer(2, LoggerLevelCritical, msg, entry.messageFields())
+}
+
+// log critical key-values with entry fields
+func (entry *Entry) Criticalw(msg string, keysAndValues ...interface{}) {
+	if entry.logger.Disabled() {
+		return
+	}
+	entry.writerw(LoggerLevelCritical, msg, keysAndValues)
+}
+
+// log critical level by the default logger
+func Critical(msg string) {
+	if Default().Disabled() {
+		return
+	}
+	Default().writer(2, LoggerLevelCritical, msg, nil)
+}
+
+// log critical format by the default logger
+func Criticalf(format string, a ...interface{}) {
+	if Default().Disabled() {
+		return
+	}
+	msg := fmt.Sprintf(format, a...)
+	Default().writer(2, LoggerLevelCritical, msg, nil)
+}
+
+// log critical key-values by the default logger
+func Criticalw(msg string, keysAndValues ...interface{}) {
+	if Default().Disabled() {
+		return
+	}
+	Default().writerw(LoggerLevelCritical, msg, keysAndValues)
+}
+
+// log error level
+func (logger *Logger) Error(msg string) {
+	if logger.Disabled() {
+		return
+	}
+	logger.writer(2, LoggerLevelError, msg, nil)
+}
+
+// log error format
+func (logger *Logger) Errorf(format string, a ...interface{}) {
+	if logger.Disabled() {
+		return
+	}
+	msg := fmt.Sprintf(format, a...)
+	logger.writer(2, LoggerLevelError, msg, nil)
+}
+
+// log error key-values
+func (logger *Logger) Errorw(msg string, keysAndValues ...interface{}) {
+	if logger.Disabled() {
+		return
+	}
+	logger.writerw(LoggerLevelError, msg, keysAndValues)
+}
+
+// log error level with context fields
+func (logger *Logger) ErrorCtx(ctx context.Context, msg string) {
+	if logger.Disabled() {
+		return
+	}
+	logger.writer(2, LoggerLevelError, msg, logger.messageContextFields(ctx))
+}
+
+// log error format with context fields
+func (logger *Logger) ErrorCtxf(ctx context.Context, format string, a ...interface{}) {
+	if logger.Disabled() {
+		return
+	}
+	msg := fmt.Sprintf(format, a...)
+	logger.writer(2, LoggerLevelError, msg, logger.messageContextFields(ctx))
+}
+
+// log error level with entry fields
+func (entry *Entry) Error(msg string) {
+	if entry.logger.Disabled() {
+		return
+	}
+	entry.writer(2, LoggerLevelError, msg, entry.messageFields())
+}
+
+// log error format with entry fields
+func (entry *Entry) Errorf(format string, a ...interface{}) {
+	if entry.logger.Disabled() {
+		return
+	}
+	msg := fmt.Sprintf(format, a...)
+	entry.writer(2, LoggerLevelError, msg, entry.messageFields())
+}
+
+// log error key-values with entry fields
+func (entry *Entry) Errorw(msg string, keysAndValues ...interface{}) {
+	if entry.logger.Disabled() {
+		return
+	}
+	entry.writerw(LoggerLevelError, msg, keysAndValues)
+}
+
+// log error level by the default logger
+func Error(msg string) {
+	if Default().Disabled() {
+		return
+	}
+	Default().writer(2, LoggerLevelError, msg, nil)
+}
+
+// log error format by the default logger
+func Errorf(format string, a ...interface{}) {
+	if Default().Disabled() {
+		return
+	}
+	msg := fmt.Sprintf(format, a...)
+	Default().writer(2, LoggerLevelError, msg, nil)
+}
+
+// log error key-values by the default logger
+func Errorw(msg string, keysAndValues ...interface{}) {
+	if Default().Disabled() {
+		return
+	}
+	Default().writerw(LoggerLevelError, msg, keysAndValues)
+}
+
+// log warning level
+func (logger *Logger) Warning(msg string) {
+	if logger.Disabled() {
+		return
+	}
+	logger.writer(2, LoggerLevelWarning, msg, nil)
+}
+
+// log warning format
+func (logger *Logger) Warningf(format string, a ...interface{}) {
+	if logger.Disabled() {
+		return
+	}
+	msg := fmt.Sprintf(format, a...)
+	logger.writer(2, LoggerLevelWarning, msg, nil)
+}
+
+// log warning key-values
+func (logger *Logger) Warningw(msg string, keysAndValues ...interface{}) {
+	if logger.Disabled() {
+		return
+	}
+	logger.writerw(LoggerLevelWarning, msg, keysAndValues)
+}
+
+// log warning level with context fields
+func (logger *Logger) WarningCtx(ctx context.Context, msg string) {
+	if logger.Disabled() {
+		return
+	}
+	logger.writer(2, LoggerLevelWarning, msg, logger.messageContextFields(ctx))
+}
+
+// log warning format with context fields
+func (logger *Logger) WarningCtxf(ctx context.Context, format string, a ...interface{}) {
+	if logger.Disabled() {
+		return
+	}
+	msg := fmt.Sprintf(format, a...)
+	logger.writer(2, LoggerLevelWarning, msg, logger.messageContextFields(ctx))
+}
+
+// log warning level with entry fields
+func (entry *Entry) Warning(msg string) {
+	if entry.logger.Disabled() {
+		return
+	}
+	entry.writer(2, LoggerLevelWarning, msg, entry.messageFields())
+}
+
+// log warning format with entry fields
+func (entry *Entry) Warningf(format string, a ...interface{}) {
+	if entry.logger.Disabled() {
+		return
+	}
+	msg := fmt.Sprintf(format, a...)
+	entry.writer(2, LoggerLevelWarning, msg, entry.messageFields())
+}
+
+// log warning key-values with entry fields
+func (entry *Entry) Warningw(msg string, keysAndValues ...interface{}) {
+	if entry.logger.Disabled() {
+		return
+	}
+	entry.writerw(LoggerLevelWarning, msg, keysAndValues)
+}
+
+// log warning level by the default logger
+func Warning(msg string) {
+	if Default().Disabled() {
+		return
+	}
+	Default().writer(2, LoggerLevelWarning, msg, nil)
+}
+
+// log warning format by the default logger
+func Warningf(format string, a ...interface{}) {
+	if Default().Disabled() {
+		return
+	}
+	msg := fmt.Sprintf(format, a...)
+	Default().writer(2, LoggerLevelWarning, msg, nil)
+}
+
+// log warning key-values by the default logger
+func Warningw(msg string, keysAndValues ...interface{}) {
+	if Default().Disabled() {
+		return
+	}
+	Default().writerw(LoggerLevelWarning, msg, keysAndValues)
+}
+
+// log notice level
+func (logger *Logger) Notice(msg string) {
+	if logger.Disabled() {
+		return
+	}
+	logger.writer(2, LoggerLevelNotice, msg, nil)
+}
+
+// log notice format
+func (logger *Logger) Noticef(format string, a ...interface{}) {
+	if logger.Disabled() {
+		return
+	}
+	msg := fmt.Sprintf(format, a...)
+	logger.writer(2, LoggerLevelNotice, msg, nil)
+}
+
+// log notice key-values
+func (logger *Logger) Noticew(msg string, keysAndValues ...interface{}) {
+	if logger.Disabled() {
+		return
+	}
+	logger.writerw(LoggerLevelNotice, msg, keysAndValues)
+}
+
+// log notice level with context fields
+func (logger *Logger) NoticeCtx(ctx context.Context, msg string) {
+	if logger.Disabled() {
+		return
+	}
+	logger.writer(2, LoggerLevelNotice, msg, logger.messageContextFields(ctx))
+}
+
+// log notice format with context fields
+func (logger *Logger) NoticeCtxf(ctx context.Context, format string, a ...interface{}) {
+	if logger.Disabled() {
+		return
+	}
+	msg := fmt.Sprintf(format, a...)
+	logger.writer(2, LoggerLevelNotice, msg, logger.messageContextFields(ctx))
+}
+
+// log notice level with entry fields
+func (entry *Entry) Notice(msg string) {
+	if entry.logger.Disabled() {
+		return
+	}
+	entry.writer(2, LoggerLevelNotice, msg, entry.messageFields())
+}
+
+// log notice format with entry fields
+func (entry *Entry) Noticef(format string, a ...interface{}) {
+	if entry.logger.Disabled() {
+		return
+	}
+	msg := fmt.Sprintf(format, a...)
+	entry.writer(2, LoggerLevelNotice, msg, entry.messageFields())
+}
+
+// log notice key-values with entry fields
+func (entry *Entry) Noticew(msg string, keysAndValues ...interface{}) {
+	if entry.logger.Disabled() {
+		return
+	}
+	entry.writerw(LoggerLevelNotice, msg, keysAndValues)
+}
+
+// log notice level by the default logger
+func Notice(msg string) {
+	if Default().Disabled() {
+		return
+	}
+	Default().writer(2, LoggerLevelNotice, msg, nil)
+}
+
+// log notice format by the default logger
+func Noticef(format string, a ...interface{}) {
+	if Default().Disabled() {
+		return
+	}
+	msg := fmt.Sprintf(format, a...)
+	Default().writer(2, LoggerLevelNotice, msg, nil)
+}
+
+// log notice key-values by the default logger
+func Noticew(msg string, keysAndValues ...interface{}) {
+	if Default().Disabled() {
+		return
+	}
+	Default().writerw(LoggerLevelNotice, msg, keysAndValues)
+}
+
+// log info level
+func (logger *Logger) Info(msg string) {
+	if logger.Disabled() {
+		return
+	}
+	logger.writer(2, LoggerLevelInfo, msg, nil)
+}
+
+// log info format
+func (logger *Logger) Infof(format string, a ...interface{}) {
+	if logger.Disabled() {
+		return
+	}
+	msg := fmt.Sprintf(format, a...)
+	logger.writer(2, LoggerLevelInfo, msg, nil)
+}
+
+// log info key-values
+func (logger *Logger) Infow(msg string, keysAndValues ...interface{}) {
+	if logger.Disabled() {
+		return
+	}
+	logger.writerw(LoggerLevelInfo, msg, keysAndValues)
+}
+
+// log info level with context fields
+func (logger *Logger) InfoCtx(ctx context.Context, msg string) {
+	if logger.Disabled() {
+		return
+	}
+	logger.writer(2, LoggerLevelInfo, msg, logger.messageContextFields(ctx))
+}
+
+// log info format with context fields
+func (logger *Logger) InfoCtxf(ctx context.Context, format string, a ...interface{}) {
+	if logger.Disabled() {
+		return
+	}
+	msg := fmt.Sprintf(format, a...)
+	logger.writer(2, LoggerLevelInfo, msg, logger.messageContextFields(ctx))
+}
+
+// log info level with entry fields
+func (entry *Entry) Info(msg string) {
+	if entry.logger.Disabled() {
+		return
+	}
+	entry.writer(2, LoggerLevelInfo, msg, entry.messageFields())
+}
+
+// log info format with entry fields
+func (entry *Entry) Infof(format string, a ...interface{}) {
+	if entry.logger.Disabled() {
+		return
+	}
+	msg := fmt.Sprintf(format, a...)
+	entry.writer(2, LoggerLevelInfo, msg, entry.messageFields())
+}
+
+// log info key-values with entry fields
+func (entry *Entry) Infow(msg string, keysAndValues ...interface{}) {
+	if entry.logger.Disabled() {
+		return
+	}
+	entry.writerw(LoggerLevelInfo, msg, keysAndValues)
+}
+
+// log info level by the default logger
+func Info(msg string) {
+	if Default().Disabled() {
+		return
+	}
+	Default().writer(2, LoggerLevelInfo, msg, nil)
+}
+
+// log info format by the default logger
+func Infof(format string, a ...interface{}) {
+	if Default().Disabled() {
+		return
+	}
+	msg := fmt.Sprintf(format, a...)
+	Default().writer(2, LoggerLevelInfo, msg, nil)
+}
+
+// log info key-values by the default logger
+func Infow(msg string, keysAndValues ...interface{}) {
+	if Default().Disabled() {
+		return
+	}
+	Default().writerw(LoggerLevelInfo, msg, keysAndValues)
+}
+
+// log debug level
+func (logger *Logger) Debug(msg string) {
+	if logger.Disabled() {
+		return
+	}
+	logger.writer(2, LoggerLevelDebug, msg, nil)
+}
+
+// log debug format
+func (logger *Logger) Debugf(format string, a ...interface{}) {
+	if logger.Disabled() {
+		return
+	}
+	msg := fmt.Sprintf(format, a...)
+	logger.writer(2, LoggerLevelDebug, msg, nil)
+}
+
+// log debug key-values
+func (logger *Logger) Debugw(msg string, keysAndValues ...interface{}) {
+	if logger.Disabled() {
+		return
+	}
+	logger.writerw(LoggerLevelDebug, msg, keysAndValues)
+}
+
+// log debug level with context fields
+func (logger *Logger) DebugCtx(ctx context.Context, msg string) {
+	if logger.Disabled() {
+		return
+	}
+	logger.writer(2, LoggerLevelDebug, msg, logger.messageContextFields(ctx))
+}
+
+// log debug format with context fields
+func (logger *Logger) DebugCtxf(ctx context.Context, format string, a ...interface{}) {
+	if logger.Disabled() {
+		return
+	}
+	msg := fmt.Sprintf(format, a...)
+	logger.writer(2, LoggerLevelDebug, msg, logger.messageContextFields(ctx))
+}
+
+// log debug level with entry fields
+func (entry *Entry) Debug(msg string) {
+	if entry.logger.Disabled() {
+		return
+	}
+	entry.writer(2, LoggerLevelDebug, msg, entry.messageFields())
+}
+
+// log debug format with entry fields
+func (entry *Entry) Debugf(format string, a ...interface{}) {
+	if entry.logger.Disabled() {
+		return
+	}
+	msg := fmt.Sprintf(format, a...)
+	entry.writer(2, LoggerLevelDebug, msg, entry.messageFields())
+}
+
+// log debug key-values with entry fields
+func (entry *Entry) Debugw(msg string, keysAndValues ...interface{}) {
+	if entry.logger.Disabled() {
+		return
+	}
+	entry.writerw(LoggerLevelDebug, msg, keysAndValues)
+}
+
+// log debug level by the default logger
+func Debug(msg string) {
+	if Default().Disabled() {
+		return
+	}
+	Default().writer(2, LoggerLevelDebug, msg, nil)
+}
+
+// log debug format by the default logger
+func Debugf(format string, a ...interface{}) {
+	if Default().Disabled() {
+		return
+	}
+	msg := fmt.Sprintf(format, a...)
+	Default().writer(2, LoggerLevelDebug, msg, nil)
+}
+
+// log debug key-values by the default logger
+func Debugw(msg string, keysAndValues ...interface{}) {
+	if Default().Disabled() {
+		return
+	}
+	Default().writerw(LoggerLevelDebug, msg, keysAndValues)
+}
@@ -0,0 +1,24 @@
+package go_logger
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLevelMethods(t *testing.T) {
+
+	loggerType := reflect.TypeOf(&Logger{})
+	entryType := reflect.TypeOf(&Entry{})
+	for _, levelString := range levelStringMapping {
+		for _, suffix := range []string{"", "f", "w", "Ctx", "Ctxf"} {
+			if _, ok := loggerType.MethodByName(levelString + suffix); !ok {
+				t.Errorf("Logger level method %s%s is not generated", levelString, suffix)
+			}
+		}
+		for _, suffix := range []string{"", "f", "w"} {
+			if _, ok := entryType.MethodByName(levelString + suffix); !ok {
+				t.Errorf("Entry level method %s%s is not generated", levelString, suffix)
+			}
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package go_logger
+
+import (
+	"sync/atomic"
+	"testing"
+)
+
+// level change listener adapter
+type adapterLevelListener struct {
+	adapterCount
+	changes [][2]int
+}
+
+func (adapter *adapterLevelListener) OnLevelChange(oldLevel int, newLevel int) {
+	adapter.changes = append(adapter.changes, [2]int{oldLevel, newLevel})
+}
+
+func init() {
+	Register("level_listener", func() LoggerAbstract {
+		return &adapterLevelListener{}
+	})
+}
+
+func TestLogger_SetLevel(t *testing.T) {
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("level_listener", LoggerLevelDebug, &countConfig{})
+	listener := logger.outputList()[0].LoggerAbstract.(*adapterLevelListener)
+
+	if logger.SetLevel("level_listener", 100) == nil {
+		t.Error("illegal level must be error")
+	}
+	if logger.SetLevel("file", LoggerLevelError) == nil {
+		t.Error("not attached adapter must be error")
+	}
+
+	logger.SetLevel("level_listener", LoggerLevelError)
+	logger.SetLevel("level_listener", LoggerLevelError)
+	logger.Info("logger set level info")
+	logger.Error("logger set level error")
+
+	if atomic.LoadInt64(&listener.writes) != 1 {
+		t.Error("output must write by the new level")
+	}
+	if len(listener.changes) != 1 || listener.changes[0] != [2]int{LoggerLevelDebug, LoggerLevelError} {
+		t.Errorf("level change listener must be notified once, got %v", listener.changes)
+	}
+}
@@ -0,0 +1,107 @@
+//go:build android && cgo
+// +build android,cgo
+
+package go_logger
+
+/*
+#cgo LDFLAGS: -llog
+#include <stdlib.h>
+#include <android/log.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"unsafe"
+)
+
+const LOGCAT_ADAPTER_NAME = "logcat"
+
+// android log priority of levels
+var levelLogcatPriorities = map[int]C.int{
+	LoggerLevelEmergency: C.ANDROID_LOG_FATAL,
+	LoggerLevelAlert:     C.ANDROID_LOG_FATAL,
+	LoggerLevelCritical:  C.ANDROID_LOG_ERROR,
+	LoggerLevelError:     C.ANDROID_LOG_ERROR,
+	LoggerLevelWarning:   C.ANDROID_LOG_WARN,
+	LoggerLevelNotice:    C.ANDROID_LOG_INFO,
+	LoggerLevelInfo:      C.ANDROID_LOG_INFO,
+	LoggerLevelDebug:     C.ANDROID_LOG_DEBUG,
+}
+
+// adapter logcat, write to android logcat by __android_log_write
+type AdapterLogcat struct {
+	tag    *C.char
+	config *LogcatConfig
+}
+
+// logcat config
+type LogcatConfig struct {
+
+	// logcat tag, if empty, default "GoLog"
+	Tag string
+
+	// please input format string
+	// if format is empty, default format "%body%", logcat shows time and priority itself
+	Format string
+}
+
+func (lc *LogcatConfig) Name() string {
+	return LOGCAT_ADAPTER_NAME
+}
+
+func NewAdapterLogcat() LoggerAbstract {
+	return &AdapterLogcat{
+		config: &LogcatConfig{},
+	}
+}
+
+func (adapterLogcat *AdapterLogcat) Init(logcatConfig Config) error {
+	if logcatConfig.Name() != LOGCAT_ADAPTER_NAME {
+		return errors.New("logger logcat adapter init error, config must LogcatConfig")
+	}
+
+	lc := logcatConfig.(*LogcatConfig)
+	adapterLogcat.config = lc
+
+	if lc.Tag == "" {
+		lc.Tag = "GoLog"
+	}
+	if lc.Format == "" {
+		lc.Format = "%body%"
+	}
+	// the tag lives as long as the adapter
+	adapterLogcat.tag = C.CString(lc.Tag)
+	return nil
+}
+
+func (adapterLogcat *AdapterLogcat) Write(loggerMsg *loggerMessage) error {
+	priority, ok := levelLogcatPriorities[loggerMsg.Level]
+	if !ok {
+		priority = C.ANDROID_LOG_DEFAULT
+	}
+	text := C.CString(loggerMessageFormat(adapterLogcat.config.Format, loggerMsg))
+	defer C.free(unsafe.Pointer(text))
+
+	if C.__android_log_write(priority, adapterLogcat.tag, text) < 0 {
+		return errors.New("logger logcat adapter write error")
+	}
+	return nil
+}
+
+func (adapterLogcat *AdapterLogcat) Flush() {
+
+}
+
+func (adapterLogcat *AdapterLogcat) Name() string {
+	return LOGCAT_ADAPTER_NAME
+}
+
+//new empty config of the adapter, describes the config fields
+func (adapterLogcat *AdapterLogcat) NewConfig() Config {
+	return &LogcatConfig{}
+}
+
+func init() {
+	Register(LOGCAT_ADAPTER_NAME, NewAdapterLogcat)
+}
@@ -1,18 +1,22 @@
 package go_logger
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path"
-	"runtime"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 var Version = "v1.2"
 
+// level methods of Logger, Entry and the default logger are generated from the levels of gen_levels.go
+//go:generate go run gen_levels.go
+
 const (
 	LoggerLevelEmergency = iota
 	LoggerLevelAlert
@@ -61,43 +65,141 @@ func Register(adapterName string, newLog adapterLoggerFunc) {
 }
 
 type Logger struct {
+	queueTTL int64 // max age of queued messages in nanoseconds, 0 is unlimited, first for atomic alignment
+
 	lock        sync.Mutex          //sync lock
-	outputs     []*outputLogger     // outputs loggers
+	outputs     atomic.Value        // outputs loggers, []*outputLogger replaced as a whole under lock
 	msgChan     chan *loggerMessage // message channel
+	urgentChan  chan *loggerMessage // Emergency, Alert and Critical message channel
 	synchronous bool                // is sync
-	wait        sync.WaitGroup      // process wait
-	signalChan  chan string
-}
-
+	asyncLock   sync.RWMutex        // guards synchronous and async channels switching
+	stopChan    chan *flushRequest  // async worker stop requests, done is closed when stopped
+	flushChan   chan *flushRequest  // async flush requests, done is closed when flushed
+	closed      int32               // closed by Close, atomic
+	disabled    int32               // disabled by Disable, atomic
+	strictMode  int32               // strict mode, LoggerStrictOff | LoggerStrictError | LoggerStrictPanic, atomic
+
+	levelDisplayStrings atomic.Value    // map[int]string level display strings for text output, replaced as a whole under lock
+	messageCatalog      atomic.Value    // map[string]string message key catalog, replaced as a whole under lock
+	routeRules          atomic.Value    // []*routeRule content-based route rules, replaced as a whole under lock
+	rewriters           atomic.Value    // []Rewriter message rewriters, replaced as a whole under lock
+	sanitizer           atomic.Value    // *Sanitizer body and fields sanitizer, nil is none, replaced as a whole under lock
+	binaryEncoding      atomic.Value    // *binaryEncoder of []byte fields, replaced as a whole under lock
+	blobStore           atomic.Value    // *blobStore of large fields, nil is none, replaced as a whole under lock
+	errorSampler        atomic.Value    // *errorSampler identical errors sampler, nil is none, replaced as a whole under lock
+	quota               atomic.Value    // *quota records and bytes quota per field value, nil is unlimited, replaced as a whole under lock
+	stats               *loggerStats    // pipeline stats
+	deadLetterAdapter   atomic.Value    // string stale messages output, "" drops them
+	recent              atomic.Value    // *recentBuffer most recent messages, nil is none, replaced as a whole under lock
+	crash               *crashHandler   // crash file of the recent messages, nil is none
+	runtimeMetrics      *runtimeMetrics // runtime metrics task, nil is stopped
+	fatalPolicy         FatalPolicy     // Fatal-level logging policy
+	fatalHooks          []func()        // fatal policy cleanup hooks
+	enqueueTimeout      time.Duration   // async enqueue timeout, 0 blocks until queued
+	overflowPolicy      int             // enqueue timeout message policy
+	strictOrdering      bool            // async messages written in submission order, changed under lock and asyncLock
+	ordering            *orderBuffer    // reordering buffer of strict ordering
+
+	contextDeadlineFields int32        // record entry context deadline and cancellation fields, atomic
+	contextKeys           atomic.Value // []contextKeyField extracted from entry context, replaced as a whole under lock
+	errorFingerprint      atomic.Value // bool add fingerprint field to Error+ messages
+	startup               atomic.Value // *startupBuffer of records before Ready, nil is not buffered
+	namedLevels           atomic.Value // map[string]int levels of named children, replaced as a whole under lock
+	tags                  atomic.Value // map[string]string static tags of every output, replaced as a whole under lock
+	flushAckTimeout       atomic.Value // time.Duration of flushing with acknowledgements, 0 is not acknowledged
+	dropHooks             atomic.Value // []DropHook of dropped records, replaced as a whole under lock
+}
+
+// attached output, settings are not changed after it is stored in outputs,
+// setters store a changed copy, see updateOutput
 type outputLogger struct {
-	Name  string
-	Level int
+	*outputState // counters, level and silence shared by the copies of the output
+
+	Name string
 	LoggerAbstract
+	config Config // adapter config
+
+	schedule *cronSchedule // active schedule, nil is always active
+
+	normalizer *FieldNormalizer // fields normalizer, nil is not normalized
+	retries    int              // write retries on error
+	clockSkew  *clockSkew       // clock skew compensation, nil is not compensated
+	intern     *internTable     // recent records for repeat encoding, nil is not interned
+
+	cardinality *cardinalityGuard // distinct field values guard, nil is not guarded
+	recordSizes *recordSizes      // encoded record size metrics, nil is not tracked
+	tags        map[string]string // static tags merged into fields, nil is none
+	offload     *outputOffload    // encoding worker pool, nil is written on the dispatch goroutine
+}
+
+// state of an output shared by its copies
+type outputState struct {
+	silenceUntil int64 // silence until unix nano, first for atomic alignment
+	errors       int64 // write errors count
+	delivered    int64 // delivered messages count since last report
+	retried      int64 // delivered after retry messages count since last report
+	dropped      int64 // failed after all retries messages count since last report
+	level        int64 // output level, changed by SetLevel
+
+	silenceTimer *time.Timer // silence auto re-enable timer, changed under lock
 }
 
 type loggerMessage struct {
-	Timestamp         int64  `json:"timestamp"`
-	TimestampFormat   string `json:"timestamp_format"`
-	Millisecond       int64  `json:"millisecond"`
-	MillisecondFormat string `json:"millisecond_format"`
-	Level             int    `json:"level"`
-	LevelString       string `json:"level_string"`
-	Body              string `json:"body"`
-	File              string `json:"file"`
-	Line              int    `json:"line"`
-	Function          string `json:"function"`
+	Timestamp         int64                  `json:"timestamp"`
+	TimestampFormat   string                 `json:"timestamp_format"`
+	Millisecond       int64                  `json:"millisecond"`
+	MillisecondFormat string                 `json:"millisecond_format"`
+	Level             int                    `json:"level"`
+	LevelString       string                 `json:"level_string"`
+	Body              string                 `json:"body"`
+	File              string                 `json:"file"`
+	Line              int                    `json:"line"`
+	Function          string                 `json:"function"`
+	Name              string                 `json:"name,omitempty"`
+	Fields            map[string]interface{} `json:"fields,omitempty"`
+	SchemaVersion     int                    `json:"schema_version"`
+
+	// level display string for text output, LevelString if empty
+	LevelDisplayString string `json:"-"`
+
+	routeAdapters map[string]bool // routed adapter names, nil is all adapters
+	relayHops     int             // relayed times between loggers
+	filePath      string          // caller file full path
+	errorType     string          // type of the first error field, "" if none
+	formats       *formatCache    // formatted text shared by outputs, set by dispatch
+	sequence      uint64          // submission sequence of strict ordering, 0 is unordered
+	stack         []uintptr       // call stack of Error+ messages if a stack output is attached, nil if none
 }
 
 //new logger
 //return logger
 func NewLogger() *Logger {
 	logger := &Logger{
-		outputs:     []*outputLogger{},
 		msgChan:     make(chan *loggerMessage, 10),
+		urgentChan:  make(chan *loggerMessage, 10),
 		synchronous: true,
-		wait:        sync.WaitGroup{},
-		signalChan:  make(chan string, 1),
+		flushChan:   make(chan *flushRequest),
+		stats:       newLoggerStats(),
+		fatalPolicy: DefaultFatalPolicy,
+		ordering:    newOrderBuffer(),
 	}
+	logger.outputs.Store([]*outputLogger{})
+	logger.contextKeys.Store(defaultContextKeys)
+	logger.namedLevels.Store(map[string]int{})
+	logger.levelDisplayStrings.Store(map[int]string{})
+	logger.messageCatalog.Store(map[string]string{})
+	logger.routeRules.Store([]*routeRule{})
+	logger.rewriters.Store([]Rewriter{})
+	logger.sanitizer.Store((*Sanitizer)(nil))
+	logger.binaryEncoding.Store(&binaryEncoder{encoding: BINARY_ENCODING_BASE64})
+	logger.blobStore.Store((*blobStore)(nil))
+	logger.errorSampler.Store((*errorSampler)(nil))
+	logger.deadLetterAdapter.Store("")
+	logger.errorFingerprint.Store(false)
+	logger.quota.Store((*quota)(nil))
+	logger.recent.Store((*recentBuffer)(nil))
+	logger.tags.Store(map[string]string(nil))
+	logger.flushAckTimeout.Store(time.Duration(0))
 	//default adapter console
 	logger.attach("console", LoggerLevelDebug, &ConsoleConfig{})
 
@@ -118,7 +220,14 @@ func (logger *Logger) Attach(adapterName string, level int, config Config) error
 //param : adapterName console | file | database | ...
 //return : error
 func (logger *Logger) attach(adapterName string, level int, config Config) error {
-	for _, output := range logger.outputs {
+	return logger.attachTagged(adapterName, level, config, nil)
+}
+
+//attach a logger adapter with static tags after lock
+//param : adapterName string, level int, config Config, tags map[string]string, nil is none
+//return : error
+func (logger *Logger) attachTagged(adapterName string, level int, config Config, tags map[string]string) error {
+	for _, output := range logger.outputList() {
 		if output.Name == adapterName {
 			printError("logger: adapter " + adapterName + "already attached!")
 		}
@@ -127,22 +236,57 @@ func (logger *Logger) attach(adapterName string, level int, config Config) error
 	if !ok {
 		printError("logger: adapter " + adapterName + "is nil!")
 	}
+	if err := checkConfigFormat(config); err != nil {
+		if err = logger.misuse(err); err != nil {
+			return err
+		}
+	}
 	adapterLog := logFun()
 	err := adapterLog.Init(config)
 	if err != nil {
 		printError("logger: adapter " + adapterName + " init failed, error: " + err.Error())
 	}
 
-	output := &outputLogger{
+	logger.addOutput(&outputLogger{
+		outputState:    &outputState{level: int64(level)},
 		Name:           adapterName,
-		Level:          level,
 		LoggerAbstract: adapterLog,
-	}
-
-	logger.outputs = append(logger.outputs, output)
+		config:         config,
+		tags:           tags,
+	})
 	return nil
 }
 
+//add an initialized output after lock
+func (logger *Logger) addOutput(output *outputLogger) {
+	// copy on write, writers may be reading the current outputs
+	current := logger.outputList()
+	outputs := make([]*outputLogger, 0, len(current)+1)
+	outputs = append(outputs, current...)
+	logger.outputs.Store(append(outputs, output))
+}
+
+//replace an attached output by a changed copy after lock, writers may be reading the current output
+//params : adapterName string, change func(output *outputLogger) error called with the copy
+//return : error of change, or the adapter is not attached
+func (logger *Logger) updateOutput(adapterName string, change func(output *outputLogger) error) error {
+	current := logger.outputList()
+	for i, output := range current {
+		if output.Name != adapterName {
+			continue
+		}
+		updated := *output
+		if err := change(&updated); err != nil {
+			return err
+		}
+		outputs := append([]*outputLogger{}, current...)
+		outputs[i] = &updated
+		logger.outputs.Store(outputs)
+		return nil
+	}
+	return errors.New("logger: adapter " + adapterName + " is not attached!")
+}
+
 //start attach a logger adapter
 //param : adapterName console | file | database | ...
 //return : error
@@ -158,66 +302,125 @@ func (logger *Logger) Detach(adapterName string) error {
 //return : error
 func (logger *Logger) detach(adapterName string) error {
 	outputs := []*outputLogger{}
-	for _, output := range logger.outputs {
+	for _, output := range logger.outputList() {
 		if output.Name == adapterName {
+			if lazy, ok := output.LoggerAbstract.(*lazyAdapter); ok {
+				lazy.stop()
+			}
+			if offload := output.offload; offload != nil {
+				offload.stop()
+			}
 			continue
 		}
 		outputs = append(outputs, output)
 	}
-	logger.outputs = outputs
+	logger.outputs.Store(outputs)
 	return nil
 }
 
-//set logger level
-//params : level int
-//func (logger *Logger) SetLevel(level int) {
-//	logger.level = level
-//}
-
 //set logger synchronous false
-//params : sync bool
+//calling again with another channel length drains and restarts the async worker
+//a closed logger stays synchronous
+//params : data ...int, message channel length, default 100
 func (logger *Logger) SetAsync(data ...int) {
 	logger.lock.Lock()
 	defer logger.lock.Unlock()
-	logger.synchronous = false
+	logger.asyncLock.Lock()
+	defer logger.asyncLock.Unlock()
 
-	msgChanLen := 100
+	if atomic.LoadInt32(&logger.closed) == 1 {
+		return
+	}
+
+	msgChanLen := loggerAsyncChanLen
 	if len(data) > 0 {
 		msgChanLen = data[0]
 	}
+	if !logger.synchronous {
+		if len(data) == 0 || cap(logger.msgChan) == msgChanLen {
+			return
+		}
+		logger.stopAsyncWrite()
+	}
+	logger.synchronous = false
 
 	logger.msgChan = make(chan *loggerMessage, msgChanLen)
-	logger.signalChan = make(chan string, 1)
+	logger.urgentChan = make(chan *loggerMessage, msgChanLen)
+	logger.flushChan = make(chan *flushRequest)
+	logger.stopChan = make(chan *flushRequest)
 
-	if !logger.synchronous {
-		go func() {
-			defer func() {
-				e := recover()
-				if e != nil {
-					fmt.Printf("%v", e)
-				}
+	go func() {
+		// restart on adapter panic, a dead worker blocks writers and Flush
+		for {
+			stopped := func() bool {
+				defer func() {
+					e := recover()
+					if e != nil {
+						fmt.Printf("%v", e)
+					}
+				}()
+				logger.startAsyncWrite()
+				return true
 			}()
-			logger.startAsyncWrite()
-		}()
+			if stopped {
+				return
+			}
+		}
+	}()
+}
+
+//set logger synchronous true, queued messages are written and outputs flushed
+func (logger *Logger) SetSync() {
+	logger.lock.Lock()
+	defer logger.lock.Unlock()
+	logger.asyncLock.Lock()
+	defer logger.asyncLock.Unlock()
+
+	if logger.synchronous {
+		return
 	}
+	logger.stopAsyncWrite()
+	logger.synchronous = true
+}
+
+//stop async worker after it writes queued messages, must hold asyncLock
+//return : flush error
+func (logger *Logger) stopAsyncWrite() error {
+	request := &flushRequest{done: make(chan struct{})}
+	logger.stopChan <- request
+	<-request.done
+	return request.err
 }
 
 //write log message
 //params : level int, msg string
 //return : error
 func (logger *Logger) Writer(level int, msg string) error {
-	funcName := "null"
-	pc, file, line, ok := runtime.Caller(3)
-	if !ok {
-		file = "null"
-		line = 0
-	} else {
-		funcName = runtime.FuncForPC(pc).Name()
+	return logger.writer(2, level, msg, nil)
+}
+
+//write log message with caller depth and fields
+//params : callDepth int, level int, msg string, fields map[string]interface{}
+//return : error
+func (logger *Logger) writer(callDepth int, level int, msg string, fields map[string]interface{}) error {
+	return logger.writerNamed(callDepth+1, "", "", level, msg, fields)
+}
+
+//write log message of a named child with caller depth and fields
+//params : callDepth int, name string, errorType string of the first error field, level int, msg string, fields map[string]interface{}
+//return : error
+func (logger *Logger) writerNamed(callDepth int, name string, errorType string, level int, msg string, fields map[string]interface{}) error {
+	if logger.Disabled() {
+		return nil
 	}
-	_, filename := path.Split(file)
+	filePath, line, funcName := loggerCaller(callDepth)
+	_, filename := path.Split(filePath)
 
-	if levelStringMapping[level] == "" {
-		printError("logger: level " + strconv.Itoa(level) + " is illegal!")
+	var misuseErr error
+	levelString, ok := levelStringMapping[level]
+	if !ok {
+		levelString = "Unknown"
+		misuseErr = errors.New("logger: level " + strconv.Itoa(level) + " is illegal!")
 	}
 
 	loggerMsg := &loggerMessage{
@@ -226,78 +429,275 @@ func (logger *Logger) Writer(level int, msg string) error {
 		Millisecond:       time.Now().UnixNano() / 1e6,
 		MillisecondFormat: time.Now().Format("2006-01-02 15:04:05.999"),
 		Level:             level,
-		LevelString:       levelStringMapping[level],
-		Body:              msg,
+		LevelString:       levelString,
+		Body:              truncateString(msg, loggerBodyMaxBytes, "..."),
 		File:              filename,
 		Line:              line,
 		Function:          funcName,
+		Name:              name,
+		filePath:          filePath,
+		errorType:         errorType,
+		Fields:            fields,
+		SchemaVersion:     LoggerMessageSchemaVersion,
 	}
-
-	if !logger.synchronous {
-		logger.wait.Add(1)
-		logger.msgChan <- loggerMsg
+	if level <= LoggerLevelError && logger.hasStackOutput() {
+		loggerMsg.stack = loggerStack(callDepth)
+	}
+	logger.translate(loggerMsg)
+	logger.rewrite(loggerMsg)
+	logger.fingerprint(loggerMsg)
+	logger.externalize(loggerMsg)
+	logger.encodeBinary(loggerMsg)
+	logger.sanitize(loggerMsg)
+	logger.stats.count(level)
+	recent, _ := logger.recent.Load().(*recentBuffer)
+	recent.add(loggerMsg)
+	if !logger.route(loggerMsg) {
+		logger.drop(loggerMsg, DROP_REASON_ROUTE)
+	} else if !logger.sample(loggerMsg) {
+		logger.drop(loggerMsg, DROP_REASON_SAMPLED)
+	} else if !logger.checkQuota(loggerMsg) {
+		logger.drop(loggerMsg, DROP_REASON_QUOTA)
 	} else {
-		logger.writeToOutputs(loggerMsg)
+		logger.dispatch(loggerMsg)
 	}
 
+	if misuseErr != nil {
+		return logger.misuse(misuseErr)
+	}
 	return nil
 }
 
+//write log message with key-value pairs as fields
+//params : level int, msg string, keysAndValues []interface{}
+//return : error
+func (logger *Logger) writerw(level int, msg string, keysAndValues []interface{}) error {
+	fields, misuseErr := keyValuesToFields(keysAndValues)
+	err := logger.writerNamed(3, "", errorTypeOf(keysAndValues), level, msg, fields)
+	if misuseErr != nil {
+		return logger.misuse(misuseErr)
+	}
+	return err
+}
+
+//current outputs, must not be modified, safe without lock
+//return : outputs
+func (logger *Logger) outputList() []*outputLogger {
+	outputs, _ := logger.outputs.Load().([]*outputLogger)
+	return outputs
+}
+
+// adapter writing the call stack of messages, the logger captures the stack of Error+ messages
+// only while such an output is attached
+type stackWriter interface {
+	writesStack()
+}
+
+//an attached output writes the call stack
+func (logger *Logger) hasStackOutput() bool {
+	for _, output := range logger.outputList() {
+		if _, ok := output.LoggerAbstract.(stackWriter); ok {
+			return true
+		}
+	}
+	return false
+}
+
+//get an attached adapter for operations outside the logger, e.g. rotate the file adapter
+//a lazy adapter is returned once its Init succeeded
+//params : adapterName string
+//return : LoggerAbstract, false if the adapter is not attached or not initialized
+func (logger *Logger) Output(adapterName string) (LoggerAbstract, bool) {
+	output := logger.output(adapterName)
+	if output == nil {
+		return nil, false
+	}
+	if lazy, ok := output.LoggerAbstract.(*lazyAdapter); ok {
+		if atomic.LoadInt32(&lazy.ready) == 0 {
+			return nil, false
+		}
+		return lazy.adapter, true
+	}
+	return output.LoggerAbstract, true
+}
+
+//dispatch message to msgChan if async, otherwise write to loggerOutputs, nothing if disabled
+//Emergency, Alert and Critical messages are dispatched to urgentChan
+func (logger *Logger) dispatch(loggerMsg *loggerMessage) {
+	if logger.Disabled() {
+		return
+	}
+	loggerMsg.formats = &formatCache{owner: loggerMsg}
+	if logger.bufferStartup(loggerMsg) {
+		return
+	}
+	logger.asyncLock.RLock()
+	if !logger.synchronous {
+		msgChan := logger.msgChan
+		if loggerMsg.Level <= LoggerLevelCritical {
+			msgChan = logger.urgentChan
+		}
+		if logger.strictOrdering {
+			logger.ordering.assign(loggerMsg)
+		}
+		queued := logger.enqueue(msgChan, loggerMsg)
+		policy := logger.overflowPolicy
+		logger.asyncLock.RUnlock()
+		if !queued {
+			if loggerMsg.sequence != 0 {
+				logger.ordering.skip(loggerMsg.sequence)
+			}
+			logger.overflow(loggerMsg, policy)
+		}
+		return
+	}
+	logger.asyncLock.RUnlock()
+	logger.writeToOutputs(loggerMsg)
+}
+
 //sync write message to loggerOutputs
 //params : loggerMessage
 func (logger *Logger) writeToOutputs(loggerMsg *loggerMessage) {
-	for _, loggerOutput := range logger.outputs {
+	tags, _ := logger.tags.Load().(map[string]string)
+	for _, loggerOutput := range logger.outputList() {
+		// write route
+		if loggerMsg.routeAdapters != nil && !loggerMsg.routeAdapters[loggerOutput.Name] {
+			continue
+		}
+		// write silence
+		if loggerOutput.isSilenced() {
+			continue
+		}
+		// write schedule
+		if loggerOutput.schedule != nil &&
+			!loggerOutput.schedule.match(time.Unix(0, loggerMsg.Millisecond*1e6)) {
+			continue
+		}
 		// write level
-		if loggerOutput.Level >= loggerMsg.Level {
-			err := loggerOutput.Write(loggerMsg)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "logger: unable write loggerMessage to adapter:%v, error: %v\n", loggerOutput.Name, err)
+		if loggerOutput.getLevel() >= loggerMsg.Level {
+			outputMsg := loggerOutput.prepare(loggerMsg, tags)
+			if loggerOutput.recordSizes != nil {
+				loggerOutput.recordSizes.observe(outputMsg)
+			}
+			if offload := loggerOutput.offload; offload == nil || !offload.enqueue(loggerOutput, outputMsg) {
+				loggerOutput.deliver(outputMsg)
 			}
 		}
 	}
 }
 
-//start async write by read logger.msgChan
+//prepare message for output with the logger tags, the shared message is copied if changed
+func (output *outputLogger) prepare(loggerMsg *loggerMessage, tags map[string]string) *loggerMessage {
+	if tags == nil && output.tags == nil && output.normalizer == nil && output.clockSkew == nil && output.cardinality == nil {
+		return loggerMsg
+	}
+	outputMsg := *loggerMsg
+	if tags != nil || output.tags != nil {
+		outputMsg.Fields = mergeTags(tags, output.tags, loggerMsg.Fields)
+	}
+	if output.normalizer != nil {
+		outputMsg.Fields = output.normalizer.normalize(outputMsg.Fields)
+	}
+	if output.cardinality != nil {
+		outputMsg.Fields = output.cardinality.guard(outputMsg.Fields)
+	}
+	if output.clockSkew != nil {
+		output.clockSkew.apply(&outputMsg)
+	}
+	return &outputMsg
+}
+
+//start async write by read logger.msgChan, logger.urgentChan is read first
+//messages of strict ordering are written in sequence by the reordering buffer
+//return when a stop request is acked
 func (logger *Logger) startAsyncWrite() {
 	for {
 		select {
+		case loggerMsg := <-logger.urgentChan:
+			logger.writeOrdered(loggerMsg)
+			continue
+		default:
+		}
+		select {
+		case loggerMsg := <-logger.urgentChan:
+			logger.writeOrdered(loggerMsg)
 		case loggerMsg := <-logger.msgChan:
-			logger.writeToOutputs(loggerMsg)
-			logger.wait.Done()
-		case signal := <-logger.signalChan:
-			if signal == "flush" {
-				logger.flush()
-			}
+			logger.writeOrdered(loggerMsg)
+		case <-logger.ordering.wake:
+			logger.writeReady()
+		case request := <-logger.flushChan:
+			logger.flushAck(request)
+		case request := <-logger.stopChan:
+			logger.flushAck(request)
+			return
 		}
 	}
 }
 
-//flush msgChan data
-func (logger *Logger) flush() {
-	if !logger.synchronous {
-		for {
-			if len(logger.msgChan) > 0 {
-				loggerMsg := <-logger.msgChan
-				logger.writeToOutputs(loggerMsg)
-				logger.wait.Done()
-				continue
-			}
+//flush and close request done, done is closed even if an adapter panics
+func (logger *Logger) flushAck(request *flushRequest) {
+	defer close(request.done)
+	request.err = logger.flush()
+}
+
+//flush msgChan data, called by the async worker
+//messages waiting in the reordering buffer are written once the earlier sequences are received
+//return : AdapterErrors of outputs failed to flush
+func (logger *Logger) flush() error {
+	for {
+		if len(logger.urgentChan) > 0 {
+			loggerMsg := <-logger.urgentChan
+			logger.writeOrdered(loggerMsg)
+			continue
+		}
+		if len(logger.msgChan) > 0 {
+			loggerMsg := <-logger.msgChan
+			logger.writeOrdered(loggerMsg)
+			continue
+		}
+		if !logger.ordering.waiting() {
 			break
 		}
-		for _, loggerOutput := range logger.outputs {
-			loggerOutput.Flush()
+		// an earlier sequence is being queued or skipped by a concurrent writer
+		select {
+		case loggerMsg := <-logger.urgentChan:
+			logger.writeOrdered(loggerMsg)
+		case loggerMsg := <-logger.msgChan:
+			logger.writeOrdered(loggerMsg)
+		case <-logger.ordering.wake:
+			logger.writeReady()
 		}
 	}
+	return logger.flushOutputs()
+}
+
+//write pending summary records, repeat counts of the error sampler and quota summaries, called by Flush and Close
+func (logger *Logger) writeSummaries() {
+	if sampler, _ := logger.errorSampler.Load().(*errorSampler); sampler != nil {
+		logger.writeSamples(sampler, true)
+	}
+	if quota, _ := logger.quota.Load().(*quota); quota != nil {
+		logger.writeQuotaSummaries(quota, true)
+	}
 }
 
 //if SetAsync() or logger.synchronous is false, must call Flush() to flush msgChan data
-func (logger *Logger) Flush() {
+//return : AdapterErrors of outputs failed to flush, nil if all flushed
+func (logger *Logger) Flush() error {
+	logger.writeSummaries()
+
+	// the worker drains messages queued before the request, then acks
+	// Flush is safe to call concurrently and repeatedly
+	logger.asyncLock.RLock()
 	if !logger.synchronous {
-		logger.signalChan <- "flush"
-		logger.wait.Wait()
-		return
+		request := &flushRequest{done: make(chan struct{})}
+		logger.flushChan <- request
+		logger.asyncLock.RUnlock()
+		<-request.done
+		return request.err
 	}
-	logger.flush()
+	logger.asyncLock.RUnlock()
+	return logger.flushOutputs()
 }
 
 func (logger *Logger) LoggerLevel(levelStr string) int {
@@ -325,106 +725,39 @@ func (logger *Logger) LoggerLevel(levelStr string) int {
 }
 
 func loggerMessageFormat(format string, loggerMsg *loggerMessage) string {
+	if message, ok := loggerMessageTemplate(format, loggerMsg); ok {
+		return message
+	}
 	message := strings.Replace(format, "%timestamp%", strconv.FormatInt(loggerMsg.Timestamp, 10), 1)
 	message = strings.Replace(message, "%timestamp_format%", loggerMsg.TimestampFormat, 1)
 	message = strings.Replace(message, "%millisecond%", strconv.FormatInt(loggerMsg.Millisecond, 10), 1)
 	message = strings.Replace(message, "%millisecond_format%", loggerMsg.MillisecondFormat, 1)
 	message = strings.Replace(message, "%level%", strconv.Itoa(loggerMsg.Level), 1)
-	message = strings.Replace(message, "%level_string%", loggerMsg.LevelString, 1)
+	levelString := loggerMsg.LevelString
+	if loggerMsg.LevelDisplayString != "" {
+		levelString = loggerMsg.LevelDisplayString
+	}
+	message = strings.Replace(message, "%level_string%", levelString, 1)
 	message = strings.Replace(message, "%file%", loggerMsg.File, 1)
 	message = strings.Replace(message, "%line%", strconv.Itoa(loggerMsg.Line), 1)
 	message = strings.Replace(message, "%function%", loggerMsg.Function, 1)
-	message = strings.Replace(message, "%body%", loggerMsg.Body, 1)
-
-	return message
-}
-
-//log emergency level
-func (logger *Logger) Emergency(msg string) {
-	logger.Writer(LoggerLevelEmergency, msg)
-}
-
-//log emergency format
-func (logger *Logger) Emergencyf(format string, a ...interface{}) {
-	msg := fmt.Sprintf(format, a...)
-	logger.Writer(LoggerLevelEmergency, msg)
-}
-
-//log alert level
-func (logger *Logger) Alert(msg string) {
-	logger.Writer(LoggerLevelAlert, msg)
-}
-
-//log alert format
-func (logger *Logger) Alertf(format string, a ...interface{}) {
-	msg := fmt.Sprintf(format, a...)
-	logger.Writer(LoggerLevelAlert, msg)
-}
-
-//log critical level
-func (logger *Logger) Critical(msg string) {
-	logger.Writer(LoggerLevelCritical, msg)
-}
-
-//log critical format
-func (logger *Logger) Criticalf(format string, a ...interface{}) {
-	msg := fmt.Sprintf(format, a...)
-	logger.Writer(LoggerLevelCritical, msg)
-}
+	message = strings.Replace(message, "%name%", loggerMsg.Name, 1)
 
-//log error level
-func (logger *Logger) Error(msg string) {
-	logger.Writer(LoggerLevelError, msg)
-}
-
-//log error format
-func (logger *Logger) Errorf(format string, a ...interface{}) {
-	msg := fmt.Sprintf(format, a...)
-	logger.Writer(LoggerLevelError, msg)
-}
-
-//log warning level
-func (logger *Logger) Warning(msg string) {
-	logger.Writer(LoggerLevelWarning, msg)
-}
-
-//log warning format
-func (logger *Logger) Warningf(format string, a ...interface{}) {
-	msg := fmt.Sprintf(format, a...)
-	logger.Writer(LoggerLevelWarning, msg)
-}
-
-//log notice level
-func (logger *Logger) Notice(msg string) {
-	logger.Writer(LoggerLevelNotice, msg)
-}
-
-//log notice format
-func (logger *Logger) Noticef(format string, a ...interface{}) {
-	msg := fmt.Sprintf(format, a...)
-	logger.Writer(LoggerLevelNotice, msg)
-}
-
-//log info level
-func (logger *Logger) Info(msg string) {
-	logger.Writer(LoggerLevelInfo, msg)
-}
-
-//log info format
-func (logger *Logger) Infof(format string, a ...interface{}) {
-	msg := fmt.Sprintf(format, a...)
-	logger.Writer(LoggerLevelInfo, msg)
-}
+	// the default format has no %name%, the name prefixes the body
+	if loggerMsg.Name != "" && format == defaultLoggerMessageFormat {
+		message = strings.Replace(message, "%body%", loggerMsg.Name+": "+loggerMsg.Body, 1)
+	} else {
+		message = strings.Replace(message, "%body%", loggerMsg.Body, 1)
+	}
 
-//log debug level
-func (logger *Logger) Debug(msg string) {
-	logger.Writer(LoggerLevelDebug, msg)
-}
+	// fields are appended if format has no %fields%
+	if strings.Contains(format, "%fields%") {
+		message = strings.Replace(message, "%fields%", loggerFieldsFormat(loggerMsg.Fields), 1)
+	} else if len(loggerMsg.Fields) > 0 {
+		message += " " + loggerFieldsFormat(loggerMsg.Fields)
+	}
 
-//log debug format
-func (logger *Logger) Debugf(format string, a ...interface{}) {
-	msg := fmt.Sprintf(format, a...)
-	logger.Writer(LoggerLevelDebug, msg)
+	return message
 }
 
 func printError(message string) {
@@ -0,0 +1,445 @@
+package go_logger
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// counting adapter for concurrency tests
+type adapterCount struct {
+	writes int64
+}
+
+type countConfig struct{}
+
+func (cc *countConfig) Name() string {
+	return "count"
+}
+
+func (adapter *adapterCount) Init(config Config) error {
+	return nil
+}
+
+func (adapter *adapterCount) Write(loggerMsg *loggerMessage) error {
+	atomic.AddInt64(&adapter.writes, 1)
+	return nil
+}
+
+func (adapter *adapterCount) WriteRepeat(loggerMsg *loggerMessage, internId string) error {
+	atomic.AddInt64(&adapter.writes, 1)
+	return nil
+}
+
+func (adapter *adapterCount) Flush() {
+
+}
+
+func (adapter *adapterCount) Name() string {
+	return "count"
+}
+
+func init() {
+	Register("count", func() LoggerAbstract {
+		return &adapterCount{}
+	})
+}
+
+//run with go test -race
+func TestLogger_AttachDetachWhileWriting(t *testing.T) {
+
+	for _, async := range []bool{false, true} {
+		logger := NewLogger()
+		logger.Detach("console")
+		logger.Attach("console", LoggerLevelDebug, &ConsoleConfig{
+			Format: "%body%",
+		})
+		buffer := &bytes.Buffer{}
+		logger.outputList()[0].LoggerAbstract.(*AdapterConsole).write.writer = buffer
+		if async {
+			logger.SetAsync()
+		}
+
+		stop := make(chan struct{})
+		toggled := make(chan struct{})
+		go func() {
+			defer close(toggled)
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				logger.Attach("count", LoggerLevelDebug, &countConfig{})
+				logger.Config()
+				logger.Detach("count")
+			}
+		}()
+
+		writers := sync.WaitGroup{}
+		for i := 0; i < 8; i++ {
+			writers.Add(1)
+			go func() {
+				defer writers.Done()
+				for j := 0; j < 200; j++ {
+					logger.Infow("logger concurrent test", "j", j)
+				}
+			}()
+		}
+		writers.Wait()
+		close(stop)
+		<-toggled
+		logger.Flush()
+
+		if strings.Count(buffer.String(), "\n") != 8*200 {
+			t.Errorf("async %v, console must receive every message while other outputs change", async)
+		}
+	}
+}
+
+func TestLogger_outputsCopyOnWrite(t *testing.T) {
+
+	logger := NewLogger()
+	before := logger.outputList()
+	logger.Attach("count", LoggerLevelDebug, &countConfig{})
+	logger.Detach("console")
+
+	if len(before) != 1 || before[0].Name != "console" {
+		t.Error("outputs read before attach and detach must not change")
+	}
+	after := logger.outputList()
+	if len(after) != 1 || after[0].Name != "count" {
+		t.Error("outputs after attach and detach error")
+	}
+}
+
+// panic adapter for worker recovery tests
+type adapterPanic struct {
+	adapterCount
+}
+
+func (adapter *adapterPanic) Write(loggerMsg *loggerMessage) error {
+	panic("logger panic adapter")
+}
+
+func (adapter *adapterPanic) Name() string {
+	return "panic"
+}
+
+func init() {
+	Register("panic", func() LoggerAbstract {
+		return &adapterPanic{}
+	})
+}
+
+func TestLogger_FlushConcurrent(t *testing.T) {
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("count", LoggerLevelDebug, &countConfig{})
+	count := logger.outputList()[0].LoggerAbstract.(*adapterCount)
+	logger.SetAsync()
+
+	wait := sync.WaitGroup{}
+	for i := 0; i < 4; i++ {
+		wait.Add(2)
+		go func() {
+			defer wait.Done()
+			for j := 0; j < 100; j++ {
+				logger.Info("logger concurrent flush test")
+			}
+			logger.Flush()
+		}()
+		go func() {
+			defer wait.Done()
+			for j := 0; j < 10; j++ {
+				logger.Flush()
+			}
+		}()
+	}
+	wait.Wait()
+
+	if atomic.LoadInt64(&count.writes) != 4*100 {
+		t.Errorf("messages written before Flush returns must be delivered, got %d", count.writes)
+	}
+}
+
+func TestLogger_FlushTwiceAndAfterClose(t *testing.T) {
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("count", LoggerLevelDebug, &countConfig{})
+	count := logger.outputList()[0].LoggerAbstract.(*adapterCount)
+	logger.SetAsync()
+
+	logger.Info("logger flush twice test")
+	logger.Flush()
+	logger.Flush()
+	logger.Info("logger write after flush test")
+	logger.Close()
+	logger.Close()
+	logger.Flush()
+
+	// 2 messages and 1 shutdown summary
+	if atomic.LoadInt64(&count.writes) != 3 {
+		t.Errorf("flush twice and after close error, got %d writes", count.writes)
+	}
+}
+
+func TestLogger_FlushAfterAdapterPanic(t *testing.T) {
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("panic", LoggerLevelError, &countConfig{})
+	logger.Attach("count", LoggerLevelDebug, &countConfig{})
+	count := logger.outputList()[1].LoggerAbstract.(*adapterCount)
+	logger.SetAsync()
+
+	logger.Error("logger adapter panic test")
+	logger.Info("logger write after panic test")
+	logger.Flush()
+
+	if atomic.LoadInt64(&count.writes) != 1 {
+		t.Errorf("async worker must survive an adapter panic, got %d writes", count.writes)
+	}
+}
+
+func TestLogger_SetAsyncSetSync(t *testing.T) {
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("count", LoggerLevelDebug, &countConfig{})
+	count := logger.outputList()[0].LoggerAbstract.(*adapterCount)
+
+	logger.SetAsync(10)
+	msgChan := logger.msgChan
+	logger.SetAsync()
+	logger.SetAsync(10)
+	if logger.msgChan != msgChan {
+		t.Error("SetAsync again without another length must keep the worker")
+	}
+	for i := 0; i < 5; i++ {
+		logger.Info("logger set async test")
+	}
+	logger.SetAsync(50)
+	if cap(logger.msgChan) != 50 {
+		t.Error("SetAsync with another length must resize channels")
+	}
+	logger.Info("logger set async test")
+	logger.SetSync()
+	if !logger.synchronous || atomic.LoadInt64(&count.writes) != 6 {
+		t.Errorf("SetSync must write queued messages, got %d writes", count.writes)
+	}
+	logger.Info("logger set sync test")
+	if atomic.LoadInt64(&count.writes) != 7 {
+		t.Error("sync mode must write directly")
+	}
+}
+
+//run with go test -race
+func TestLogger_SwitchModeWhileWriting(t *testing.T) {
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("count", LoggerLevelDebug, &countConfig{})
+	count := logger.outputList()[0].LoggerAbstract.(*adapterCount)
+
+	writers := sync.WaitGroup{}
+	for i := 0; i < 8; i++ {
+		writers.Add(1)
+		go func() {
+			defer writers.Done()
+			for j := 0; j < 200; j++ {
+				logger.Info("logger switch mode test")
+				if j%50 == 0 {
+					logger.Flush()
+				}
+			}
+		}()
+	}
+	for i := 0; i < 20; i++ {
+		logger.SetAsync(10 + i%2)
+		logger.SetSync()
+	}
+	writers.Wait()
+	logger.SetSync()
+
+	if atomic.LoadInt64(&count.writes) != 8*200 {
+		t.Errorf("switching mode must not lose messages, got %d writes", count.writes)
+	}
+}
+
+func TestLogger_CloseStopsAsyncWorker(t *testing.T) {
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("count", LoggerLevelDebug, &countConfig{})
+	count := logger.outputList()[0].LoggerAbstract.(*adapterCount)
+	logger.SetAsync()
+
+	for i := 0; i < 10; i++ {
+		logger.Info("logger close test")
+	}
+	if err := logger.Close(); err != nil {
+		t.Error(err.Error())
+	}
+	// 10 messages and 1 shutdown summary
+	if atomic.LoadInt64(&count.writes) != 11 {
+		t.Errorf("close must drain the async queue, got %d writes", count.writes)
+	}
+	if !logger.synchronous {
+		t.Error("close must stop the async worker")
+	}
+
+	logger.SetAsync()
+	logger.Info("logger write after close test")
+	if !logger.synchronous || atomic.LoadInt64(&count.writes) != 12 {
+		t.Error("messages after close must be written synchronously")
+	}
+}
+
+// settings changed while messages are written, run with go test -race
+var settersWhileWriting = []struct {
+	name string
+	set  func(logger *Logger, i int)
+}{
+	{"SetRouteRules", func(logger *Logger, i int) {
+		logger.SetRouteRules([]RouteRule{{BodyRegexp: "^logger setter", Action: ROUTE_ACTION_REWRITE, SetFields: map[string]interface{}{"i": i}}})
+	}},
+	{"SetLevelDisplayStrings", func(logger *Logger, i int) {
+		logger.SetLevelDisplayStrings(map[int]string{LoggerLevelInfo: "信息" + strconv.Itoa(i)})
+	}},
+	{"SetMessageCatalog", func(logger *Logger, i int) {
+		logger.SetMessageCatalog(map[string]string{"logger setter test": "translated " + strconv.Itoa(i)})
+	}},
+	{"SetSanitizer", func(logger *Logger, i int) {
+		logger.SetSanitizer(&Sanitizer{MaxBodyBytes: 10 + i%10})
+	}},
+	{"SetBinaryEncoding", func(logger *Logger, i int) {
+		logger.SetBinaryEncoding(BINARY_ENCODING_HEX, i%10)
+	}},
+	{"SetBlobStore", func(logger *Logger, i int) {
+		if i%2 == 0 {
+			logger.SetBlobStore(nil, 0)
+			return
+		}
+		logger.SetBlobStore(&DiskBlobStore{Dir: os.TempDir()}, 1<<20)
+	}},
+	{"SetErrorSampling", func(logger *Logger, i int) {
+		logger.SetErrorSampling(time.Duration(1+i%3)*time.Millisecond, 0)
+	}},
+	{"SetQueueTTL", func(logger *Logger, i int) {
+		logger.SetQueueTTL(time.Duration(i%2)*time.Minute, "")
+	}},
+	{"SetContextDeadlineFields", func(logger *Logger, i int) {
+		logger.SetContextDeadlineFields(i%2 == 0)
+	}},
+	{"SetErrorFingerprint", func(logger *Logger, i int) {
+		logger.SetErrorFingerprint(i%2 == 0)
+	}},
+	{"SetRewriters", func(logger *Logger, i int) {
+		logger.SetRewriters(func(loggerMsg *LoggerMessage) {
+			loggerMsg.Body += strconv.Itoa(i)
+		})
+	}},
+	{"SetFieldNormalizer", func(logger *Logger, i int) {
+		logger.SetFieldNormalizer("count", &FieldNormalizer{MaxFields: i % 5})
+	}},
+	{"SetWriteRetries", func(logger *Logger, i int) {
+		logger.SetWriteRetries("count", i%3)
+	}},
+	{"SetClockSkew", func(logger *Logger, i int) {
+		logger.SetClockSkew("count", time.Duration(i%3)*time.Second, i%2 == 0)
+	}},
+	{"SetLevel", func(logger *Logger, i int) {
+		logger.SetLevel("count", LoggerLevelInfo+i%3)
+	}},
+	{"SetSchedule", func(logger *Logger, i int) {
+		logger.SetSchedule("count", []string{"", "* * * * *", "* 0-23 * * *"}[i%3])
+	}},
+	{"SetInterning", func(logger *Logger, i int) {
+		logger.SetInterning("count", i%3)
+	}},
+	{"SetCardinalityGuard", func(logger *Logger, i int) {
+		logger.SetCardinalityGuard("count", &CardinalityGuard{Fields: []string{"j"}, MaxValues: 1 + i%10, Action: CARDINALITY_ACTION_HASH})
+	}},
+	{"SetRecordSizeMetrics", func(logger *Logger, i int) {
+		logger.SetRecordSizeMetrics("count", i%3)
+	}},
+	{"SetTags", func(logger *Logger, i int) {
+		logger.SetTags(map[string]string{"env": strconv.Itoa(i)})
+	}},
+	{"SetAdapterTags", func(logger *Logger, i int) {
+		logger.SetAdapterTags("count", map[string]string{"region": strconv.Itoa(i)})
+	}},
+	{"SetOutputWorkers", func(logger *Logger, i int) {
+		logger.SetOutputWorkers("count", i%3, 0)
+	}},
+	{"SetQuota", func(logger *Logger, i int) {
+		logger.SetQuota(&QuotaConfig{Field: "tenant", Window: time.Millisecond, MaxRecords: int64(1 + i%3)})
+	}},
+	{"SetRecentBuffer", func(logger *Logger, i int) {
+		logger.SetRecentBuffer(i % 3)
+	}},
+}
+
+//run with go test -race
+func TestLogger_SettersWhileWriting(t *testing.T) {
+
+	for _, setter := range settersWhileWriting {
+		for _, async := range []bool{false, true} {
+			testSetterWhileWriting(t, setter.name, setter.set, async)
+		}
+	}
+}
+
+func testSetterWhileWriting(t *testing.T, name string, set func(logger *Logger, i int), async bool) {
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("count", LoggerLevelDebug, &countConfig{})
+	if async {
+		logger.SetAsync()
+	}
+
+	stop := make(chan struct{})
+	changed := make(chan struct{})
+	go func() {
+		defer close(changed)
+		for i := 0; ; i++ {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			set(logger, i)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	writers := sync.WaitGroup{}
+	for i := 0; i < 4; i++ {
+		writers.Add(1)
+		go func() {
+			defer writers.Done()
+			for j := 0; j < 100; j++ {
+				logger.InfoCtx(ctx, "logger setter context test")
+				logger.Infow("logger setter test", "tenant", j%3, "j", j, "data", []byte("logger setter data"))
+				logger.Error("logger setter error test")
+			}
+		}()
+	}
+	writers.Wait()
+	close(stop)
+	<-changed
+	if err := logger.Close(); err != nil {
+		t.Errorf("%s async %v: %v", name, async, err)
+	}
+}
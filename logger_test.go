@@ -1,7 +1,9 @@
 package go_logger
 
 import (
+	"bytes"
 	"fmt"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -13,11 +15,12 @@ func TestNewLogger(t *testing.T) {
 func TestLogger_Attach(t *testing.T) {
 
 	logger := NewLogger()
+	logger.Detach("console")
 	fileConfig := &FileConfig{
 		Filename: "./test.log",
 	}
 	logger.Attach("file", LoggerLevelDebug, fileConfig)
-	outputs := logger.outputs
+	outputs := logger.outputList()
 	for _, outputLogger := range outputs {
 		if outputLogger.Name != "file" {
 			t.Error("file attach failed")
@@ -30,13 +33,32 @@ func TestLogger_Detach(t *testing.T) {
 	logger := NewLogger()
 	logger.Detach("console")
 
-	outputs := logger.outputs
+	outputs := logger.outputList()
 
 	if len(outputs) > 0 {
 		t.Error("logger detach error")
 	}
 }
 
+func TestLogger_Output(t *testing.T) {
+
+	logger := NewLogger()
+	adapter, ok := logger.Output("console")
+	if !ok || adapter != logger.outputList()[0].LoggerAbstract {
+		t.Error("logger output must return the attached adapter")
+	}
+	if _, ok := logger.Output("file"); ok {
+		t.Error("logger output of an adapter not attached must be false")
+	}
+
+	atomic.StoreInt32(&flakyAvailable, 0)
+	logger.AttachLazy("flaky", LoggerLevelDebug, &countConfig{}, time.Hour, 1)
+	defer logger.Detach("flaky")
+	if _, ok := logger.Output("flaky"); ok {
+		t.Error("logger output of a lazy adapter not initialized must be false")
+	}
+}
+
 func TestLogger_LoggerLevel(t *testing.T) {
 
 	logger := NewLogger()
@@ -83,3 +105,43 @@ func TestLogger_loggerMessageFormat(t *testing.T) {
 
 	fmt.Println(str)
 }
+
+func TestLogger_WriterCaller(t *testing.T) {
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("console", LoggerLevelDebug, &ConsoleConfig{
+		Format: "%file% %body%",
+	})
+	buffer := &bytes.Buffer{}
+	logger.outputList()[0].LoggerAbstract.(*AdapterConsole).write.writer = buffer
+
+	logger.Info("logger caller test")
+	logger.Infow("logger caller test", "key", "value")
+	file := loggerTestCallerFile("logger_test.go")
+	if buffer.String() != file+" logger caller test\n"+file+" logger caller test key=value\n" {
+		t.Error("logger writer caller error: " + buffer.String())
+	}
+}
+
+func TestLogger_flushUrgentFirst(t *testing.T) {
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("console", LoggerLevelDebug, &ConsoleConfig{
+		Format: "%body%",
+	})
+	buffer := &bytes.Buffer{}
+	logger.outputList()[0].LoggerAbstract.(*AdapterConsole).write.writer = buffer
+
+	// async without worker goroutine, messages stay in channels
+	logger.synchronous = false
+	logger.Debug("debug")
+	logger.Info("info")
+	logger.Critical("critical")
+	logger.flush()
+
+	if buffer.String() != "critical\ndebug\ninfo\n" {
+		t.Error("logger urgent messages must be written first: " + buffer.String())
+	}
+}
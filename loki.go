@@ -0,0 +1,314 @@
+//go:build !tinygo && !logger_tiny
+// +build !tinygo,!logger_tiny
+
+package go_logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const LOKI_ADAPTER_NAME = "loki"
+
+// adapter loki, messages are batched into the Grafana Loki push API
+// a stream per distinct label set, lines are JSON messages or formatted by Format
+type AdapterLoki struct {
+	lock     sync.Mutex
+	sendLock sync.Mutex // batches are pushed in order
+	config   *LokiConfig
+	client   *http.Client
+	streams  map[string]*lokiStream
+	keys     []string // stream keys in order of the first line
+	count    int
+	timer    *time.Timer
+	failed   error // error of the last timer push, reported by FlushAck
+}
+
+// loki config
+type LokiConfig struct {
+
+	// push api url, "http://127.0.0.1:3100/loki/api/v1/push"
+	Url string `config:"required"`
+
+	// tenant of a multi-tenant Loki, sent as X-Scope-OrgID, empty sends none
+	TenantId string
+
+	// request headers, e.g. Authorization
+	Headers map[string]string
+
+	// static labels of every stream, e.g. {"app": "api", "env": "prod"}
+	Labels map[string]string
+
+	// label of the lower-case level string, default "level"
+	LevelLabel string
+
+	// message fields used as labels, keep their values low-cardinality
+	// the fields stay in the line
+	FieldLabels []string
+
+	// line format, see ConsoleConfig Format, empty writes the message as JSON
+	Format string
+
+	// lines per push, default 100
+	BatchSize int
+
+	// max wait before a batch is pushed, default 1 second
+	BatchWait time.Duration
+
+	// push request timeout, default 10 seconds
+	Timeout time.Duration
+}
+
+// lines of a label set
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+func (lc *LokiConfig) Name() string {
+	return LOKI_ADAPTER_NAME
+}
+
+func NewAdapterLoki() LoggerAbstract {
+	return &AdapterLoki{
+		config:  &LokiConfig{},
+		streams: map[string]*lokiStream{},
+	}
+}
+
+func (adapterLoki *AdapterLoki) Init(lokiConfig Config) error {
+	if lokiConfig.Name() != LOKI_ADAPTER_NAME {
+		return errors.New("logger loki adapter init error, config must LokiConfig")
+	}
+
+	lc := lokiConfig.(*LokiConfig)
+	adapterLoki.config = lc
+
+	if lc.Url == "" {
+		return errors.New("config Url cannot be empty!")
+	}
+	if lc.LevelLabel == "" {
+		lc.LevelLabel = "level"
+	}
+	for label := range lc.Labels {
+		if !lokiLabelName(label) {
+			return errors.New("config Labels name " + label + " is illegal!")
+		}
+	}
+	if !lokiLabelName(lc.LevelLabel) {
+		return errors.New("config LevelLabel " + lc.LevelLabel + " is illegal!")
+	}
+	for _, field := range lc.FieldLabels {
+		if !lokiLabelName(field) {
+			return errors.New("config FieldLabels name " + field + " is illegal!")
+		}
+	}
+	if lc.BatchSize < 0 || lc.BatchWait < 0 || lc.Timeout < 0 {
+		return errors.New("config BatchSize, BatchWait and Timeout cannot be negative!")
+	}
+	if lc.BatchSize == 0 {
+		lc.BatchSize = 100
+	}
+	if lc.BatchWait == 0 {
+		lc.BatchWait = time.Second
+	}
+	if lc.Timeout == 0 {
+		lc.Timeout = 10 * time.Second
+	}
+	adapterLoki.client = &http.Client{Timeout: lc.Timeout}
+	return nil
+}
+
+//Loki label name, [a-zA-Z_][a-zA-Z0-9_]*
+func lokiLabelName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, c := range name {
+		if c != '_' && (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') && (i == 0 || c < '0' || c > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+func (adapterLoki *AdapterLoki) Write(loggerMsg *loggerMessage) error {
+	config := adapterLoki.config
+	labels := make(map[string]string, len(config.Labels)+len(config.FieldLabels)+1)
+	for label, value := range config.Labels {
+		labels[label] = value
+	}
+	labels[config.LevelLabel] = strings.ToLower(loggerMsg.LevelString)
+	for _, field := range config.FieldLabels {
+		value, ok := loggerMsg.Fields[field]
+		if !ok {
+			continue
+		}
+		labelValue, ok := formatValue(value)
+		if !ok {
+			labelValue = fmt.Sprint(value)
+		}
+		labels[field] = labelValue
+	}
+
+	line := ""
+	if config.Format == "" {
+		line = loggerMsg.formatJson("")
+	} else {
+		line = loggerMsg.formatText(config.Format)
+	}
+	timestamp := strconv.FormatInt(loggerMsg.Millisecond*int64(time.Millisecond), 10)
+
+	adapterLoki.lock.Lock()
+	key := lokiStreamKey(labels)
+	stream, ok := adapterLoki.streams[key]
+	if !ok {
+		stream = &lokiStream{Stream: labels}
+		adapterLoki.streams[key] = stream
+		adapterLoki.keys = append(adapterLoki.keys, key)
+	}
+	stream.Values = append(stream.Values, [2]string{timestamp, line})
+	adapterLoki.count++
+	full := adapterLoki.count >= config.BatchSize
+	// the first line of a batch arms the push timer
+	if !full && adapterLoki.timer == nil {
+		adapterLoki.timer = time.AfterFunc(config.BatchWait, func() {
+			if err := adapterLoki.push(); err != nil {
+				adapterLoki.lock.Lock()
+				adapterLoki.failed = err
+				adapterLoki.lock.Unlock()
+				fmt.Fprintf(os.Stderr, "logger: unable push batch to adapter:%v, error: %v\n", LOKI_ADAPTER_NAME, err)
+			}
+		})
+	}
+	adapterLoki.lock.Unlock()
+
+	if full {
+		return adapterLoki.push()
+	}
+	return nil
+}
+
+//key of a label set, labels sorted by name
+func lokiStreamKey(labels map[string]string) string {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range names {
+		b.WriteString(name + "=" + strconv.Quote(labels[name]) + ",")
+	}
+	return b.String()
+}
+
+//push the pending batch, nothing if empty
+//return : error, the batch is dropped on error
+func (adapterLoki *AdapterLoki) push() error {
+	adapterLoki.sendLock.Lock()
+	defer adapterLoki.sendLock.Unlock()
+
+	adapterLoki.lock.Lock()
+	if adapterLoki.timer != nil {
+		adapterLoki.timer.Stop()
+		adapterLoki.timer = nil
+	}
+	if adapterLoki.count == 0 {
+		adapterLoki.lock.Unlock()
+		return nil
+	}
+	streams := make([]*lokiStream, 0, len(adapterLoki.keys))
+	for _, key := range adapterLoki.keys {
+		streams = append(streams, adapterLoki.streams[key])
+	}
+	adapterLoki.streams = map[string]*lokiStream{}
+	adapterLoki.keys = nil
+	adapterLoki.count = 0
+	adapterLoki.lock.Unlock()
+
+	body, err := json.Marshal(map[string]interface{}{"streams": streams})
+	if err != nil {
+		return err
+	}
+	return adapterLoki.request(body)
+}
+
+//post a push request
+func (adapterLoki *AdapterLoki) request(body []byte) error {
+	config := adapterLoki.config
+	request, err := http.NewRequest("POST", config.Url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	for key, value := range config.Headers {
+		request.Header.Set(key, value)
+	}
+	if config.TenantId != "" {
+		request.Header.Set("X-Scope-OrgID", config.TenantId)
+	}
+
+	response, err := adapterLoki.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		message, _ := ioutil.ReadAll(io.LimitReader(response.Body, 512))
+		return errors.New("request " + config.Url + " failed, code=" + strconv.Itoa(response.StatusCode) + ", " + strings.TrimSpace(string(message)))
+	}
+	return nil
+}
+
+func (adapterLoki *AdapterLoki) Flush() {
+	adapterLoki.FlushError()
+}
+
+//push the pending batch
+func (adapterLoki *AdapterLoki) FlushError() error {
+	return adapterLoki.push()
+}
+
+//push the pending batch and wait for the push response up to timeout, after the pushes in flight
+//a failed timer push since the last FlushAck is reported too
+func (adapterLoki *AdapterLoki) FlushAck(timeout time.Duration) error {
+	err := waitAck(timeout, adapterLoki.push)
+	adapterLoki.lock.Lock()
+	if err == nil {
+		err = adapterLoki.failed
+	}
+	adapterLoki.failed = nil
+	adapterLoki.lock.Unlock()
+	return err
+}
+
+//push the pending batch and stop the timer, the adapter must not be written after Close
+func (adapterLoki *AdapterLoki) Close() error {
+	return adapterLoki.push()
+}
+
+func (adapterLoki *AdapterLoki) Name() string {
+	return LOKI_ADAPTER_NAME
+}
+
+//new empty config of the adapter, describes the config fields
+func (adapterLoki *AdapterLoki) NewConfig() Config {
+	return &LokiConfig{}
+}
+
+func init() {
+	Register(LOKI_ADAPTER_NAME, NewAdapterLoki)
+}
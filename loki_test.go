@@ -0,0 +1,156 @@
+//go:build !tinygo && !logger_tiny
+// +build !tinygo,!logger_tiny
+
+package go_logger
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// push requests received by a fake Loki
+type lokiServer struct {
+	lock    sync.Mutex
+	tenants []string
+	pushes  [][]lokiStream
+}
+
+func (server *lokiServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	push := struct {
+		Streams []lokiStream `json:"streams"`
+	}{}
+	if err := json.NewDecoder(r.Body).Decode(&push); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	server.lock.Lock()
+	server.tenants = append(server.tenants, r.Header.Get("X-Scope-OrgID"))
+	server.pushes = append(server.pushes, push.Streams)
+	server.lock.Unlock()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (server *lokiServer) received() ([]string, [][]lokiStream) {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+	return append([]string{}, server.tenants...), append([][]lokiStream{}, server.pushes...)
+}
+
+func TestAdapterLoki_Init(t *testing.T) {
+
+	configs := []*LokiConfig{
+		{},
+		{Url: "http://127.0.0.1:3100/loki/api/v1/push", Labels: map[string]string{"app-name": "api"}},
+		{Url: "http://127.0.0.1:3100/loki/api/v1/push", FieldLabels: []string{"1user"}},
+		{Url: "http://127.0.0.1:3100/loki/api/v1/push", BatchSize: -1},
+	}
+	for _, config := range configs {
+		if NewAdapterLoki().Init(config) == nil {
+			t.Errorf("loki adapter config %+v must be error", config)
+		}
+	}
+}
+
+func TestAdapterLoki_Write(t *testing.T) {
+
+	server := &lokiServer{}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	lokiAdapter := NewAdapterLoki()
+	err := lokiAdapter.Init(&LokiConfig{
+		Url:         httpServer.URL,
+		TenantId:    "team-a",
+		Labels:      map[string]string{"app": "api"},
+		FieldLabels: []string{"region"},
+		Format:      "%body%",
+		BatchSize:   3,
+		BatchWait:   time.Hour,
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	messages := []*loggerMessage{
+		{Millisecond: 1000, LevelString: "Info", Body: "logger loki 1", Fields: map[string]interface{}{"region": "eu"}},
+		{Millisecond: 2000, LevelString: "Error", Body: "logger loki 2", Fields: map[string]interface{}{"region": "eu"}},
+		{Millisecond: 3000, LevelString: "Info", Body: "logger loki 3", Fields: map[string]interface{}{"region": "eu"}},
+		{Millisecond: 4000, LevelString: "Info", Body: "logger loki 4"},
+	}
+	for _, loggerMsg := range messages {
+		if err := lokiAdapter.Write(loggerMsg); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+
+	tenants, pushes := server.received()
+	if len(pushes) != 1 || len(pushes[0]) != 2 || tenants[0] != "team-a" {
+		t.Fatalf("loki adapter must push a full batch of 2 streams: %+v %v", pushes, tenants)
+	}
+	info := pushes[0][0]
+	if info.Stream["app"] != "api" || info.Stream["level"] != "info" || info.Stream["region"] != "eu" ||
+		len(info.Values) != 2 || info.Values[0] != [2]string{"1000000000", "logger loki 1 region=eu"} || info.Values[1][1] != "logger loki 3 region=eu" {
+		t.Errorf("loki adapter info stream error: %+v", info)
+	}
+	if pushes[0][1].Stream["level"] != "error" || pushes[0][1].Values[0][1] != "logger loki 2 region=eu" {
+		t.Errorf("loki adapter error stream error: %+v", pushes[0][1])
+	}
+
+	if err := lokiAdapter.(*AdapterLoki).FlushError(); err != nil {
+		t.Fatal(err.Error())
+	}
+	_, pushes = server.received()
+	if len(pushes) != 2 || len(pushes[1]) != 1 || len(pushes[1][0].Stream) != 2 || pushes[1][0].Values[0][1] != "logger loki 4" {
+		t.Errorf("loki adapter flush must push the pending batch: %+v", pushes)
+	}
+}
+
+func TestAdapterLoki_BatchWait(t *testing.T) {
+
+	server := &lokiServer{}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	lokiAdapter := NewAdapterLoki()
+	err := lokiAdapter.Init(&LokiConfig{Url: httpServer.URL, BatchWait: 10 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer lokiAdapter.(*AdapterLoki).Close()
+	lokiAdapter.Write(&loggerMessage{Millisecond: 1000, LevelString: "Info", Body: "logger loki wait"})
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		_, pushes := server.received()
+		if len(pushes) == 1 {
+			line := map[string]interface{}{}
+			json.Unmarshal([]byte(pushes[0][0].Values[0][1]), &line)
+			if line["body"] != "logger loki wait" {
+				t.Errorf("loki adapter line must be JSON: %v", pushes[0][0].Values[0][1])
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("loki adapter must push after BatchWait")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestAdapterLoki_WriteError(t *testing.T) {
+
+	httpServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "entry out of order", http.StatusBadRequest)
+	}))
+	defer httpServer.Close()
+
+	lokiAdapter := NewAdapterLoki()
+	lokiAdapter.Init(&LokiConfig{Url: httpServer.URL, BatchSize: 1})
+	if lokiAdapter.Write(&loggerMessage{LevelString: "Info", Body: "logger loki"}) == nil {
+		t.Error("loki adapter must return the push error")
+	}
+}
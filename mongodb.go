@@ -0,0 +1,753 @@
+//go:build !tinygo && !logger_tiny
+// +build !tinygo,!logger_tiny
+
+package go_logger
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"math"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const MONGODB_ADAPTER_NAME = "mongodb"
+
+const (
+	MONGODB_AUTH_SCRAM_SHA_256 = "SCRAM-SHA-256"
+	MONGODB_AUTH_SCRAM_SHA_1   = "SCRAM-SHA-1"
+)
+
+// OP_MSG opcode of the MongoDB wire protocol
+const mongodbOpMsg = 2013
+
+// error code of the create command, the collection exists
+const mongodbNamespaceExists = 48
+
+// adapter mongodb, messages are inserted as documents into a collection
+// the collection may be created as a capped collection, the oldest documents are removed when full
+type AdapterMongodb struct {
+	lock      sync.Mutex
+	conn      net.Conn
+	reader    *bufio.Reader
+	config    *MongodbConfig
+	requestId int32
+}
+
+// mongodb config
+type MongodbConfig struct {
+
+	// server address, "127.0.0.1:27017", the primary of a replica set
+	Address string `config:"required"`
+
+	// connect by TLS, TLSConfig nil verifies the server by the host of Address
+	TLS       bool
+	TLSConfig *tls.Config
+
+	// database and collection
+	Database   string `config:"required"`
+	Collection string `config:"required"`
+
+	// username and password, empty does not authenticate
+	Username string
+	Password string
+
+	// authentication database, default "admin"
+	AuthSource string
+
+	// SCRAM-SHA-256 or SCRAM-SHA-1, default SCRAM-SHA-256
+	AuthMechanism string
+
+	// create the collection as a capped collection of CappedSize bytes if it does not exist
+	// an existing collection is not converted
+	Capped bool
+
+	// capped collection max bytes, default 100 MB
+	CappedSize int64
+
+	// capped collection max documents, 0 is limited by CappedSize only
+	CappedMaxDocuments int64
+
+	// connect, write and read timeout, default 5 seconds
+	Timeout time.Duration
+}
+
+// document of the BSON encoding, the order of the elements is kept
+type bsonDocument []bsonElement
+
+// element of a BSON document
+type bsonElement struct {
+	key   string
+	value interface{}
+}
+
+// error reply of the server
+type mongodbError struct {
+	code    int
+	message string
+}
+
+func (err *mongodbError) Error() string {
+	return "mongodb: " + err.message + " (code " + strconv.Itoa(err.code) + ")"
+}
+
+// command not sent, the connection failed before the whole message was written
+type mongodbSendError struct {
+	error
+}
+
+func (mc *MongodbConfig) Name() string {
+	return MONGODB_ADAPTER_NAME
+}
+
+func NewAdapterMongodb() LoggerAbstract {
+	return &AdapterMongodb{
+		config: &MongodbConfig{},
+	}
+}
+
+func (adapterMongodb *AdapterMongodb) Init(mongodbConfig Config) error {
+	if mongodbConfig.Name() != MONGODB_ADAPTER_NAME {
+		return errors.New("logger mongodb adapter init error, config must MongodbConfig")
+	}
+
+	mc := mongodbConfig.(*MongodbConfig)
+	adapterMongodb.config = mc
+
+	if mc.Address == "" {
+		return errors.New("config Address cannot be empty!")
+	}
+	if mc.Database == "" || mc.Collection == "" {
+		return errors.New("config Database and Collection cannot be empty!")
+	}
+	if mc.AuthMechanism == "" {
+		mc.AuthMechanism = MONGODB_AUTH_SCRAM_SHA_256
+	}
+	if mc.AuthMechanism != MONGODB_AUTH_SCRAM_SHA_256 && mc.AuthMechanism != MONGODB_AUTH_SCRAM_SHA_1 {
+		return errors.New("config AuthMechanism must be one of the 'SCRAM-SHA-256', 'SCRAM-SHA-1'!")
+	}
+	if mc.AuthSource == "" {
+		mc.AuthSource = "admin"
+	}
+	if mc.CappedSize < 0 || mc.CappedMaxDocuments < 0 || mc.Timeout < 0 {
+		return errors.New("config CappedSize, CappedMaxDocuments and Timeout cannot be negative!")
+	}
+	if mc.CappedSize == 0 {
+		mc.CappedSize = 100 * 1024 * 1024
+	}
+	if mc.Timeout == 0 {
+		mc.Timeout = 5 * time.Second
+	}
+
+	adapterMongodb.lock.Lock()
+	defer adapterMongodb.lock.Unlock()
+
+	if err := adapterMongodb.connect(); err != nil {
+		return err
+	}
+	if !mc.Capped {
+		return nil
+	}
+	create := bsonDocument{
+		{"create", mc.Collection},
+		{"capped", true},
+		{"size", mc.CappedSize},
+	}
+	if mc.CappedMaxDocuments > 0 {
+		create = append(create, bsonElement{"max", mc.CappedMaxDocuments})
+	}
+	_, err := adapterMongodb.command(mc.Database, create)
+	if mongoErr, ok := err.(*mongodbError); ok && mongoErr.code == mongodbNamespaceExists {
+		return nil
+	}
+	return err
+}
+
+//connect and authenticate, called with lock held
+func (adapterMongodb *AdapterMongodb) connect() error {
+	config := adapterMongodb.config
+	conn, err := net.DialTimeout("tcp", config.Address, config.Timeout)
+	if err != nil {
+		return err
+	}
+	if config.TLS {
+		tlsConfig := config.TLSConfig
+		if tlsConfig == nil {
+			host, _, _ := net.SplitHostPort(config.Address)
+			tlsConfig = &tls.Config{ServerName: host}
+		}
+		conn = tls.Client(conn, tlsConfig)
+	}
+	adapterMongodb.conn = conn
+	adapterMongodb.reader = bufio.NewReader(conn)
+	if config.Username != "" {
+		if err := adapterMongodb.authenticate(); err != nil {
+			conn.Close()
+			adapterMongodb.conn = nil
+			return err
+		}
+	}
+	return nil
+}
+
+//SCRAM authentication, RFC 5802, called with lock held
+func (adapterMongodb *AdapterMongodb) authenticate() error {
+	config := adapterMongodb.config
+	newHash := sha256.New
+	password := config.Password
+	if config.AuthMechanism == MONGODB_AUTH_SCRAM_SHA_1 {
+		newHash = sha1.New
+		digest := md5.Sum([]byte(config.Username + ":mongo:" + config.Password))
+		password = hex.EncodeToString(digest[:])
+	}
+
+	nonce := make([]byte, 24)
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	clientNonce := base64.StdEncoding.EncodeToString(nonce)
+	username := strings.NewReplacer("=", "=3D", ",", "=2C").Replace(config.Username)
+	clientFirstBare := "n=" + username + ",r=" + clientNonce
+
+	reply, err := adapterMongodb.command(config.AuthSource, bsonDocument{
+		{"saslStart", 1},
+		{"mechanism", config.AuthMechanism},
+		{"payload", []byte("n,," + clientFirstBare)},
+		{"autoAuthorize", 1},
+		{"options", bsonDocument{{"skipEmptyExchange", true}}},
+	})
+	if err != nil {
+		return err
+	}
+	serverFirst, _ := reply["payload"].([]byte)
+	attributes := scramAttributes(string(serverFirst))
+	salt, err := base64.StdEncoding.DecodeString(attributes["s"])
+	iterations, _ := strconv.Atoi(attributes["i"])
+	if err != nil || iterations <= 0 || !strings.HasPrefix(attributes["r"], clientNonce) {
+		return errors.New("mongodb: SCRAM server first message is illegal!")
+	}
+
+	saltedPassword := pbkdf2([]byte(password), salt, iterations, newHash)
+	clientKey := hmacSum(newHash, saltedPassword, "Client Key")
+	storedKey := newHash()
+	storedKey.Write(clientKey)
+	clientFinalWithoutProof := "c=biws,r=" + attributes["r"]
+	authMessage := clientFirstBare + "," + string(serverFirst) + "," + clientFinalWithoutProof
+	clientSignature := hmacSum(newHash, storedKey.Sum(nil), authMessage)
+	proof := make([]byte, len(clientKey))
+	for i := range clientKey {
+		proof[i] = clientKey[i] ^ clientSignature[i]
+	}
+
+	reply, err = adapterMongodb.command(config.AuthSource, bsonDocument{
+		{"saslContinue", 1},
+		{"conversationId", reply["conversationId"]},
+		{"payload", []byte(clientFinalWithoutProof + ",p=" + base64.StdEncoding.EncodeToString(proof))},
+	})
+	if err != nil {
+		return err
+	}
+	serverFinal, _ := reply["payload"].([]byte)
+	serverKey := hmacSum(newHash, saltedPassword, "Server Key")
+	serverSignature := base64.StdEncoding.EncodeToString(hmacSum(newHash, serverKey, authMessage))
+	if scramAttributes(string(serverFinal))["v"] != serverSignature {
+		return errors.New("mongodb: SCRAM server signature is illegal!")
+	}
+	// servers without skipEmptyExchange finish by an empty exchange
+	for done, _ := reply["done"].(bool); !done; done, _ = reply["done"].(bool) {
+		reply, err = adapterMongodb.command(config.AuthSource, bsonDocument{
+			{"saslContinue", 1},
+			{"conversationId", reply["conversationId"]},
+			{"payload", []byte{}},
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//attributes of a SCRAM message, "r=...,s=...,i=..."
+func scramAttributes(message string) map[string]string {
+	attributes := map[string]string{}
+	for _, attribute := range strings.Split(message, ",") {
+		if len(attribute) >= 2 && attribute[1] == '=' {
+			attributes[attribute[:1]] = attribute[2:]
+		}
+	}
+	return attributes
+}
+
+//HMAC of message by key
+func hmacSum(newHash func() hash.Hash, key []byte, message string) []byte {
+	mac := hmac.New(newHash, key)
+	mac.Write([]byte(message))
+	return mac.Sum(nil)
+}
+
+//PBKDF2 of RFC 8018, a single block of the hash size
+func pbkdf2(password []byte, salt []byte, iterations int, newHash func() hash.Hash) []byte {
+	mac := hmac.New(newHash, password)
+	mac.Write(salt)
+	mac.Write([]byte{0, 0, 0, 1})
+	u := mac.Sum(nil)
+	result := append([]byte{}, u...)
+	for i := 1; i < iterations; i++ {
+		mac.Reset()
+		mac.Write(u)
+		u = mac.Sum(u[:0])
+		for j := range result {
+			result[j] ^= u[j]
+		}
+	}
+	return result
+}
+
+func (adapterMongodb *AdapterMongodb) Write(loggerMsg *loggerMessage) error {
+	config := adapterMongodb.config
+	insert := bsonDocument{
+		{"insert", config.Collection},
+		{"documents", []interface{}{mongodbDocument(loggerMsg)}},
+		{"ordered", true},
+	}
+
+	adapterMongodb.lock.Lock()
+	defer adapterMongodb.lock.Unlock()
+
+	if adapterMongodb.conn != nil {
+		_, err := adapterMongodb.command(config.Database, insert)
+		if _, sendFailed := err.(mongodbSendError); !sendFailed {
+			// an insert sent may have been executed, e.g. a read timeout, and is not sent again
+			adapterMongodb.closeOnError(err)
+			return err
+		}
+		adapterMongodb.conn.Close()
+		adapterMongodb.conn = nil
+	}
+	// connect, or reconnect once if the insert was not sent, e.g. a failover of the primary
+	if err := adapterMongodb.connect(); err != nil {
+		return err
+	}
+	_, err := adapterMongodb.command(config.Database, insert)
+	adapterMongodb.closeOnError(err)
+	return err
+}
+
+//close the connection after a network error, the next write reconnects, called with lock held
+func (adapterMongodb *AdapterMongodb) closeOnError(err error) {
+	if _, ok := err.(*mongodbError); err == nil || ok {
+		return
+	}
+	adapterMongodb.conn.Close()
+	adapterMongodb.conn = nil
+}
+
+//log document of the message
+func mongodbDocument(loggerMsg *loggerMessage) bsonDocument {
+	document := bsonDocument{
+		{"timestamp", time.Unix(0, loggerMsg.Millisecond*int64(time.Millisecond))},
+		{"level", loggerMsg.Level},
+		{"level_string", loggerMsg.LevelString},
+		{"body", loggerMsg.Body},
+		{"file", loggerMsg.File},
+		{"line", loggerMsg.Line},
+		{"function", loggerMsg.Function},
+	}
+	if loggerMsg.Name != "" {
+		document = append(document, bsonElement{"name", loggerMsg.Name})
+	}
+	if len(loggerMsg.Fields) > 0 {
+		fields := make(map[string]interface{}, len(loggerMsg.Fields))
+		for key, value := range loggerMsg.Fields {
+			fields[key] = value
+		}
+		document = append(document, bsonElement{"fields", fields})
+	}
+	return document
+}
+
+//run a command by OP_MSG, an error reply or write errors are mongodbError, called with lock held
+func (adapterMongodb *AdapterMongodb) command(database string, command bsonDocument) (map[string]interface{}, error) {
+	command = append(command, bsonElement{"$db", database})
+	document, err := bsonEncode(command)
+	if err != nil {
+		return nil, err
+	}
+	adapterMongodb.requestId++
+	message := make([]byte, 21, 21+len(document))
+	binary.LittleEndian.PutUint32(message[0:4], uint32(21+len(document)))
+	binary.LittleEndian.PutUint32(message[4:8], uint32(adapterMongodb.requestId))
+	binary.LittleEndian.PutUint32(message[12:16], mongodbOpMsg)
+	// flag bits 0, section kind 0 of the command document
+	message = append(message, document...)
+
+	conn := adapterMongodb.conn
+	conn.SetDeadline(time.Now().Add(adapterMongodb.config.Timeout))
+	// a partial message is never executed by the server
+	if _, err := conn.Write(message); err != nil {
+		return nil, mongodbSendError{err}
+	}
+
+	header := make([]byte, 16)
+	if _, err := io.ReadFull(adapterMongodb.reader, header); err != nil {
+		return nil, err
+	}
+	length := int(binary.LittleEndian.Uint32(header[0:4]))
+	if length < 21 || length > 48*1024*1024 || binary.LittleEndian.Uint32(header[12:16]) != mongodbOpMsg {
+		return nil, errors.New("mongodb: reply message is illegal!")
+	}
+	body := make([]byte, length-16)
+	if _, err := io.ReadFull(adapterMongodb.reader, body); err != nil {
+		return nil, err
+	}
+	if body[4] != 0 {
+		return nil, errors.New("mongodb: reply section kind " + strconv.Itoa(int(body[4])) + " is illegal!")
+	}
+	reply, err := bsonDecode(body[5:])
+	if err != nil {
+		return nil, err
+	}
+	if ok, _ := bsonNumber(reply["ok"]); ok != 1 {
+		code, _ := bsonNumber(reply["code"])
+		message, _ := reply["errmsg"].(string)
+		return reply, &mongodbError{code: int(code), message: message}
+	}
+	if writeErrors, _ := reply["writeErrors"].([]interface{}); len(writeErrors) > 0 {
+		writeError, _ := writeErrors[0].(map[string]interface{})
+		code, _ := bsonNumber(writeError["code"])
+		message, _ := writeError["errmsg"].(string)
+		return reply, &mongodbError{code: int(code), message: message}
+	}
+	return reply, nil
+}
+
+//number of an int32, int64 or double value
+func bsonNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
+//BSON encoding of a document
+func bsonEncode(document bsonDocument) ([]byte, error) {
+	buffer := &bytes.Buffer{}
+	if err := bsonWriteDocument(buffer, document); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+//write a document, int32 size, elements, null byte
+func bsonWriteDocument(buffer *bytes.Buffer, document bsonDocument) error {
+	start := buffer.Len()
+	buffer.Write([]byte{0, 0, 0, 0})
+	for _, element := range document {
+		if strings.IndexByte(element.key, 0) >= 0 {
+			return errors.New("mongodb: key " + strconv.Quote(element.key) + " contains a null byte!")
+		}
+		if err := bsonWriteElement(buffer, element.key, element.value); err != nil {
+			return err
+		}
+	}
+	buffer.WriteByte(0)
+	binary.LittleEndian.PutUint32(buffer.Bytes()[start:], uint32(buffer.Len()-start))
+	return nil
+}
+
+//write an element, type byte, key cstring, value
+func bsonWriteElement(buffer *bytes.Buffer, key string, value interface{}) error {
+	var b [8]byte
+	writeHeader := func(elementType byte) {
+		buffer.WriteByte(elementType)
+		buffer.WriteString(key)
+		buffer.WriteByte(0)
+	}
+	switch v := value.(type) {
+	case nil:
+		writeHeader(0x0a)
+	case string:
+		writeHeader(0x02)
+		binary.LittleEndian.PutUint32(b[:4], uint32(len(v)+1))
+		buffer.Write(b[:4])
+		buffer.WriteString(v)
+		buffer.WriteByte(0)
+	case bool:
+		writeHeader(0x08)
+		if v {
+			buffer.WriteByte(1)
+		} else {
+			buffer.WriteByte(0)
+		}
+	case int:
+		return bsonWriteElement(buffer, key, int64(v))
+	case int8:
+		return bsonWriteElement(buffer, key, int32(v))
+	case int16:
+		return bsonWriteElement(buffer, key, int32(v))
+	case uint8:
+		return bsonWriteElement(buffer, key, int32(v))
+	case uint16:
+		return bsonWriteElement(buffer, key, int32(v))
+	case uint32:
+		return bsonWriteElement(buffer, key, int64(v))
+	case uint:
+		return bsonWriteElement(buffer, key, bsonUint(uint64(v)))
+	case uint64:
+		return bsonWriteElement(buffer, key, bsonUint(v))
+	case int32:
+		writeHeader(0x10)
+		binary.LittleEndian.PutUint32(b[:4], uint32(v))
+		buffer.Write(b[:4])
+	case int64:
+		writeHeader(0x12)
+		binary.LittleEndian.PutUint64(b[:], uint64(v))
+		buffer.Write(b[:])
+	case float32:
+		return bsonWriteElement(buffer, key, float64(v))
+	case float64:
+		writeHeader(0x01)
+		binary.LittleEndian.PutUint64(b[:], math.Float64bits(v))
+		buffer.Write(b[:])
+	case time.Time:
+		writeHeader(0x09)
+		binary.LittleEndian.PutUint64(b[:], uint64(v.UnixNano()/int64(time.Millisecond)))
+		buffer.Write(b[:])
+	case time.Duration:
+		return bsonWriteElement(buffer, key, v.String())
+	case []byte:
+		writeHeader(0x05)
+		binary.LittleEndian.PutUint32(b[:4], uint32(len(v)))
+		buffer.Write(b[:4])
+		buffer.WriteByte(0)
+		buffer.Write(v)
+	case bsonDocument:
+		writeHeader(0x03)
+		return bsonWriteDocument(buffer, v)
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		document := make(bsonDocument, 0, len(v))
+		for _, k := range keys {
+			document = append(document, bsonElement{k, v[k]})
+		}
+		writeHeader(0x03)
+		return bsonWriteDocument(buffer, document)
+	case []interface{}:
+		array := make(bsonDocument, 0, len(v))
+		for i, item := range v {
+			array = append(array, bsonElement{strconv.Itoa(i), item})
+		}
+		writeHeader(0x04)
+		return bsonWriteDocument(buffer, array)
+	case error:
+		return bsonWriteElement(buffer, key, v.Error())
+	default:
+		return bsonWriteElement(buffer, key, bsonValue(value))
+	}
+	return nil
+}
+
+//uint64 as int64 if it fits, otherwise the decimal string
+func bsonUint(v uint64) interface{} {
+	if v > math.MaxInt64 {
+		return strconv.FormatUint(v, 10)
+	}
+	return int64(v)
+}
+
+//value of other types, the registered formatter, or the JSON encoding as a document, or fmt.Sprint
+func bsonValue(value interface{}) interface{} {
+	if text, ok := formatValue(value); ok {
+		return text
+	}
+	if data, err := json.Marshal(value); err == nil {
+		var decoded interface{}
+		if json.Unmarshal(data, &decoded) == nil {
+			return decoded
+		}
+	}
+	return fmt.Sprint(value)
+}
+
+//decode a BSON document of a reply, documents are map[string]interface{}, arrays are []interface{}
+func bsonDecode(data []byte) (map[string]interface{}, error) {
+	elements, err := bsonReadDocument(data)
+	if err != nil {
+		return nil, err
+	}
+	document := make(map[string]interface{}, len(elements))
+	for _, element := range elements {
+		document[element.key] = element.value
+	}
+	return document, nil
+}
+
+//read the elements of a document
+func bsonReadDocument(data []byte) (bsonDocument, error) {
+	illegal := errors.New("mongodb: BSON document is illegal!")
+	if len(data) < 5 {
+		return nil, illegal
+	}
+	size := int(binary.LittleEndian.Uint32(data[0:4]))
+	if size < 5 || size > len(data) || data[size-1] != 0 {
+		return nil, illegal
+	}
+	data = data[4 : size-1]
+	document := bsonDocument{}
+	for len(data) > 0 {
+		elementType := data[0]
+		end := bytes.IndexByte(data[1:], 0)
+		if end < 0 {
+			return nil, illegal
+		}
+		key := string(data[1 : 1+end])
+		data = data[2+end:]
+
+		var value interface{}
+		length := 0
+		switch elementType {
+		case 0x01:
+			length = 8
+			if len(data) >= length {
+				value = math.Float64frombits(binary.LittleEndian.Uint64(data))
+			}
+		case 0x02, 0x0d, 0x0e:
+			if len(data) < 4 {
+				return nil, illegal
+			}
+			length = 4 + int(binary.LittleEndian.Uint32(data))
+			if length < 5 || len(data) < length {
+				return nil, illegal
+			}
+			value = string(data[4 : length-1])
+		case 0x03, 0x04:
+			if len(data) < 4 {
+				return nil, illegal
+			}
+			length = int(binary.LittleEndian.Uint32(data))
+			if length > len(data) {
+				return nil, illegal
+			}
+			if elementType == 0x03 {
+				embedded, err := bsonDecode(data[:length])
+				if err != nil {
+					return nil, err
+				}
+				value = embedded
+			} else {
+				elements, err := bsonReadDocument(data[:length])
+				if err != nil {
+					return nil, err
+				}
+				array := make([]interface{}, 0, len(elements))
+				for _, element := range elements {
+					array = append(array, element.value)
+				}
+				value = array
+			}
+		case 0x05:
+			if len(data) < 5 {
+				return nil, illegal
+			}
+			length = 5 + int(binary.LittleEndian.Uint32(data))
+			if len(data) < length {
+				return nil, illegal
+			}
+			value = append([]byte{}, data[5:length]...)
+		case 0x07:
+			length = 12
+			if len(data) >= length {
+				value = hex.EncodeToString(data[:12])
+			}
+		case 0x08:
+			length = 1
+			if len(data) >= length {
+				value = data[0] == 1
+			}
+		case 0x09:
+			length = 8
+			if len(data) >= length {
+				millisecond := int64(binary.LittleEndian.Uint64(data))
+				value = time.Unix(0, millisecond*int64(time.Millisecond))
+			}
+		case 0x0a, 0x06, 0x7f, 0xff:
+		case 0x10:
+			length = 4
+			if len(data) >= length {
+				value = int32(binary.LittleEndian.Uint32(data))
+			}
+		case 0x11, 0x12:
+			length = 8
+			if len(data) >= length {
+				value = int64(binary.LittleEndian.Uint64(data))
+			}
+		case 0x13:
+			length = 16
+		default:
+			return nil, errors.New("mongodb: BSON type " + strconv.Itoa(int(elementType)) + " is not supported!")
+		}
+		if len(data) < length {
+			return nil, illegal
+		}
+		data = data[length:]
+		document = append(document, bsonElement{key, value})
+	}
+	return document, nil
+}
+
+func (adapterMongodb *AdapterMongodb) Flush() {
+
+}
+
+//close the connection, the adapter must not be written after Close
+func (adapterMongodb *AdapterMongodb) Close() error {
+	adapterMongodb.lock.Lock()
+	defer adapterMongodb.lock.Unlock()
+
+	if adapterMongodb.conn == nil {
+		return nil
+	}
+	err := adapterMongodb.conn.Close()
+	adapterMongodb.conn = nil
+	return err
+}
+
+func (adapterMongodb *AdapterMongodb) Name() string {
+	return MONGODB_ADAPTER_NAME
+}
+
+//new empty config of the adapter, describes the config fields
+func (adapterMongodb *AdapterMongodb) NewConfig() Config {
+	return &MongodbConfig{}
+}
+
+func init() {
+	Register(MONGODB_ADAPTER_NAME, NewAdapterMongodb)
+}
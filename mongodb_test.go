@@ -0,0 +1,348 @@
+//go:build !tinygo && !logger_tiny
+// +build !tinygo,!logger_tiny
+
+package go_logger
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// command received by the fake MongoDB server
+type mongodbCommand struct {
+	database string
+	name     string
+	command  map[string]interface{}
+}
+
+// fake MongoDB server, SCRAM-SHA-256 authentication of user "logger" by password
+// the create command fails if exists, inserts into collection "reject" fail by a write error
+type mongodbServer struct {
+	listener net.Listener
+	password string
+	exists   bool
+	lock     sync.Mutex
+	commands []mongodbCommand
+	conns    []net.Conn
+}
+
+func newMongodbServer(t *testing.T, password string, exists bool) *mongodbServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	server := &mongodbServer{listener: listener, password: password, exists: exists}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			server.lock.Lock()
+			server.conns = append(server.conns, conn)
+			server.lock.Unlock()
+			go server.serve(conn)
+		}
+	}()
+	return server
+}
+
+func (server *mongodbServer) serve(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	clientFirstBare, serverFirst := "", ""
+	for {
+		header := make([]byte, 16)
+		if _, err := io.ReadFull(reader, header); err != nil {
+			return
+		}
+		body := make([]byte, binary.LittleEndian.Uint32(header[0:4])-16)
+		if _, err := io.ReadFull(reader, body); err != nil {
+			return
+		}
+		elements, err := bsonReadDocument(body[5:])
+		if err != nil {
+			return
+		}
+		command, _ := bsonDecode(body[5:])
+		database, _ := command["$db"].(string)
+		server.lock.Lock()
+		server.commands = append(server.commands, mongodbCommand{database, elements[0].key, command})
+		server.lock.Unlock()
+
+		reply := bsonDocument{{"ok", 1.0}}
+		switch elements[0].key {
+		case "saslStart":
+			payload, _ := command["payload"].([]byte)
+			clientFirstBare = strings.TrimPrefix(string(payload), "n,,")
+			serverFirst = "r=" + scramAttributes(clientFirstBare)["r"] + "server,s=" +
+				base64.StdEncoding.EncodeToString([]byte("logger salt")) + ",i=4096"
+			reply = bsonDocument{{"conversationId", int32(1)}, {"done", false}, {"payload", []byte(serverFirst)}, {"ok", 1.0}}
+		case "saslContinue":
+			payload, _ := command["payload"].([]byte)
+			clientFinal := string(payload)
+			withoutProof := clientFinal[:strings.Index(clientFinal, ",p=")]
+			authMessage := clientFirstBare + "," + serverFirst + "," + withoutProof
+			saltedPassword := pbkdf2([]byte(server.password), []byte("logger salt"), 4096, sha256.New)
+			clientKey := hmacSum(sha256.New, saltedPassword, "Client Key")
+			storedKey := sha256.Sum256(clientKey)
+			clientSignature := hmacSum(sha256.New, storedKey[:], authMessage)
+			proof := make([]byte, len(clientKey))
+			for i := range clientKey {
+				proof[i] = clientKey[i] ^ clientSignature[i]
+			}
+			if scramAttributes(clientFinal)["p"] != base64.StdEncoding.EncodeToString(proof) {
+				reply = bsonDocument{{"ok", 0.0}, {"errmsg", "Authentication failed."}, {"code", int32(18)}}
+				break
+			}
+			serverKey := hmacSum(sha256.New, saltedPassword, "Server Key")
+			serverSignature := base64.StdEncoding.EncodeToString(hmacSum(sha256.New, serverKey, authMessage))
+			reply = bsonDocument{{"conversationId", int32(1)}, {"done", true}, {"payload", []byte("v=" + serverSignature)}, {"ok", 1.0}}
+		case "create":
+			if server.exists {
+				reply = bsonDocument{{"ok", 0.0}, {"errmsg", "Collection already exists"}, {"code", int32(48)}}
+			}
+		case "insert":
+			if command["insert"] == "reject" {
+				reply = bsonDocument{{"n", int32(0)}, {"writeErrors", []interface{}{
+					bsonDocument{{"index", int32(0)}, {"code", int32(11000)}, {"errmsg", "duplicate key"}},
+				}}, {"ok", 1.0}}
+			} else {
+				reply = bsonDocument{{"n", int32(1)}, {"ok", 1.0}}
+			}
+		}
+
+		document, _ := bsonEncode(reply)
+		message := make([]byte, 21, 21+len(document))
+		binary.LittleEndian.PutUint32(message[0:4], uint32(21+len(document)))
+		copy(message[8:12], header[4:8])
+		binary.LittleEndian.PutUint32(message[12:16], mongodbOpMsg)
+		conn.Write(append(message, document...))
+	}
+}
+
+func (server *mongodbServer) received() []mongodbCommand {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+	return append([]mongodbCommand{}, server.commands...)
+}
+
+//close the accepted connections, as a restarted server
+func (server *mongodbServer) drop() {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+	for _, conn := range server.conns {
+		conn.Close()
+	}
+	server.conns = nil
+}
+
+func TestAdapterMongodb_Init(t *testing.T) {
+
+	configs := []*MongodbConfig{
+		{},
+		{Address: "127.0.0.1:27017", Database: "logs"},
+		{Address: "127.0.0.1:27017", Database: "logs", Collection: "app", AuthMechanism: "PLAIN"},
+		{Address: "127.0.0.1:27017", Database: "logs", Collection: "app", CappedSize: -1},
+	}
+	for _, config := range configs {
+		if NewAdapterMongodb().Init(config) == nil {
+			t.Errorf("mongodb adapter config %+v must be error", config)
+		}
+	}
+
+	server := newMongodbServer(t, "secret", false)
+	defer server.listener.Close()
+	err := NewAdapterMongodb().Init(&MongodbConfig{
+		Address:    server.listener.Addr().String(),
+		Database:   "logs",
+		Collection: "app",
+		Username:   "logger",
+		Password:   "wrong",
+	})
+	if mongoErr, ok := err.(*mongodbError); !ok || mongoErr.code != 18 {
+		t.Errorf("mongodb authentication error %v", err)
+	}
+}
+
+func TestAdapterMongodb_Write(t *testing.T) {
+
+	server := newMongodbServer(t, "secret", false)
+	defer server.listener.Close()
+
+	mongodbAdapter := NewAdapterMongodb()
+	err := mongodbAdapter.Init(&MongodbConfig{
+		Address:            server.listener.Addr().String(),
+		Database:           "logs",
+		Collection:         "app",
+		Username:           "logger",
+		Password:           "secret",
+		Capped:             true,
+		CappedSize:         1 << 20,
+		CappedMaxDocuments: 1000,
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer mongodbAdapter.(*AdapterMongodb).Close()
+
+	loggerMsg := &loggerMessage{
+		Millisecond: 1500000000123,
+		Level:       LoggerLevelError,
+		LevelString: "Error",
+		Body:        "logger mongodb",
+		Name:        "payments",
+		Fields: map[string]interface{}{
+			"amount": 10,
+			"tags":   []string{"a", "b"},
+			"took":   time.Second,
+		},
+	}
+	if err := mongodbAdapter.Write(loggerMsg); err != nil {
+		t.Fatal(err.Error())
+	}
+	// a restarted server, the insert sent is not sent again, the next write reconnects and authenticates again
+	server.drop()
+	if mongodbAdapter.Write(&loggerMessage{LevelString: "Info", Body: "logger mongodb dropped"}) == nil {
+		t.Error("mongodb write to a dropped connection must be error")
+	}
+	if err := mongodbAdapter.Write(&loggerMessage{LevelString: "Info", Body: "logger mongodb reconnect"}); err != nil {
+		t.Fatal(err.Error())
+	}
+	// an insert not sent is sent again on a new connection
+	mongodbAdapter.(*AdapterMongodb).conn.Close()
+	if err := mongodbAdapter.Write(&loggerMessage{LevelString: "Info", Body: "logger mongodb resend"}); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	names := []string{}
+	var create, insert map[string]interface{}
+	for _, command := range server.received() {
+		names = append(names, command.database+"."+command.name)
+		if command.name == "create" {
+			create = command.command
+		}
+		if command.name == "insert" && insert == nil {
+			insert = command.command
+		}
+	}
+	expected := "admin.saslStart,admin.saslContinue,logs.create,logs.insert," +
+		"admin.saslStart,admin.saslContinue,logs.insert,admin.saslStart,admin.saslContinue,logs.insert"
+	if strings.Join(names, ",") != expected {
+		t.Fatalf("mongodb commands %v", names)
+	}
+	if create["create"] != "app" || create["capped"] != true || create["size"] != int64(1<<20) || create["max"] != int64(1000) {
+		t.Errorf("mongodb create command %v", create)
+	}
+	documents, _ := insert["documents"].([]interface{})
+	if len(documents) != 1 {
+		t.Fatalf("mongodb insert command %v", insert)
+	}
+	document := documents[0].(map[string]interface{})
+	fields, _ := document["fields"].(map[string]interface{})
+	if document["body"] != "logger mongodb" || document["level"] != int64(LoggerLevelError) ||
+		document["level_string"] != "Error" || document["name"] != "payments" ||
+		!document["timestamp"].(time.Time).Equal(time.Unix(0, 1500000000123*int64(time.Millisecond))) {
+		t.Errorf("mongodb document %v", document)
+	}
+	if fields["amount"] != int64(10) || fields["took"] != "1s" || len(fields["tags"].([]interface{})) != 2 {
+		t.Errorf("mongodb document fields %v", fields)
+	}
+}
+
+func TestAdapterMongodb_WriteError(t *testing.T) {
+
+	server := newMongodbServer(t, "", true)
+	defer server.listener.Close()
+
+	mongodbAdapter := NewAdapterMongodb()
+	err := mongodbAdapter.Init(&MongodbConfig{
+		Address:    server.listener.Addr().String(),
+		Database:   "logs",
+		Collection: "reject",
+		Capped:     true,
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer mongodbAdapter.(*AdapterMongodb).Close()
+
+	err = mongodbAdapter.Write(&loggerMessage{LevelString: "Info", Body: "logger mongodb"})
+	if mongoErr, ok := err.(*mongodbError); !ok || mongoErr.code != 11000 {
+		t.Errorf("mongodb write error %v", err)
+	}
+	// a write error does not reconnect
+	if commands := server.received(); len(commands) != 2 {
+		t.Errorf("mongodb commands %v", commands)
+	}
+}
+
+func TestAdapterMongodb_WriteTimeout(t *testing.T) {
+
+	// the server reads the commands and never replies
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer listener.Close()
+	lock := sync.Mutex{}
+	inserts, conns := 0, 0
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			lock.Lock()
+			conns++
+			lock.Unlock()
+			go func() {
+				defer conn.Close()
+				for {
+					header := make([]byte, 16)
+					if _, err := io.ReadFull(conn, header); err != nil {
+						return
+					}
+					body := make([]byte, binary.LittleEndian.Uint32(header[0:4])-16)
+					if _, err := io.ReadFull(conn, body); err != nil {
+						return
+					}
+					if elements, err := bsonReadDocument(body[5:]); err == nil && elements[0].key == "insert" {
+						lock.Lock()
+						inserts++
+						lock.Unlock()
+					}
+				}
+			}()
+		}
+	}()
+
+	mongodbAdapter := NewAdapterMongodb()
+	err = mongodbAdapter.Init(&MongodbConfig{
+		Address:    listener.Addr().String(),
+		Database:   "logs",
+		Collection: "app",
+		Timeout:    100 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer mongodbAdapter.(*AdapterMongodb).Close()
+
+	if mongodbAdapter.Write(&loggerMessage{LevelString: "Info", Body: "logger mongodb timeout"}) == nil {
+		t.Error("mongodb read timeout must be error")
+	}
+	lock.Lock()
+	defer lock.Unlock()
+	if inserts != 1 || conns != 1 {
+		t.Errorf("mongodb insert sent must not be sent again, %d inserts, %d connections", inserts, conns)
+	}
+}
@@ -0,0 +1,454 @@
+//go:build !tinygo && !logger_tiny
+// +build !tinygo,!logger_tiny
+
+package go_logger
+
+import (
+	"bufio"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const MQTT_ADAPTER_NAME = "mqtt"
+
+// MQTT 3.1.1 control packet types
+const (
+	mqttConnect    = 1
+	mqttConnack    = 2
+	mqttPublish    = 3
+	mqttPuback     = 4
+	mqttPubrec     = 5
+	mqttPubrel     = 6
+	mqttPubcomp    = 7
+	mqttPingreq    = 12
+	mqttPingresp   = 13
+	mqttDisconnect = 14
+)
+
+// adapter mqtt, messages are published to an MQTT 3.1.1 broker, for edge and IoT devices
+// a lost connection is reconnected once per write, QoS 1 and 2 messages are published again as duplicates
+type AdapterMqtt struct {
+	lock      sync.Mutex
+	conn      net.Conn
+	reader    *bufio.Reader
+	config    *MqttConfig
+	tlsConfig *tls.Config
+	hostname  string
+	packetId  uint16
+	lastWrite time.Time
+	stop      chan struct{} // closed by Close, stops the keep alive pings
+}
+
+// mqtt config
+type MqttConfig struct {
+
+	// broker address, "127.0.0.1:1883", TLS brokers usually listen on 8883
+	Address string `config:"required"`
+
+	// connect by TLS, TLSConfig nil verifies the broker by the host of Address
+	TLS       bool
+	TLSConfig *tls.Config
+
+	// PEM client certificate and key of TLS, e.g. AWS IoT and Azure IoT Hub device certificates
+	CertFile string
+	KeyFile  string
+
+	// PEM CA certificates verifying the broker, default the system roots
+	CAFile string
+
+	// client identifier, default "go-logger-<hostname>-<pid>"
+	ClientId string
+
+	// username and password, empty is anonymous
+	Username string
+	Password string
+
+	// topic template, {hostname}, {level} and {name} are replaced by the hostname,
+	// the lower-case level and the logger name, e.g. "logs/{hostname}/{level}"
+	Topic string `config:"required"`
+
+	// QoS 0 at most once, 1 at least once, 2 exactly once, default 0
+	QoS int
+
+	// retained messages, the broker keeps the last message of the topic for new subscribers
+	Retain bool
+
+	// keep alive interval, PINGREQ is sent when idle, default 60 seconds
+	KeepAlive time.Duration
+
+	// connect, write and acknowledgement timeout, default 5 seconds
+	Timeout time.Duration
+
+	// message format, see ConsoleConfig Format, empty writes the message as JSON
+	Format string
+}
+
+// CONNACK return code of a refused connection
+type mqttConnectError byte
+
+func (err mqttConnectError) Error() string {
+	reasons := map[mqttConnectError]string{
+		1: "unacceptable protocol version",
+		2: "identifier rejected",
+		3: "server unavailable",
+		4: "bad user name or password",
+		5: "not authorized",
+	}
+	if reason, ok := reasons[err]; ok {
+		return "mqtt: connection refused, " + reason
+	}
+	return "mqtt: connection refused, return code " + strconv.Itoa(int(err))
+}
+
+func (mc *MqttConfig) Name() string {
+	return MQTT_ADAPTER_NAME
+}
+
+func NewAdapterMqtt() LoggerAbstract {
+	return &AdapterMqtt{
+		config: &MqttConfig{},
+	}
+}
+
+func (adapterMqtt *AdapterMqtt) Init(mqttConfig Config) error {
+	if mqttConfig.Name() != MQTT_ADAPTER_NAME {
+		return errors.New("logger mqtt adapter init error, config must MqttConfig")
+	}
+
+	mc := mqttConfig.(*MqttConfig)
+	adapterMqtt.config = mc
+
+	if mc.Address == "" {
+		return errors.New("config Address cannot be empty!")
+	}
+	if mc.Topic == "" {
+		return errors.New("config Topic cannot be empty!")
+	}
+	if strings.ContainsAny(mc.Topic, "+#") {
+		return errors.New("config Topic cannot contain wildcards '+', '#'!")
+	}
+	if mc.QoS < 0 || mc.QoS > 2 {
+		return errors.New("config QoS must be one of the 0, 1, 2!")
+	}
+	if mc.KeepAlive < 0 || mc.KeepAlive > 65535*time.Second || mc.Timeout < 0 {
+		return errors.New("config KeepAlive must between 0 and 65535 seconds, Timeout cannot be negative!")
+	}
+	if mc.KeepAlive == 0 {
+		mc.KeepAlive = 60 * time.Second
+	}
+	if mc.Timeout == 0 {
+		mc.Timeout = 5 * time.Second
+	}
+	adapterMqtt.hostname, _ = os.Hostname()
+	if adapterMqtt.hostname == "" {
+		adapterMqtt.hostname = "localhost"
+	}
+	if mc.ClientId == "" {
+		mc.ClientId = "go-logger-" + adapterMqtt.hostname + "-" + strconv.Itoa(os.Getpid())
+	}
+	if mc.TLS {
+		tlsConfig, err := adapterMqtt.loadTLSConfig()
+		if err != nil {
+			return err
+		}
+		adapterMqtt.tlsConfig = tlsConfig
+	}
+
+	adapterMqtt.lock.Lock()
+	err := adapterMqtt.connect()
+	adapterMqtt.lock.Unlock()
+	if err != nil {
+		return err
+	}
+	adapterMqtt.stop = make(chan struct{})
+	go adapterMqtt.keepAlive(adapterMqtt.stop)
+	return nil
+}
+
+//TLS config with the client certificate and the CA certificates
+func (adapterMqtt *AdapterMqtt) loadTLSConfig() (*tls.Config, error) {
+	config := adapterMqtt.config
+	tlsConfig := config.TLSConfig
+	if tlsConfig == nil {
+		host, _, _ := net.SplitHostPort(config.Address)
+		tlsConfig = &tls.Config{ServerName: host}
+	} else {
+		tlsConfig = tlsConfig.Clone()
+	}
+	if config.CertFile != "" || config.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(config.CertFile, config.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = append(tlsConfig.Certificates, cert)
+	}
+	if config.CAFile != "" {
+		data, err := ioutil.ReadFile(config.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, errors.New("config CAFile " + config.CAFile + " has no PEM certificates!")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	return tlsConfig, nil
+}
+
+//connect and send CONNECT, called with lock held
+func (adapterMqtt *AdapterMqtt) connect() error {
+	config := adapterMqtt.config
+	conn, err := net.DialTimeout("tcp", config.Address, config.Timeout)
+	if err != nil {
+		return err
+	}
+	if adapterMqtt.tlsConfig != nil {
+		conn = tls.Client(conn, adapterMqtt.tlsConfig)
+	}
+
+	// clean session, the session state is the pending acknowledgement of a write only
+	flags := byte(0x02)
+	payload := mqttString(config.ClientId)
+	if config.Username != "" {
+		flags |= 0x80
+		payload = append(payload, mqttString(config.Username)...)
+	}
+	if config.Password != "" {
+		flags |= 0x40
+		payload = append(payload, mqttString(config.Password)...)
+	}
+	keepAlive := int(config.KeepAlive / time.Second)
+	variable := append(mqttString("MQTT"), 4, flags, byte(keepAlive>>8), byte(keepAlive))
+
+	adapterMqtt.conn = conn
+	adapterMqtt.reader = bufio.NewReader(conn)
+	err = adapterMqtt.writePacket(mqttConnect<<4, append(variable, payload...))
+	if err == nil {
+		var body []byte
+		body, err = adapterMqtt.readPacket(mqttConnack, 0, false)
+		if err == nil && len(body) == 2 && body[1] != 0 {
+			err = mqttConnectError(body[1])
+		}
+	}
+	if err != nil {
+		conn.Close()
+		adapterMqtt.conn = nil
+		return err
+	}
+	return nil
+}
+
+func (adapterMqtt *AdapterMqtt) Write(loggerMsg *loggerMessage) error {
+	config := adapterMqtt.config
+	message := ""
+	if config.Format == "" {
+		message = loggerMsg.formatJson("")
+	} else {
+		message = loggerMsg.formatText(config.Format)
+	}
+	topic := strings.NewReplacer(
+		"{hostname}", adapterMqtt.hostname,
+		"{level}", strings.ToLower(loggerMsg.LevelString),
+		"{name}", loggerMsg.Name,
+	).Replace(config.Topic)
+
+	adapterMqtt.lock.Lock()
+	defer adapterMqtt.lock.Unlock()
+
+	adapterMqtt.packetId++
+	if adapterMqtt.packetId == 0 {
+		adapterMqtt.packetId = 1
+	}
+	if adapterMqtt.conn != nil {
+		err := adapterMqtt.publish(topic, message, false)
+		if err == nil {
+			return nil
+		}
+		adapterMqtt.conn.Close()
+		adapterMqtt.conn = nil
+	}
+	if err := adapterMqtt.connect(); err != nil {
+		return err
+	}
+	return adapterMqtt.publish(topic, message, true)
+}
+
+//send PUBLISH and wait for the acknowledgement of QoS 1 (PUBACK) or QoS 2 (PUBREC, PUBREL, PUBCOMP)
+//called with lock held
+func (adapterMqtt *AdapterMqtt) publish(topic string, message string, dup bool) error {
+	config := adapterMqtt.config
+	header := byte(mqttPublish<<4) | byte(config.QoS<<1)
+	if dup && config.QoS > 0 {
+		header |= 0x08
+	}
+	if config.Retain {
+		header |= 0x01
+	}
+	body := mqttString(topic)
+	packetId := []byte{byte(adapterMqtt.packetId >> 8), byte(adapterMqtt.packetId)}
+	if config.QoS > 0 {
+		body = append(body, packetId...)
+	}
+	body = append(body, message...)
+	if err := adapterMqtt.writePacket(header, body); err != nil {
+		return err
+	}
+
+	switch config.QoS {
+	case 1:
+		_, err := adapterMqtt.readPacket(mqttPuback, adapterMqtt.packetId, true)
+		return err
+	case 2:
+		if _, err := adapterMqtt.readPacket(mqttPubrec, adapterMqtt.packetId, true); err != nil {
+			return err
+		}
+		if err := adapterMqtt.writePacket(mqttPubrel<<4|0x02, packetId); err != nil {
+			return err
+		}
+		_, err := adapterMqtt.readPacket(mqttPubcomp, adapterMqtt.packetId, true)
+		return err
+	}
+	return nil
+}
+
+//write a control packet, fixed header, remaining length and body, called with lock held
+func (adapterMqtt *AdapterMqtt) writePacket(header byte, body []byte) error {
+	if len(body) > 268435455 {
+		return errors.New("mqtt: packet of " + strconv.Itoa(len(body)) + " bytes exceeds the max remaining length!")
+	}
+	packet := make([]byte, 0, 5+len(body))
+	packet = append(packet, header)
+	for length := len(body); ; {
+		digit := byte(length % 128)
+		length /= 128
+		if length > 0 {
+			digit |= 0x80
+		}
+		packet = append(packet, digit)
+		if length == 0 {
+			break
+		}
+	}
+	packet = append(packet, body...)
+	adapterMqtt.conn.SetWriteDeadline(time.Now().Add(adapterMqtt.config.Timeout))
+	_, err := adapterMqtt.conn.Write(packet)
+	adapterMqtt.lastWrite = time.Now()
+	return err
+}
+
+//read control packets until the packet type, and the packet id if hasId, PINGRESP is skipped
+//called with lock held
+func (adapterMqtt *AdapterMqtt) readPacket(packetType byte, packetId uint16, hasId bool) ([]byte, error) {
+	adapterMqtt.conn.SetReadDeadline(time.Now().Add(adapterMqtt.config.Timeout))
+	for {
+		header, err := adapterMqtt.reader.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+		length, multiplier := 0, 1
+		for i := 0; ; i++ {
+			digit, err := adapterMqtt.reader.ReadByte()
+			if err != nil {
+				return nil, err
+			}
+			if i == 4 {
+				return nil, errors.New("mqtt: remaining length is illegal!")
+			}
+			length += int(digit&0x7f) * multiplier
+			multiplier *= 128
+			if digit&0x80 == 0 {
+				break
+			}
+		}
+		body := make([]byte, length)
+		if _, err := io.ReadFull(adapterMqtt.reader, body); err != nil {
+			return nil, err
+		}
+		if header>>4 != packetType {
+			continue
+		}
+		if hasId && (len(body) < 2 || uint16(body[0])<<8|uint16(body[1]) != packetId) {
+			continue
+		}
+		return body, nil
+	}
+}
+
+//send PINGREQ when no packet was sent for half of the keep alive interval, until stop is closed
+//a failed ping closes the connection, the next write reconnects
+func (adapterMqtt *AdapterMqtt) keepAlive(stop chan struct{}) {
+	interval := adapterMqtt.config.KeepAlive / 2
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+		adapterMqtt.lock.Lock()
+		if adapterMqtt.conn != nil && time.Since(adapterMqtt.lastWrite) >= interval {
+			err := adapterMqtt.writePacket(mqttPingreq<<4, nil)
+			if err == nil {
+				_, err = adapterMqtt.readPacket(mqttPingresp, 0, false)
+			}
+			if err != nil {
+				adapterMqtt.conn.Close()
+				adapterMqtt.conn = nil
+			}
+		}
+		adapterMqtt.lock.Unlock()
+	}
+}
+
+//UTF-8 string of the MQTT encoding, 2 bytes length prefixed
+func mqttString(s string) []byte {
+	b := make([]byte, 0, 2+len(s))
+	b = append(b, byte(len(s)>>8), byte(len(s)))
+	return append(b, s...)
+}
+
+func (adapterMqtt *AdapterMqtt) Flush() {
+
+}
+
+//send DISCONNECT and close the connection, the adapter must not be written after Close
+func (adapterMqtt *AdapterMqtt) Close() error {
+	adapterMqtt.lock.Lock()
+	defer adapterMqtt.lock.Unlock()
+
+	if adapterMqtt.stop != nil {
+		close(adapterMqtt.stop)
+		adapterMqtt.stop = nil
+	}
+	if adapterMqtt.conn == nil {
+		return nil
+	}
+	adapterMqtt.writePacket(mqttDisconnect<<4, nil)
+	err := adapterMqtt.conn.Close()
+	adapterMqtt.conn = nil
+	return err
+}
+
+func (adapterMqtt *AdapterMqtt) Name() string {
+	return MQTT_ADAPTER_NAME
+}
+
+//new empty config of the adapter, describes the config fields
+func (adapterMqtt *AdapterMqtt) NewConfig() Config {
+	return &MqttConfig{}
+}
+
+func init() {
+	Register(MQTT_ADAPTER_NAME, NewAdapterMqtt)
+}
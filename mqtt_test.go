@@ -0,0 +1,255 @@
+//go:build !tinygo && !logger_tiny
+// +build !tinygo,!logger_tiny
+
+package go_logger
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// message published to the fake MQTT broker
+type mqttPublished struct {
+	topic   string
+	qos     int
+	retain  bool
+	dup     bool
+	payload string
+}
+
+// fake MQTT 3.1.1 broker, refuses connections by code if not 0
+type mqttServer struct {
+	listener  net.Listener
+	lock      sync.Mutex
+	code      byte
+	connects  []string
+	published []mqttPublished
+	pings     int
+	conns     []net.Conn
+}
+
+func newMqttServer(t *testing.T, code byte) *mqttServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	server := &mqttServer{listener: listener, code: code}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			server.lock.Lock()
+			server.conns = append(server.conns, conn)
+			server.lock.Unlock()
+			go server.serve(conn)
+		}
+	}()
+	return server
+}
+
+func (server *mqttServer) serve(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	for {
+		header, err := reader.ReadByte()
+		if err != nil {
+			return
+		}
+		length, multiplier := 0, 1
+		for {
+			digit, err := reader.ReadByte()
+			if err != nil {
+				return
+			}
+			length += int(digit&0x7f) * multiplier
+			multiplier *= 128
+			if digit&0x80 == 0 {
+				break
+			}
+		}
+		body := make([]byte, length)
+		if _, err := io.ReadFull(reader, body); err != nil {
+			return
+		}
+
+		switch header >> 4 {
+		case mqttConnect:
+			// protocol name, level, flags, keep alive, then the payload strings
+			fields := []string{}
+			for payload := body[10:]; len(payload) >= 2; {
+				size := int(payload[0])<<8 | int(payload[1])
+				fields = append(fields, string(payload[2:2+size]))
+				payload = payload[2+size:]
+			}
+			server.lock.Lock()
+			server.connects = append(server.connects, strings.Join(fields, ","))
+			server.lock.Unlock()
+			conn.Write([]byte{mqttConnack << 4, 2, 0, server.code})
+		case mqttPublish:
+			qos := int(header>>1) & 3
+			size := int(body[0])<<8 | int(body[1])
+			published := mqttPublished{
+				topic:  string(body[2 : 2+size]),
+				qos:    qos,
+				retain: header&0x01 == 0x01,
+				dup:    header&0x08 == 0x08,
+			}
+			rest := body[2+size:]
+			if qos > 0 {
+				packetId := rest[:2]
+				rest = rest[2:]
+				if qos == 1 {
+					conn.Write([]byte{mqttPuback << 4, 2, packetId[0], packetId[1]})
+				} else {
+					conn.Write([]byte{mqttPubrec << 4, 2, packetId[0], packetId[1]})
+				}
+			}
+			published.payload = string(rest)
+			server.lock.Lock()
+			server.published = append(server.published, published)
+			server.lock.Unlock()
+		case mqttPubrel:
+			conn.Write([]byte{mqttPubcomp << 4, 2, body[0], body[1]})
+		case mqttPingreq:
+			server.lock.Lock()
+			server.pings++
+			server.lock.Unlock()
+			conn.Write([]byte{mqttPingresp << 4, 0})
+		case mqttDisconnect:
+			return
+		}
+	}
+}
+
+func (server *mqttServer) received() ([]string, []mqttPublished, int) {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+	return append([]string{}, server.connects...), append([]mqttPublished{}, server.published...), server.pings
+}
+
+//close the accepted connections, as a restarted broker
+func (server *mqttServer) drop() {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+	for _, conn := range server.conns {
+		conn.Close()
+	}
+	server.conns = nil
+}
+
+func TestAdapterMqtt_Init(t *testing.T) {
+
+	configs := []*MqttConfig{
+		{},
+		{Address: "127.0.0.1:1883"},
+		{Address: "127.0.0.1:1883", Topic: "logs/#"},
+		{Address: "127.0.0.1:1883", Topic: "logs", QoS: 3},
+		{Address: "127.0.0.1:1883", Topic: "logs", KeepAlive: -time.Second},
+		{Address: "127.0.0.1:1883", Topic: "logs", TLS: true, CertFile: "not_exists.pem", KeyFile: "not_exists.key"},
+	}
+	for _, config := range configs {
+		if NewAdapterMqtt().Init(config) == nil {
+			t.Errorf("mqtt adapter config %+v must be error", config)
+		}
+	}
+
+	server := newMqttServer(t, 5)
+	defer server.listener.Close()
+	err := NewAdapterMqtt().Init(&MqttConfig{Address: server.listener.Addr().String(), Topic: "logs"})
+	if _, ok := err.(mqttConnectError); !ok {
+		t.Errorf("mqtt refused connection error %v", err)
+	}
+}
+
+func TestAdapterMqtt_Write(t *testing.T) {
+
+	server := newMqttServer(t, 0)
+	defer server.listener.Close()
+
+	mqttAdapter := NewAdapterMqtt()
+	err := mqttAdapter.Init(&MqttConfig{
+		Address:  server.listener.Addr().String(),
+		ClientId: "device-1",
+		Username: "device",
+		Password: "secret",
+		Topic:    "logs/{hostname}/{level}",
+		QoS:      1,
+		Retain:   true,
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer mqttAdapter.(*AdapterMqtt).Close()
+
+	if err := mqttAdapter.Write(&loggerMessage{LevelString: "Error", Body: "logger mqtt 1"}); err != nil {
+		t.Fatal(err.Error())
+	}
+	// a restarted broker, the next write reconnects and publishes again as duplicate
+	server.drop()
+	if err := mqttAdapter.Write(&loggerMessage{LevelString: "Info", Body: "logger mqtt 2"}); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	hostname, _ := os.Hostname()
+	connects, published, _ := server.received()
+	if len(connects) != 2 || connects[0] != "device-1,device,secret" {
+		t.Errorf("mqtt connects %q", connects)
+	}
+	if len(published) != 2 {
+		t.Fatalf("mqtt published %d messages, expected 2", len(published))
+	}
+	if published[0].topic != "logs/"+hostname+"/error" || published[0].qos != 1 || !published[0].retain ||
+		published[0].dup || !strings.Contains(published[0].payload, `"body":"logger mqtt 1"`) {
+		t.Errorf("mqtt published %+v", published[0])
+	}
+	if published[1].topic != "logs/"+hostname+"/info" || !published[1].dup {
+		t.Errorf("mqtt published %+v", published[1])
+	}
+}
+
+func TestAdapterMqtt_WriteQoS2(t *testing.T) {
+
+	server := newMqttServer(t, 0)
+	defer server.listener.Close()
+
+	mqttAdapter := NewAdapterMqtt()
+	err := mqttAdapter.Init(&MqttConfig{
+		Address:   server.listener.Addr().String(),
+		Topic:     "logs/{name}",
+		QoS:       2,
+		KeepAlive: time.Second,
+		Format:    "%body%",
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer mqttAdapter.(*AdapterMqtt).Close()
+
+	for i := 0; i < 3; i++ {
+		if err := mqttAdapter.Write(&loggerMessage{Name: "payments", Body: "logger mqtt"}); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+	// idle for the keep alive interval, PINGREQ is sent
+	time.Sleep(1500 * time.Millisecond)
+
+	connects, published, pings := server.received()
+	if len(connects) != 1 || !strings.HasPrefix(connects[0], "go-logger-") {
+		t.Errorf("mqtt connects %q", connects)
+	}
+	if len(published) != 3 || published[2].topic != "logs/payments" || published[2].qos != 2 ||
+		published[2].payload != "logger mqtt" {
+		t.Errorf("mqtt published %+v", published)
+	}
+	if pings == 0 {
+		t.Error("mqtt keep alive ping not sent")
+	}
+}
@@ -0,0 +1,107 @@
+package go_logger
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// separator of nested child names, "payments.stripe"
+const loggerNameSeparator = "."
+
+//new named child, shares outputs of the logger
+//the name is in LoggerMessage.Name and format "%name%", the default format prefixes the body, "payments: charged"
+//params : name string
+//return : *Entry
+func (logger *Logger) Named(name string) *Entry {
+	return &Entry{logger: logger, name: name}
+}
+
+//new named child of the entry, name is appended to the entry name
+//fields, context and level are inherited
+//params : name string
+//return : *Entry
+func (entry *Entry) Named(name string) *Entry {
+	child := *entry
+	if entry.name != "" && name != "" {
+		child.name = entry.name + loggerNameSeparator + name
+	} else if name != "" {
+		child.name = name
+	}
+	return &child
+}
+
+//set the level of a named child and its descendants, "" is the root level
+//a child without a level inherits the level of the nearest parent, "a.b.c" from "a.b", then "a", then the root
+//less severe messages are dropped before dispatch, outputs still filter by their own level
+//the root level applies to named children only, messages of the logger itself are filtered by outputs
+//a WithLevel override of the entry takes precedence over named levels
+//params : name string, level int
+//return : error
+func (logger *Logger) SetNamedLevel(name string, level int) error {
+	if _, ok := levelStringMapping[level]; !ok {
+		return errors.New("logger: level " + strconv.Itoa(level) + " is illegal!")
+	}
+	logger.lock.Lock()
+	defer logger.lock.Unlock()
+
+	levels := logger.copyNamedLevels()
+	levels[name] = level
+	logger.namedLevels.Store(levels)
+	return nil
+}
+
+//remove the level of a named child, it inherits the level of its parent again
+//params : name string
+func (logger *Logger) RemoveNamedLevel(name string) {
+	logger.lock.Lock()
+	defer logger.lock.Unlock()
+
+	levels := logger.copyNamedLevels()
+	delete(levels, name)
+	logger.namedLevels.Store(levels)
+}
+
+//copy of the named levels, called with lock held
+func (logger *Logger) copyNamedLevels() map[string]int {
+	current, _ := logger.namedLevels.Load().(map[string]int)
+	levels := make(map[string]int, len(current)+1)
+	for name, level := range current {
+		levels[name] = level
+	}
+	return levels
+}
+
+//resolved level of a named child, the level of the name, else of the nearest parent, else the root level
+//params : name string
+//return : int level, bool false if neither the name, its parents nor the root has a level
+func (logger *Logger) NamedLevel(name string) (int, bool) {
+	levels, _ := logger.namedLevels.Load().(map[string]int)
+	if len(levels) == 0 {
+		return 0, false
+	}
+	for {
+		if level, ok := levels[name]; ok {
+			return level, true
+		}
+		if name == "" {
+			return 0, false
+		}
+		if index := strings.LastIndex(name, loggerNameSeparator); index >= 0 {
+			name = name[:index]
+		} else {
+			name = ""
+		}
+	}
+}
+
+//new entry with level override, less severe messages are dropped before dispatch
+//outputs still filter by their own level
+//params : level int
+//return : *Entry
+func (entry *Entry) WithLevel(level int) *Entry {
+	child := *entry
+	child.level = level
+	child.hasLevel = true
+	return &child
+}
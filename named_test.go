@@ -0,0 +1,129 @@
+package go_logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestLogger_Named(t *testing.T) {
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("console", LoggerLevelDebug, &ConsoleConfig{
+		Format: "[%name%] %body% %fields%",
+	})
+	buffer := &bytes.Buffer{}
+	logger.outputList()[0].LoggerAbstract.(*AdapterConsole).write.writer = buffer
+
+	payments := logger.Named("payments")
+	payments.Info("logger named")
+	payments.WithField("id", 1).Named("stripe").Infow("logger named nested", "amount", 10)
+	logger.Info("logger not named")
+	expected := "[payments] logger named \n" +
+		"[payments.stripe] logger named nested amount=10 id=1\n" +
+		"[] logger not named \n"
+	if buffer.String() != expected {
+		t.Error("named child logger error: " + buffer.String())
+	}
+
+	buffer.Reset()
+	logger.Detach("console")
+	logger.Attach("console", LoggerLevelDebug, &ConsoleConfig{})
+	logger.outputList()[0].LoggerAbstract.(*AdapterConsole).write.writer = buffer
+	payments.Info("charged")
+	if !strings.HasSuffix(buffer.String(), " [Info] payments: charged\n") {
+		t.Error("default format must prefix the body with the name: " + buffer.String())
+	}
+}
+
+func TestEntry_WithLevel(t *testing.T) {
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("console", LoggerLevelDebug, &ConsoleConfig{
+		Format: "%name% %body%",
+	})
+	buffer := &bytes.Buffer{}
+	logger.outputList()[0].LoggerAbstract.(*AdapterConsole).write.writer = buffer
+
+	db := logger.Named("db").WithLevel(LoggerLevelWarning)
+	db.Info("logger child info")
+	db.Debugf("logger child %s", "debug")
+	db.Warning("logger child warning")
+	db.Named("pool").Error("logger grandchild error")
+	db.Named("pool").WithLevel(LoggerLevelDebug).Debug("logger grandchild debug")
+	expected := "db logger child warning\n" +
+		"db.pool logger grandchild error\n" +
+		"db.pool logger grandchild debug\n"
+	if buffer.String() != expected {
+		t.Error("child logger level error: " + buffer.String())
+	}
+}
+
+func TestLogger_SetNamedLevel(t *testing.T) {
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("console", LoggerLevelDebug, &ConsoleConfig{
+		Format: "%name% %body%",
+	})
+	buffer := &bytes.Buffer{}
+	logger.outputList()[0].LoggerAbstract.(*AdapterConsole).write.writer = buffer
+
+	if logger.SetNamedLevel("db", 8) == nil {
+		t.Error("named level 8 must be error")
+	}
+	logger.SetNamedLevel("", LoggerLevelError)
+	logger.SetNamedLevel("db", LoggerLevelWarning)
+	logger.SetNamedLevel("db.pool.conn", LoggerLevelDebug)
+
+	logger.Named("http").Warning("logger root warning")
+	logger.Named("http").Error("logger root error")
+	logger.Named("db").Named("pool").Info("logger parent info")
+	logger.Named("db.pool").Warning("logger parent warning")
+	logger.Named("db").Named("pool").Named("conn").Debug("logger own debug")
+	logger.Named("dbx").Warning("logger prefix warning")
+	logger.Named("db").WithLevel(LoggerLevelDebug).Debug("logger override debug")
+	logger.Info("logger unnamed info")
+
+	logger.RemoveNamedLevel("db")
+	logger.Named("db.pool").Warning("logger removed warning")
+	logger.Named("db.pool").Error("logger removed error")
+
+	expected := "http logger root error\n" +
+		"db.pool logger parent warning\n" +
+		"db.pool.conn logger own debug\n" +
+		"db logger override debug\n" +
+		" logger unnamed info\n" +
+		"db.pool logger removed error\n"
+	if buffer.String() != expected {
+		t.Error("named level error: " + buffer.String())
+	}
+
+	if level, ok := logger.NamedLevel("db.pool.conn.tx"); !ok || level != LoggerLevelDebug {
+		t.Errorf("named level of db.pool.conn.tx %d %v", level, ok)
+	}
+	logger.RemoveNamedLevel("")
+	if _, ok := logger.NamedLevel("db.pool"); ok {
+		t.Error("named level of db.pool must not be set")
+	}
+}
+
+func TestLogger_NamedJson(t *testing.T) {
+
+	loggerMsg := &loggerMessage{Body: "logger named json", Name: "payments"}
+	data, err := json.Marshal(loggerMsg)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	decoded := &loggerMessage{}
+	if err := json.Unmarshal(data, decoded); err != nil || decoded.Name != "payments" {
+		t.Errorf("named message json error: %s", string(data))
+	}
+	data, _ = json.Marshal(&loggerMessage{Body: "logger not named json"})
+	if bytes.Contains(data, []byte(`"name"`)) {
+		t.Errorf("not named message json must omit name: %s", string(data))
+	}
+}
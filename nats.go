@@ -0,0 +1,448 @@
+//go:build !tinygo && !logger_tiny
+// +build !tinygo,!logger_tiny
+
+package go_logger
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const NATS_ADAPTER_NAME = "nats"
+
+// adapter nats, messages are published to a subject by the NATS text protocol
+// with JetStream, every publish waits for the stream acknowledgement
+type AdapterNats struct {
+	lock   sync.Mutex
+	conn   *natsConn
+	config *NatsConfig
+	inbox  string // reply subject prefix of JetStream acknowledgements
+	seq    int64  // sequence of the reply subjects
+}
+
+// connection to a server, read by a goroutine answering PING
+type natsConn struct {
+	conn      net.Conn
+	writeLock sync.Mutex
+	pongs     chan struct{}
+	acks      chan natsMsg
+	done      chan struct{} // closed when the connection is lost
+	err       error         // error of the lost connection, read after done
+}
+
+// message received on the inbox
+type natsMsg struct {
+	subject string
+	payload string
+}
+
+// nats config
+type NatsConfig struct {
+
+	// server addresses, tried in order on connect and reconnect, "127.0.0.1:4222"
+	Servers []string `config:"required"`
+
+	// subject, %level_string% is replaced by the lower-case level, e.g. "logs.app.%level_string%"
+	Subject string `config:"required"`
+
+	// user and password, or token authentication, empty does not authenticate
+	User     string
+	Password string
+	Token    string
+
+	// connect by TLS, TLSConfig nil verifies the server by its host
+	TLS       bool
+	TLSConfig *tls.Config
+
+	// publish to JetStream and wait for the acknowledgement, the subject must be bound to a stream
+	JetStream bool
+
+	// connect, write and acknowledgement timeout, default 5 seconds
+	Timeout time.Duration
+
+	// reconnect attempts of a failed publish, each tries all Servers, default 3
+	ReconnectAttempts int
+
+	// wait between reconnect attempts, default 200 milliseconds
+	ReconnectWait time.Duration
+
+	// message format, see ConsoleConfig Format, empty writes the message as JSON
+	Format string
+}
+
+func (nc *NatsConfig) Name() string {
+	return NATS_ADAPTER_NAME
+}
+
+func NewAdapterNats() LoggerAbstract {
+	return &AdapterNats{
+		config: &NatsConfig{},
+	}
+}
+
+func (adapterNats *AdapterNats) Init(natsConfig Config) error {
+	if natsConfig.Name() != NATS_ADAPTER_NAME {
+		return errors.New("logger nats adapter init error, config must NatsConfig")
+	}
+
+	nc := natsConfig.(*NatsConfig)
+	adapterNats.config = nc
+
+	if len(nc.Servers) == 0 {
+		return errors.New("config Servers cannot be empty!")
+	}
+	if nc.Subject == "" || strings.ContainsAny(nc.Subject, " \t\r\n") {
+		return errors.New("config Subject cannot be empty or contain spaces!")
+	}
+	if nc.Timeout < 0 || nc.ReconnectAttempts < 0 || nc.ReconnectWait < 0 {
+		return errors.New("config Timeout, ReconnectAttempts and ReconnectWait cannot be negative!")
+	}
+	if nc.Timeout == 0 {
+		nc.Timeout = 5 * time.Second
+	}
+	if nc.ReconnectAttempts == 0 {
+		nc.ReconnectAttempts = 3
+	}
+	if nc.ReconnectWait == 0 {
+		nc.ReconnectWait = 200 * time.Millisecond
+	}
+	adapterNats.inbox = "_INBOX.logger." + strconv.FormatInt(time.Now().UnixNano(), 36)
+
+	adapterNats.lock.Lock()
+	defer adapterNats.lock.Unlock()
+	return adapterNats.connect()
+}
+
+//connect to the first reachable server, called with lock held
+func (adapterNats *AdapterNats) connect() error {
+	var err error
+	for _, server := range adapterNats.config.Servers {
+		if err = adapterNats.connectServer(server); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+//connect to a server: read INFO, send CONNECT and PING, wait for PONG
+func (adapterNats *AdapterNats) connectServer(server string) error {
+	config := adapterNats.config
+	conn, err := net.DialTimeout("tcp", server, config.Timeout)
+	if err != nil {
+		return err
+	}
+	conn.SetDeadline(time.Now().Add(config.Timeout))
+	reader := bufio.NewReader(conn)
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	if !strings.HasPrefix(line, "INFO ") {
+		conn.Close()
+		return errors.New("nats: unexpected " + strconv.Quote(line) + " from " + server)
+	}
+	if config.TLS {
+		tlsConfig := config.TLSConfig
+		if tlsConfig == nil {
+			host, _, _ := net.SplitHostPort(server)
+			tlsConfig = &tls.Config{ServerName: host}
+		}
+		conn = tls.Client(conn, tlsConfig)
+		reader = bufio.NewReader(conn)
+	}
+
+	options := map[string]interface{}{
+		"verbose":  false,
+		"pedantic": false,
+		"name":     "go-logger",
+		"lang":     "go",
+		"protocol": 1,
+	}
+	if config.User != "" {
+		options["user"] = config.User
+		options["pass"] = config.Password
+	}
+	if config.Token != "" {
+		options["auth_token"] = config.Token
+	}
+	connect, _ := json.Marshal(options)
+	request := "CONNECT " + string(connect) + "\r\n"
+	if config.JetStream {
+		request += "SUB " + adapterNats.inbox + ".* 1\r\n"
+	}
+	nc := &natsConn{
+		conn:  conn,
+		pongs: make(chan struct{}, 1),
+		acks:  make(chan natsMsg, 1),
+		done:  make(chan struct{}),
+	}
+	if err := nc.write(request, config.Timeout); err != nil {
+		conn.Close()
+		return err
+	}
+	conn.SetDeadline(time.Time{})
+	go nc.read(reader)
+
+	// an authentication error closes the connection before PONG
+	if err := nc.ping(config.Timeout); err != nil {
+		conn.Close()
+		return err
+	}
+	adapterNats.conn = nc
+	return nil
+}
+
+//write to the connection, PONG replies of the reader are written concurrently
+func (nc *natsConn) write(data string, timeout time.Duration) error {
+	nc.writeLock.Lock()
+	defer nc.writeLock.Unlock()
+
+	nc.conn.SetWriteDeadline(time.Now().Add(timeout))
+	_, err := nc.conn.Write([]byte(data))
+	return err
+}
+
+//read the connection until closed, answer PING, deliver PONG and inbox messages
+func (nc *natsConn) read(reader *bufio.Reader) {
+	defer close(nc.done)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			// keep the -ERR the server sent before closing
+			if nc.err == nil {
+				nc.err = err
+			}
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		switch {
+		case line == "PING":
+			nc.write("PONG\r\n", 5*time.Second)
+		case line == "PONG":
+			select {
+			case nc.pongs <- struct{}{}:
+			default:
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			// the server closes the connection after most errors
+			if nc.err == nil {
+				nc.err = errors.New("nats: " + line)
+			}
+		case strings.HasPrefix(line, "MSG "):
+			// MSG <subject> <sid> [reply-to] <#bytes>
+			parts := strings.Fields(line)
+			size, err := strconv.Atoi(parts[len(parts)-1])
+			if err != nil || len(parts) < 4 {
+				nc.err = errors.New("nats: " + line + " is illegal!")
+				nc.conn.Close()
+				return
+			}
+			payload := make([]byte, size+2)
+			if _, err := io.ReadFull(reader, payload); err != nil {
+				nc.err = err
+				return
+			}
+			msg := natsMsg{subject: parts[1], payload: string(payload[:size])}
+			// an acknowledgement nobody waits for is replaced
+			select {
+			case nc.acks <- msg:
+			default:
+				select {
+				case <-nc.acks:
+				default:
+				}
+				nc.acks <- msg
+			}
+		}
+	}
+}
+
+//round trip a PING
+func (nc *natsConn) ping(timeout time.Duration) error {
+	if err := nc.write("PING\r\n", timeout); err != nil {
+		return err
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-nc.pongs:
+		return nil
+	case <-nc.done:
+		return nc.lostError()
+	case <-timer.C:
+		return errors.New("nats: PING timeout")
+	}
+}
+
+//error of the lost connection
+func (nc *natsConn) lostError() error {
+	if nc.err != nil {
+		return nc.err
+	}
+	return errors.New("nats: connection closed")
+}
+
+func (adapterNats *AdapterNats) Write(loggerMsg *loggerMessage) error {
+	config := adapterNats.config
+	message := ""
+	if config.Format == "" {
+		message = loggerMsg.formatJson("")
+	} else {
+		message = loggerMsg.formatText(config.Format)
+	}
+	subject := strings.Replace(config.Subject, "%level_string%", strings.ToLower(loggerMsg.LevelString), -1)
+
+	adapterNats.lock.Lock()
+	defer adapterNats.lock.Unlock()
+
+	err := adapterNats.publish(subject, message)
+	for attempt := 0; err != nil && attempt < config.ReconnectAttempts; attempt++ {
+		// a JetStream error reply is not a connection failure
+		if _, ok := err.(natsJetStreamError); ok {
+			return err
+		}
+		adapterNats.close()
+		if attempt > 0 {
+			time.Sleep(config.ReconnectWait)
+		}
+		if err = adapterNats.connect(); err != nil {
+			continue
+		}
+		err = adapterNats.publish(subject, message)
+	}
+	return err
+}
+
+// error of a JetStream acknowledgement
+type natsJetStreamError string
+
+func (err natsJetStreamError) Error() string {
+	return "nats: jetstream " + string(err)
+}
+
+//publish a message, wait for the JetStream acknowledgement, called with lock held
+func (adapterNats *AdapterNats) publish(subject string, message string) error {
+	nc := adapterNats.conn
+	if nc == nil {
+		return errors.New("nats: not connected")
+	}
+	select {
+	case <-nc.done:
+		return nc.lostError()
+	default:
+	}
+	config := adapterNats.config
+	if !config.JetStream {
+		return nc.write("PUB "+subject+" "+strconv.Itoa(len(message))+"\r\n"+message+"\r\n", config.Timeout)
+	}
+
+	adapterNats.seq++
+	reply := adapterNats.inbox + "." + strconv.FormatInt(adapterNats.seq, 10)
+	err := nc.write("PUB "+subject+" "+reply+" "+strconv.Itoa(len(message))+"\r\n"+message+"\r\n", config.Timeout)
+	if err != nil {
+		return err
+	}
+	timer := time.NewTimer(config.Timeout)
+	defer timer.Stop()
+	for {
+		select {
+		case msg := <-nc.acks:
+			// late acknowledgement of a timed out publish
+			if msg.subject != reply {
+				continue
+			}
+			return natsAck(subject, msg.payload)
+		case <-nc.done:
+			return nc.lostError()
+		case <-timer.C:
+			return errors.New("nats: jetstream acknowledgement timeout")
+		}
+	}
+}
+
+//error of a JetStream acknowledgement payload
+func natsAck(subject string, payload string) error {
+	ack := struct {
+		Stream string `json:"stream"`
+		Error  *struct {
+			Code        int    `json:"code"`
+			Description string `json:"description"`
+		} `json:"error"`
+	}{}
+	if err := json.Unmarshal([]byte(payload), &ack); err != nil {
+		return natsJetStreamError("ack " + strconv.Quote(payload) + " is illegal")
+	}
+	if ack.Error != nil {
+		return natsJetStreamError(ack.Error.Description + ", code=" + strconv.Itoa(ack.Error.Code))
+	}
+	if ack.Stream == "" {
+		return natsJetStreamError("no stream acknowledged subject " + subject)
+	}
+	return nil
+}
+
+//close the connection, called with lock held
+func (adapterNats *AdapterNats) close() error {
+	if adapterNats.conn == nil {
+		return nil
+	}
+	err := adapterNats.conn.conn.Close()
+	adapterNats.conn = nil
+	return err
+}
+
+func (adapterNats *AdapterNats) Flush() {
+	adapterNats.FlushError()
+}
+
+//round trip a PING, published messages are processed by the server
+func (adapterNats *AdapterNats) FlushError() error {
+	adapterNats.lock.Lock()
+	defer adapterNats.lock.Unlock()
+
+	if adapterNats.conn == nil {
+		return errors.New("nats: not connected")
+	}
+	return adapterNats.conn.ping(adapterNats.config.Timeout)
+}
+
+//round trip a PING with timeout instead of the config Timeout
+func (adapterNats *AdapterNats) FlushAck(timeout time.Duration) error {
+	adapterNats.lock.Lock()
+	defer adapterNats.lock.Unlock()
+
+	if adapterNats.conn == nil {
+		return errors.New("nats: not connected")
+	}
+	return adapterNats.conn.ping(timeout)
+}
+
+//close the connection, the adapter must not be written after Close
+func (adapterNats *AdapterNats) Close() error {
+	adapterNats.lock.Lock()
+	defer adapterNats.lock.Unlock()
+
+	return adapterNats.close()
+}
+
+func (adapterNats *AdapterNats) Name() string {
+	return NATS_ADAPTER_NAME
+}
+
+//new empty config of the adapter, describes the config fields
+func (adapterNats *AdapterNats) NewConfig() Config {
+	return &NatsConfig{}
+}
+
+func init() {
+	Register(NATS_ADAPTER_NAME, NewAdapterNats)
+}
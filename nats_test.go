@@ -0,0 +1,182 @@
+//go:build !tinygo && !logger_tiny
+// +build !tinygo,!logger_tiny
+
+package go_logger
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// published message received by the fake NATS server
+type natsPublished struct {
+	subject string
+	payload string
+}
+
+// fake NATS server, acknowledges JetStream publishes by ack
+type natsServer struct {
+	listener  net.Listener
+	lock      sync.Mutex
+	connects  []string
+	published []natsPublished
+	ack       string
+	conns     []net.Conn
+}
+
+func newNatsServer(t *testing.T, ack string) *natsServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	server := &natsServer{listener: listener, ack: ack}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			server.lock.Lock()
+			server.conns = append(server.conns, conn)
+			server.lock.Unlock()
+			go server.serve(conn)
+		}
+	}()
+	return server
+}
+
+func (server *natsServer) serve(conn net.Conn) {
+	defer conn.Close()
+	conn.Write([]byte(`INFO {"server_id":"test","max_payload":1048576}` + "\r\n"))
+	reader := bufio.NewReader(conn)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		parts := strings.Fields(line)
+		switch parts[0] {
+		case "CONNECT":
+			server.lock.Lock()
+			server.connects = append(server.connects, strings.TrimSpace(line))
+			server.lock.Unlock()
+		case "PING":
+			conn.Write([]byte("PONG\r\n"))
+		case "PUB":
+			size, _ := strconv.Atoi(parts[len(parts)-1])
+			payload := make([]byte, size+2)
+			io.ReadFull(reader, payload)
+			server.lock.Lock()
+			server.published = append(server.published, natsPublished{parts[1], string(payload[:size])})
+			server.lock.Unlock()
+			if len(parts) == 4 {
+				conn.Write([]byte("MSG " + parts[2] + " 1 " + strconv.Itoa(len(server.ack)) + "\r\n" + server.ack + "\r\n"))
+			}
+		}
+	}
+}
+
+func (server *natsServer) received() ([]string, []natsPublished) {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+	return append([]string{}, server.connects...), append([]natsPublished{}, server.published...)
+}
+
+//close the accepted connections, as a restarted server
+func (server *natsServer) drop() {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+	for _, conn := range server.conns {
+		conn.Close()
+	}
+	server.conns = nil
+}
+
+func TestAdapterNats_Init(t *testing.T) {
+
+	configs := []*NatsConfig{
+		{},
+		{Servers: []string{"127.0.0.1:4222"}},
+		{Servers: []string{"127.0.0.1:4222"}, Subject: "logs app"},
+		{Servers: []string{"127.0.0.1:4222"}, Subject: "logs", Timeout: -1},
+	}
+	for _, config := range configs {
+		if NewAdapterNats().Init(config) == nil {
+			t.Errorf("nats adapter config %+v must be error", config)
+		}
+	}
+}
+
+func TestAdapterNats_Write(t *testing.T) {
+
+	server := newNatsServer(t, "")
+	defer server.listener.Close()
+
+	natsAdapter := NewAdapterNats()
+	err := natsAdapter.Init(&NatsConfig{
+		Servers: []string{"127.0.0.1:1", server.listener.Addr().String()},
+		Subject: "logs.app.%level_string%",
+		Token:   "secret",
+		Format:  "%body%",
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer natsAdapter.(*AdapterNats).Close()
+
+	natsAdapter.Write(&loggerMessage{LevelString: "Error", Body: "logger nats 1"})
+	natsAdapter.(*AdapterNats).FlushError()
+	// a restarted server, the next write reconnects
+	server.drop()
+	<-natsAdapter.(*AdapterNats).conn.done
+	if err := natsAdapter.Write(&loggerMessage{LevelString: "Info", Body: "logger nats 2"}); err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := natsAdapter.(*AdapterNats).FlushError(); err != nil {
+		t.Fatal(err.Error())
+	}
+
+	connects, published := server.received()
+	if len(connects) != 2 || !strings.Contains(connects[0], `"auth_token":"secret"`) {
+		t.Errorf("nats adapter connect error: %v", connects)
+	}
+	expected := []natsPublished{{"logs.app.error", "logger nats 1"}, {"logs.app.info", "logger nats 2"}}
+	if len(published) != 2 || published[0] != expected[0] || published[1] != expected[1] {
+		t.Errorf("nats adapter published error: %+v", published)
+	}
+}
+
+func TestAdapterNats_WriteJetStream(t *testing.T) {
+
+	server := newNatsServer(t, `{"stream":"LOGS","seq":1}`)
+	defer server.listener.Close()
+
+	natsAdapter := NewAdapterNats()
+	err := natsAdapter.Init(&NatsConfig{
+		Servers:   []string{server.listener.Addr().String()},
+		Subject:   "logs",
+		JetStream: true,
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer natsAdapter.(*AdapterNats).Close()
+	if err := natsAdapter.Write(&loggerMessage{LevelString: "Info", Body: "logger nats jetstream"}); err != nil {
+		t.Fatal(err.Error())
+	}
+	_, published := server.received()
+	if len(published) != 1 || !strings.Contains(published[0].payload, `"body":"logger nats jetstream"`) {
+		t.Errorf("nats adapter jetstream published error: %+v", published)
+	}
+
+	server.ack = `{"error":{"code":503,"description":"no responders"}}`
+	err = natsAdapter.Write(&loggerMessage{LevelString: "Info", Body: "logger nats jetstream"})
+	if err == nil || !strings.Contains(err.Error(), "no responders") {
+		t.Errorf("nats adapter must return the jetstream error, got %v", err)
+	}
+}
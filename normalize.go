@@ -0,0 +1,133 @@
+package go_logger
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// field name of dropped fields count when MaxFields is exceeded
+const fieldsDroppedField = "fields_dropped"
+
+// field normalizer, applied to fields before an output formats the message
+type FieldNormalizer struct {
+
+	// flatten nested map[string]interface{} to keys joined by FlattenSeparator
+	FlattenMaps bool
+
+	// flatten key separator, default "."
+	FlattenSeparator string
+
+	// convert keys to lower case
+	LowercaseKeys bool
+
+	// convert keys to snake_case, e.g. "userID" to "user_id"
+	SnakeCaseKeys bool
+
+	// coerce values that are not string, number, bool or nil to string
+	StringifyValues bool
+
+	// max field count, 0 is unlimited
+	// fields are kept by sorted keys, the dropped count is saved in "fields_dropped"
+	MaxFields int
+}
+
+//set the field normalizer of an attached output
+//params : adapterName string, normalizer *FieldNormalizer, nil to remove
+//return : error
+func (logger *Logger) SetFieldNormalizer(adapterName string, normalizer *FieldNormalizer) error {
+	if normalizer != nil && normalizer.MaxFields < 0 {
+		return errors.New("logger: FieldNormalizer MaxFields cannot be negative!")
+	}
+	logger.lock.Lock()
+	defer logger.lock.Unlock()
+
+	return logger.updateOutput(adapterName, func(output *outputLogger) error {
+		output.normalizer = normalizer
+		return nil
+	})
+}
+
+//normalize fields, return new fields
+func (normalizer *FieldNormalizer) normalize(fields map[string]interface{}) map[string]interface{} {
+	if len(fields) == 0 {
+		return fields
+	}
+	separator := normalizer.FlattenSeparator
+	if separator == "" {
+		separator = "."
+	}
+
+	normalized := make(map[string]interface{}, len(fields))
+	var add func(prefix string, fields map[string]interface{})
+	add = func(prefix string, fields map[string]interface{}) {
+		for key, value := range fields {
+			if nested, ok := value.(map[string]interface{}); ok && normalizer.FlattenMaps {
+				add(prefix+key+separator, nested)
+				continue
+			}
+			key = prefix + key
+			if normalizer.SnakeCaseKeys {
+				key = snakeCase(key)
+			}
+			if normalizer.LowercaseKeys {
+				key = strings.ToLower(key)
+			}
+			if normalizer.StringifyValues {
+				value = coerceScalar(value)
+			}
+			normalized[key] = value
+		}
+	}
+	add("", fields)
+
+	if normalizer.MaxFields > 0 && len(normalized) > normalizer.MaxFields {
+		keys := make([]string, 0, len(normalized))
+		for key := range normalized {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys[normalizer.MaxFields:] {
+			delete(normalized, key)
+		}
+		normalized[fieldsDroppedField] = len(keys) - normalizer.MaxFields
+	}
+	return normalized
+}
+
+//convert "userID", "UserName", "user-name" to snake_case
+func snakeCase(s string) string {
+	runes := []rune(s)
+	var b strings.Builder
+	for i, r := range runes {
+		switch {
+		case r == '-' || r == ' ':
+			b.WriteRune('_')
+		case unicode.IsUpper(r):
+			// word boundary: "aB", or the last upper of an acronym followed by lower: "HTTPServer"
+			if i > 0 && runes[i-1] != '_' && runes[i-1] != '-' && runes[i-1] != ' ' &&
+				(unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) ||
+					(i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				b.WriteRune('_')
+			}
+			b.WriteRune(unicode.ToLower(r))
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+//coerce value to string if not string, number, bool or nil
+func coerceScalar(value interface{}) interface{} {
+	switch value.(type) {
+	case nil, string, bool,
+		int, int8, int16, int32, int64,
+		uint, uint8, uint16, uint32, uint64,
+		float32, float64:
+		return value
+	}
+	return fmt.Sprint(value)
+}
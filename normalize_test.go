@@ -0,0 +1,45 @@
+package go_logger
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSnakeCase(t *testing.T) {
+
+	cases := map[string]string{
+		"userID":     "user_id",
+		"UserName":   "user_name",
+		"HTTPServer": "http_server",
+		"user-name":  "user_name",
+		"user_name":  "user_name",
+	}
+	for s, expect := range cases {
+		if snakeCase(s) != expect {
+			t.Error("snake case " + s + " error: " + snakeCase(s))
+		}
+	}
+}
+
+func TestFieldNormalizer_normalize(t *testing.T) {
+
+	normalizer := &FieldNormalizer{
+		FlattenMaps:     true,
+		SnakeCaseKeys:   true,
+		StringifyValues: true,
+	}
+	fields := normalizer.normalize(map[string]interface{}{
+		"requestID": "abc",
+		"http":      map[string]interface{}{"statusCode": 200},
+		"cost":      time.Second,
+	})
+	if fields["request_id"] != "abc" || fields["http.status_code"] != 200 || fields["cost"] != "1s" {
+		t.Errorf("field normalizer error: %v", fields)
+	}
+
+	normalizer = &FieldNormalizer{MaxFields: 1}
+	fields = normalizer.normalize(map[string]interface{}{"a": 1, "b": 2, "c": 3})
+	if len(fields) != 2 || fields["a"] != 1 || fields[fieldsDroppedField] != 2 {
+		t.Errorf("field normalizer max fields error: %v", fields)
+	}
+}
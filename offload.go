@@ -0,0 +1,158 @@
+package go_logger
+
+import (
+	"errors"
+	"sync"
+)
+
+// worker pool of an output, messages are encoded and written by the workers instead of the dispatch goroutine
+// a message is counted as pending from queueing until written, Flush waits until no message is pending
+type outputOffload struct {
+	workers   int
+	queue     chan *offloadTask
+	lock      sync.Mutex
+	idle      *sync.Cond // broadcast when pending is 0
+	pending   int
+	stopped   bool
+	stopChan  chan struct{}
+	stoppedWg sync.WaitGroup
+}
+
+// message queued for the workers with the output copy writing it, so settings changed
+// after queueing do not apply to it
+type offloadTask struct {
+	output    *outputLogger
+	loggerMsg *loggerMessage
+}
+
+//write the messages of an output by a pool of workers, e.g. an output with expensive encoding
+//(JSON of many fields, compression), so the dispatch goroutine and the other outputs are not delayed
+//messages are queued for the workers, a full queue blocks the dispatch until a worker is free;
+//with more than one worker the adapter is written concurrently and messages may be written out of order
+//Flush and Close wait for the queued messages before flushing the output
+//more than one worker cannot be set with strict ordering, see SetStrictOrdering
+//params : adapterName string, workers int, 0 writes on the dispatch goroutine again; queueSize int, 0 is 100
+//return : error
+func (logger *Logger) SetOutputWorkers(adapterName string, workers int, queueSize int) error {
+	if workers < 0 || queueSize < 0 {
+		return errors.New("logger: output workers and queue size cannot be negative!")
+	}
+	logger.lock.Lock()
+	defer logger.lock.Unlock()
+
+	if logger.output(adapterName) == nil {
+		return errors.New("logger: adapter " + adapterName + " is not attached!")
+	}
+	if workers > 1 && logger.strictOrdering {
+		return errors.New("logger: output workers more than 1 cannot be set with strict ordering!")
+	}
+	if queueSize == 0 {
+		queueSize = 100
+	}
+	var offload *outputOffload
+	if workers > 0 {
+		offload = newOutputOffload(workers, queueSize)
+	}
+	// writers holding the previous output copy write by themselves once its workers are stopped
+	var previous *outputOffload
+	logger.updateOutput(adapterName, func(output *outputLogger) error {
+		previous = output.offload
+		output.offload = offload
+		return nil
+	})
+	if previous != nil {
+		previous.stop()
+	}
+	return nil
+}
+
+//start the workers of an output
+func newOutputOffload(workers int, queueSize int) *outputOffload {
+	offload := &outputOffload{
+		workers:  workers,
+		queue:    make(chan *offloadTask, queueSize),
+		stopChan: make(chan struct{}),
+	}
+	offload.idle = sync.NewCond(&offload.lock)
+	offload.stoppedWg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go offload.work()
+	}
+	return offload
+}
+
+//write queued messages until stopped
+func (offload *outputOffload) work() {
+	defer offload.stoppedWg.Done()
+	for {
+		select {
+		case task := <-offload.queue:
+			task.output.deliver(task.loggerMsg)
+			offload.lock.Lock()
+			offload.pending--
+			if offload.pending == 0 {
+				offload.idle.Broadcast()
+			}
+			offload.lock.Unlock()
+		case <-offload.stopChan:
+			return
+		}
+	}
+}
+
+//queue a message of an output copy for the workers
+//return : false if the workers are stopped, the message must be written by the caller
+func (offload *outputOffload) enqueue(output *outputLogger, loggerMsg *loggerMessage) bool {
+	offload.lock.Lock()
+	if offload.stopped {
+		offload.lock.Unlock()
+		return false
+	}
+	offload.pending++
+	offload.lock.Unlock()
+
+	offload.queue <- &offloadTask{output: output, loggerMsg: loggerMsg}
+	return true
+}
+
+//wait until the queued messages are written
+func (offload *outputOffload) wait() {
+	offload.lock.Lock()
+	for offload.pending > 0 {
+		offload.idle.Wait()
+	}
+	offload.lock.Unlock()
+}
+
+//write the queued messages and stop the workers, later messages are written by the callers
+func (offload *outputOffload) stop() {
+	offload.lock.Lock()
+	if offload.stopped {
+		offload.lock.Unlock()
+		return
+	}
+	offload.stopped = true
+	for offload.pending > 0 {
+		offload.idle.Wait()
+	}
+	offload.lock.Unlock()
+
+	close(offload.stopChan)
+	offload.stoppedWg.Wait()
+}
+
+//workers are stopped
+func (offload *outputOffload) isStopped() bool {
+	offload.lock.Lock()
+	defer offload.lock.Unlock()
+	return offload.stopped
+}
+
+//stop the workers of every output after lock
+func (logger *Logger) stopOutputWorkers() {
+	for _, output := range logger.outputList() {
+		if offload := output.offload; offload != nil {
+			offload.stop()
+		}
+	}
+}
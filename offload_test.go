@@ -0,0 +1,163 @@
+package go_logger
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// adapter with an expensive write, bodies are recorded
+type adapterSlow struct {
+	lock   sync.Mutex
+	delay  time.Duration
+	bodies []string
+}
+
+type slowConfig struct {
+	delay time.Duration
+}
+
+func (sc *slowConfig) Name() string {
+	return "slow"
+}
+
+func (adapter *adapterSlow) Init(config Config) error {
+	adapter.delay = config.(*slowConfig).delay
+	return nil
+}
+
+func (adapter *adapterSlow) Write(loggerMsg *loggerMessage) error {
+	time.Sleep(adapter.delay)
+	adapter.lock.Lock()
+	adapter.bodies = append(adapter.bodies, loggerMsg.Body)
+	adapter.lock.Unlock()
+	return nil
+}
+
+func (adapter *adapterSlow) written() []string {
+	adapter.lock.Lock()
+	defer adapter.lock.Unlock()
+	return append([]string{}, adapter.bodies...)
+}
+
+func (adapter *adapterSlow) Flush() {
+
+}
+
+func (adapter *adapterSlow) Name() string {
+	return "slow"
+}
+
+func init() {
+	Register("slow", func() LoggerAbstract {
+		return &adapterSlow{}
+	})
+}
+
+func TestLogger_SetOutputWorkers(t *testing.T) {
+
+	logger := NewLogger()
+	logger.Detach("console")
+	if logger.SetOutputWorkers("slow", 1, 0) == nil {
+		t.Error("output workers of a not attached adapter must be error")
+	}
+	logger.Attach("slow", LoggerLevelDebug, &slowConfig{delay: 20 * time.Millisecond})
+	if logger.SetOutputWorkers("slow", -1, 0) == nil {
+		t.Error("negative output workers must be error")
+	}
+	if err := logger.SetOutputWorkers("slow", 1, 0); err != nil {
+		t.Fatal(err.Error())
+	}
+	output, _ := logger.Output("slow")
+	slow := output.(*adapterSlow)
+
+	start := time.Now()
+	for _, body := range []string{"a", "b", "c", "d", "e"} {
+		logger.Info(body)
+	}
+	if elapsed := time.Since(start); elapsed >= 100*time.Millisecond {
+		t.Errorf("writes of an output with workers must not wait for the adapter, took %v", elapsed)
+	}
+	if err := logger.Flush(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if written := strings.Join(slow.written(), ""); written != "abcde" {
+		t.Errorf("one worker must write messages in order before Flush returns, written %q", written)
+	}
+	if adapters := logger.Config().Adapters; adapters[0].Workers != 1 {
+		t.Errorf("output workers snapshot %+v", adapters)
+	}
+
+	logger.Close()
+	if adapters := logger.Config().Adapters; adapters[0].Workers != 0 {
+		t.Errorf("output workers must be stopped by Close, snapshot %+v", adapters)
+	}
+	logger.Info("f")
+	if written := slow.written(); len(written) != 7 || written[6] != "f" {
+		t.Errorf("messages after Close must be written synchronously, written %q", written)
+	}
+}
+
+func TestLogger_SetOutputWorkersPool(t *testing.T) {
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("slow", LoggerLevelDebug, &slowConfig{delay: 50 * time.Millisecond})
+	logger.SetAsync()
+	if err := logger.SetOutputWorkers("slow", 4, 8); err != nil {
+		t.Fatal(err.Error())
+	}
+	output, _ := logger.Output("slow")
+	slow := output.(*adapterSlow)
+
+	start := time.Now()
+	for _, body := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		logger.Info(body)
+	}
+	if err := logger.Flush(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if elapsed := time.Since(start); elapsed >= 350*time.Millisecond {
+		t.Errorf("4 workers must write 8 messages concurrently, took %v", elapsed)
+	}
+	written := slow.written()
+	sort.Strings(written)
+	if strings.Join(written, "") != "abcdefgh" {
+		t.Errorf("workers written %q", written)
+	}
+
+	if err := logger.SetOutputWorkers("slow", 0, 0); err != nil {
+		t.Fatal(err.Error())
+	}
+	logger.Info("i")
+	logger.Flush()
+	if written := slow.written(); len(written) != 9 || written[8] != "i" {
+		t.Errorf("messages without workers written %q", written)
+	}
+	logger.Close()
+}
+
+func TestLogger_SetOutputWorkersStrictOrdering(t *testing.T) {
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("slow", LoggerLevelDebug, &slowConfig{})
+	if err := logger.SetOutputWorkers("slow", 2, 0); err != nil {
+		t.Fatal(err.Error())
+	}
+	if logger.SetStrictOrdering(true) == nil {
+		t.Error("strict ordering with an output of 2 workers must be error")
+	}
+	if err := logger.SetOutputWorkers("slow", 1, 0); err != nil {
+		t.Fatal(err.Error())
+	}
+	if err := logger.SetStrictOrdering(true); err != nil {
+		t.Errorf("strict ordering with an output of 1 worker: %v", err)
+	}
+	if logger.SetOutputWorkers("slow", 2, 0) == nil {
+		t.Error("2 output workers with strict ordering must be error")
+	}
+	logger.Close()
+}
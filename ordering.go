@@ -0,0 +1,116 @@
+package go_logger
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// reordering buffer of strict ordering, async messages are written by sequence
+type orderBuffer struct {
+	sequence uint64 // last assigned sequence, first for atomic alignment
+
+	lock    sync.Mutex
+	next    uint64                    // next sequence to write
+	pending map[uint64]*loggerMessage // received out of order, nil is a skipped sequence
+	wake    chan struct{}             // wakes the async worker when a sequence is skipped
+}
+
+func newOrderBuffer() *orderBuffer {
+	return &orderBuffer{
+		next:    1,
+		pending: map[uint64]*loggerMessage{},
+		wake:    make(chan struct{}, 1),
+	}
+}
+
+//set strict ordering, async messages are written in the global submission order
+//Emergency, Alert and Critical messages no longer overtake queued messages of other levels
+//a message not queued in the enqueue timeout leaves the order, see SetEnqueueTimeout
+//outputs with more than one worker write out of order, strict ordering cannot be enabled with them
+//params : enabled bool
+//return : error
+func (logger *Logger) SetStrictOrdering(enabled bool) error {
+	logger.lock.Lock()
+	defer logger.lock.Unlock()
+
+	if enabled {
+		for _, output := range logger.outputList() {
+			if offload := output.offload; offload != nil && offload.workers > 1 && !offload.isStopped() {
+				return errors.New("logger: strict ordering cannot be enabled with " + strconv.Itoa(offload.workers) + " output workers of adapter " + output.Name + "!")
+			}
+		}
+	}
+	logger.asyncLock.Lock()
+	defer logger.asyncLock.Unlock()
+
+	logger.strictOrdering = enabled
+	return nil
+}
+
+//assign the next submission sequence
+func (buffer *orderBuffer) assign(loggerMsg *loggerMessage) {
+	loggerMsg.sequence = atomic.AddUint64(&buffer.sequence, 1)
+}
+
+//add a received message
+func (buffer *orderBuffer) add(loggerMsg *loggerMessage) {
+	buffer.lock.Lock()
+	buffer.pending[loggerMsg.sequence] = loggerMsg
+	buffer.lock.Unlock()
+}
+
+//skip the sequence of a message that was not queued, and wake the async worker
+func (buffer *orderBuffer) skip(sequence uint64) {
+	buffer.lock.Lock()
+	buffer.pending[sequence] = nil
+	buffer.lock.Unlock()
+	select {
+	case buffer.wake <- struct{}{}:
+	default:
+	}
+}
+
+//next message in order, skipped sequences are passed
+//return : *loggerMessage, nil if the next sequence is not received
+func (buffer *orderBuffer) pop() *loggerMessage {
+	buffer.lock.Lock()
+	defer buffer.lock.Unlock()
+
+	for {
+		loggerMsg, ok := buffer.pending[buffer.next]
+		if !ok {
+			return nil
+		}
+		delete(buffer.pending, buffer.next)
+		buffer.next++
+		if loggerMsg != nil {
+			return loggerMsg
+		}
+	}
+}
+
+//is any message waiting for an earlier sequence
+func (buffer *orderBuffer) waiting() bool {
+	buffer.lock.Lock()
+	defer buffer.lock.Unlock()
+	return len(buffer.pending) > 0
+}
+
+//write message read from the async queue in sequence, a message without sequence is written at once
+func (logger *Logger) writeOrdered(loggerMsg *loggerMessage) {
+	if loggerMsg.sequence == 0 {
+		logger.writeQueued(loggerMsg)
+		return
+	}
+	logger.ordering.add(loggerMsg)
+	logger.writeReady()
+}
+
+//write the messages of the reordering buffer that are next in sequence
+func (logger *Logger) writeReady() {
+	for loggerMsg := logger.ordering.pop(); loggerMsg != nil; loggerMsg = logger.ordering.pop() {
+		logger.writeQueued(loggerMsg)
+	}
+}
@@ -0,0 +1,82 @@
+package go_logger
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLogger_SetStrictOrdering(t *testing.T) {
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("console", LoggerLevelDebug, &ConsoleConfig{Format: "%body%"})
+	buffer := &bytes.Buffer{}
+	logger.outputList()[0].LoggerAbstract.(*AdapterConsole).write.writer = buffer
+
+	// async without worker goroutine, the queued messages are written by flush
+	logger.synchronous = false
+	logger.Info("logger info 1")
+	logger.Critical("logger critical 2")
+	logger.Info("logger info 3")
+	logger.flush()
+	if buffer.String() != "logger critical 2\nlogger info 1\nlogger info 3\n" {
+		t.Errorf("critical message must overtake queued messages: %q", buffer.String())
+	}
+
+	buffer.Reset()
+	logger.SetStrictOrdering(true)
+	if !logger.Config().StrictOrdering {
+		t.Error("snapshot must report strict ordering")
+	}
+	logger.Info("logger info 1")
+	logger.Critical("logger critical 2")
+	logger.Info("logger info 3")
+	logger.flush()
+	if buffer.String() != "logger info 1\nlogger critical 2\nlogger info 3\n" {
+		t.Errorf("strict ordering must keep the submission order: %q", buffer.String())
+	}
+
+	// a message not queued in the enqueue timeout does not stop the order
+	buffer.Reset()
+	logger.msgChan = make(chan *loggerMessage, 1)
+	logger.SetEnqueueTimeout(time.Millisecond, OVERFLOW_POLICY_DROP)
+	logger.Info("logger info 1")
+	logger.Info("logger info 2")
+	logger.Critical("logger critical 3")
+	logger.flush()
+	if buffer.String() != "logger info 1\nlogger critical 3\n" || logger.ordering.waiting() {
+		t.Errorf("strict ordering must skip the dropped message: %q", buffer.String())
+	}
+}
+
+func TestLogger_SetStrictOrderingAsync(t *testing.T) {
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("console", LoggerLevelDebug, &ConsoleConfig{Format: "%body%"})
+	buffer := &lockedBuffer{}
+	logger.outputList()[0].LoggerAbstract.(*AdapterConsole).write.writer = buffer
+	logger.SetStrictOrdering(true)
+	logger.SetAsync(4)
+
+	expected := []string{}
+	for i := 0; i < 200; i++ {
+		body := "logger ordered " + strconv.Itoa(i)
+		if i%3 == 0 {
+			logger.Critical(body)
+		} else {
+			logger.Info(body)
+		}
+		expected = append(expected, body)
+	}
+	if err := logger.Flush(); err != nil {
+		t.Fatal(err.Error())
+	}
+	if buffer.String() != strings.Join(expected, "\n")+"\n" {
+		t.Errorf("strict ordering async messages out of order: %q", buffer.String())
+	}
+	logger.SetSync()
+}
@@ -0,0 +1,119 @@
+//go:build darwin && cgo
+// +build darwin,cgo
+
+package go_logger
+
+/*
+#include <stdlib.h>
+#include <os/log.h>
+
+// os_log_with_type is a macro, wrap it for cgo
+static void go_logger_os_log(os_log_t log, os_log_type_t type, const char *msg) {
+	os_log_with_type(log, type, "%{public}s", msg);
+}
+*/
+import "C"
+
+import (
+	"errors"
+	"unsafe"
+)
+
+const OSLOG_ADAPTER_NAME = "oslog"
+
+// os_log type of levels
+var levelOslogTypes = map[int]C.os_log_type_t{
+	LoggerLevelEmergency: C.OS_LOG_TYPE_FAULT,
+	LoggerLevelAlert:     C.OS_LOG_TYPE_FAULT,
+	LoggerLevelCritical:  C.OS_LOG_TYPE_FAULT,
+	LoggerLevelError:     C.OS_LOG_TYPE_ERROR,
+	LoggerLevelWarning:   C.OS_LOG_TYPE_DEFAULT,
+	LoggerLevelNotice:    C.OS_LOG_TYPE_DEFAULT,
+	LoggerLevelInfo:      C.OS_LOG_TYPE_INFO,
+	LoggerLevelDebug:     C.OS_LOG_TYPE_DEBUG,
+}
+
+// adapter oslog, write to apple unified logging (iOS / macOS) by os_log
+type AdapterOslog struct {
+	log    C.os_log_t
+	config *OslogConfig
+}
+
+// oslog config
+type OslogConfig struct {
+
+	// os_log subsystem, reverse DNS, eg: "com.example.app"
+	Subsystem string `config:"required"`
+
+	// os_log category, if empty, default "default"
+	Category string
+
+	// please input format string
+	// if format is empty, default format "%body%", os_log shows time and type itself
+	Format string
+}
+
+func (oc *OslogConfig) Name() string {
+	return OSLOG_ADAPTER_NAME
+}
+
+func NewAdapterOslog() LoggerAbstract {
+	return &AdapterOslog{
+		config: &OslogConfig{},
+	}
+}
+
+func (adapterOslog *AdapterOslog) Init(oslogConfig Config) error {
+	if oslogConfig.Name() != OSLOG_ADAPTER_NAME {
+		return errors.New("logger oslog adapter init error, config must OslogConfig")
+	}
+
+	oc := oslogConfig.(*OslogConfig)
+	adapterOslog.config = oc
+
+	if oc.Subsystem == "" {
+		return errors.New("config Subsystem cannot be empty!")
+	}
+	if oc.Category == "" {
+		oc.Category = "default"
+	}
+	if oc.Format == "" {
+		oc.Format = "%body%"
+	}
+
+	subsystem := C.CString(oc.Subsystem)
+	defer C.free(unsafe.Pointer(subsystem))
+	category := C.CString(oc.Category)
+	defer C.free(unsafe.Pointer(category))
+	adapterOslog.log = C.os_log_create(subsystem, category)
+	return nil
+}
+
+func (adapterOslog *AdapterOslog) Write(loggerMsg *loggerMessage) error {
+	logType, ok := levelOslogTypes[loggerMsg.Level]
+	if !ok {
+		logType = C.OS_LOG_TYPE_DEFAULT
+	}
+	msg := C.CString(loggerMessageFormat(adapterOslog.config.Format, loggerMsg))
+	defer C.free(unsafe.Pointer(msg))
+
+	C.go_logger_os_log(adapterOslog.log, logType, msg)
+	return nil
+}
+
+func (adapterOslog *AdapterOslog) Flush() {
+
+}
+
+func (adapterOslog *AdapterOslog) Name() string {
+	return OSLOG_ADAPTER_NAME
+}
+
+//new empty config of the adapter, describes the config fields
+func (adapterOslog *AdapterOslog) NewConfig() Config {
+	return &OslogConfig{}
+}
+
+func init() {
+	Register(OSLOG_ADAPTER_NAME, NewAdapterOslog)
+}
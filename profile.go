@@ -0,0 +1,128 @@
+package go_logger
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	JSON_PROFILE_DEFAULT = ""
+	JSON_PROFILE_GELF    = "gelf"
+	JSON_PROFILE_ECS     = "ecs"
+	JSON_PROFILE_OTEL    = "otel"
+)
+
+// map message to the destination JSON schema
+type jsonProfileFunc func(loggerMsg *loggerMessage) map[string]interface{}
+
+var jsonProfiles = map[string]jsonProfileFunc{
+	JSON_PROFILE_GELF: gelfProfile,
+	JSON_PROFILE_ECS:  ecsProfile,
+	JSON_PROFILE_OTEL: otelProfile,
+}
+
+// OpenTelemetry severity number of levels
+var otelSeverityNumbers = map[int]int{
+	LoggerLevelEmergency: 24,
+	LoggerLevelAlert:     22,
+	LoggerLevelCritical:  21,
+	LoggerLevelError:     17,
+	LoggerLevelWarning:   13,
+	LoggerLevelNotice:    10,
+	LoggerLevelInfo:      9,
+	LoggerLevelDebug:     5,
+}
+
+var profileHostname, _ = os.Hostname()
+
+//check JSON profile name
+func checkJsonProfile(profile string) error {
+	if profile == JSON_PROFILE_DEFAULT {
+		return nil
+	}
+	if _, ok := jsonProfiles[profile]; !ok {
+		return errors.New("config JsonProfile must be one of the '', 'gelf', 'ecs', 'otel'!")
+	}
+	return nil
+}
+
+//marshal message to JSON by profile
+//params : loggerMsg *loggerMessage, profile string
+//return : []byte, error
+func loggerMessageJson(loggerMsg *loggerMessage, profile string) ([]byte, error) {
+	profileFunc, ok := jsonProfiles[profile]
+	if !ok {
+		return loggerMsg.MarshalJSON()
+	}
+	return json.Marshal(profileFunc(loggerMsg))
+}
+
+//Graylog Extended Log Format 1.1
+func gelfProfile(loggerMsg *loggerMessage) map[string]interface{} {
+	record := map[string]interface{}{
+		"version":       "1.1",
+		"host":          profileHostname,
+		"short_message": loggerMsg.Body,
+		"timestamp":     float64(loggerMsg.Millisecond) / 1e3,
+		// levels are the same as syslog severities
+		"level":     loggerMsg.Level,
+		"_file":     loggerMsg.File,
+		"_line":     loggerMsg.Line,
+		"_function": loggerMsg.Function,
+	}
+	for key, value := range loggerMsg.Fields {
+		// "_id" is reserved by GELF
+		if key == "id" {
+			key = "field_id"
+		}
+		record["_"+key] = value
+	}
+	if loggerMsg.Name != "" {
+		record["_logger"] = loggerMsg.Name
+	}
+	return record
+}
+
+//Elastic Common Schema
+func ecsProfile(loggerMsg *loggerMessage) map[string]interface{} {
+	record := map[string]interface{}{}
+	for key, value := range loggerMsg.Fields {
+		record[key] = value
+	}
+	record["@timestamp"] = time.Unix(0, loggerMsg.Millisecond*1e6).UTC().Format("2006-01-02T15:04:05.000Z")
+	record["message"] = loggerMsg.Body
+	record["ecs.version"] = "1.6.0"
+	record["log.level"] = strings.ToLower(loggerMsg.LevelString)
+	record["log.origin.file.name"] = loggerMsg.File
+	record["log.origin.file.line"] = loggerMsg.Line
+	record["log.origin.function"] = loggerMsg.Function
+	if loggerMsg.Name != "" {
+		record["log.logger"] = loggerMsg.Name
+	}
+	return record
+}
+
+//OpenTelemetry log data model
+func otelProfile(loggerMsg *loggerMessage) map[string]interface{} {
+	attributes := map[string]interface{}{}
+	for key, value := range loggerMsg.Fields {
+		attributes[key] = value
+	}
+	attributes["code.filepath"] = loggerMsg.File
+	attributes["code.lineno"] = loggerMsg.Line
+	attributes["code.function"] = loggerMsg.Function
+	if loggerMsg.Name != "" {
+		attributes["logger.name"] = loggerMsg.Name
+	}
+	return map[string]interface{}{
+		"Timestamp":      strconv.FormatInt(loggerMsg.Millisecond*1e6, 10),
+		"SeverityText":   strings.ToUpper(loggerMsg.LevelString),
+		"SeverityNumber": otelSeverityNumbers[loggerMsg.Level],
+		"Body":           loggerMsg.Body,
+		"Attributes":     attributes,
+	}
+}
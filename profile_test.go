@@ -0,0 +1,49 @@
+package go_logger
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestLoggerMessageJson(t *testing.T) {
+
+	loggerMsg := &loggerMessage{
+		Timestamp:         time.Now().Unix(),
+		TimestampFormat:   time.Now().Format("2006-01-02 15:04:05"),
+		Millisecond:       time.Now().UnixNano() / 1e6,
+		MillisecondFormat: time.Now().Format("2006-01-02 15:04:05.999"),
+		Level:             LoggerLevelError,
+		LevelString:       "Error",
+		Body:              "logger json profile test",
+		File:              "profile_test.go",
+		Line:              20,
+		Function:          "TestLoggerMessageJson",
+		Fields:            map[string]interface{}{"user": "tom"},
+	}
+
+	record := map[string]interface{}{}
+	jsonByte, _ := loggerMessageJson(loggerMsg, JSON_PROFILE_GELF)
+	json.Unmarshal(jsonByte, &record)
+	if record["short_message"] != loggerMsg.Body || record["_user"] != "tom" || record["level"] != float64(3) {
+		t.Error("logger json profile gelf error: " + string(jsonByte))
+	}
+
+	record = map[string]interface{}{}
+	jsonByte, _ = loggerMessageJson(loggerMsg, JSON_PROFILE_ECS)
+	json.Unmarshal(jsonByte, &record)
+	if record["message"] != loggerMsg.Body || record["log.level"] != "error" || record["user"] != "tom" {
+		t.Error("logger json profile ecs error: " + string(jsonByte))
+	}
+
+	record = map[string]interface{}{}
+	jsonByte, _ = loggerMessageJson(loggerMsg, JSON_PROFILE_OTEL)
+	json.Unmarshal(jsonByte, &record)
+	if record["Body"] != loggerMsg.Body || record["SeverityNumber"] != float64(17) {
+		t.Error("logger json profile otel error: " + string(jsonByte))
+	}
+
+	if checkJsonProfile("splunk") == nil {
+		t.Error("logger unknown json profile must be error")
+	}
+}
@@ -0,0 +1,112 @@
+package go_logger
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+)
+
+// scope headers, set by Entry.Inject and read by Logger.Extract
+const (
+	ScopeHeaderFields        = "X-Logger-Fields"         // base64url JSON of entry fields
+	ScopeHeaderCorrelationId = "X-Logger-Correlation-Id" // request_id of the entry
+	ScopeHeaderName          = "X-Logger-Name"           // named child name
+	ScopeHeaderLevel         = "X-Logger-Level"          // level override, "error", "info" ...
+)
+
+// max encoded bytes of ScopeHeaderFields, larger fields are not propagated
+const scopeFieldsMaxBytes = 4096
+
+// headers or metadata carrying the log scope, http.Header is a ScopeCarrier
+type ScopeCarrier interface {
+	Get(key string) string
+	Set(key string, value string)
+}
+
+// map[string]string ScopeCarrier, for message metadata and RPC metadata
+type MapCarrier map[string]string
+
+func (carrier MapCarrier) Get(key string) string {
+	return carrier[key]
+}
+
+func (carrier MapCarrier) Set(key string, value string) {
+	carrier[key] = value
+}
+
+//serialize the entry scope into carrier: fields (context key fields included),
+//correlation id (the request_id field), name and level override
+//values that are not string, number, bool or nil are sent as strings
+//params : carrier ScopeCarrier
+func (entry *Entry) Inject(carrier ScopeCarrier) {
+	fields := make(map[string]interface{}, len(entry.fields))
+	for key, value := range entry.fields {
+		fields[key] = coerceScalar(value)
+	}
+	if entry.ctx != nil {
+		for _, contextKey := range entry.logger.contextKeys.Load().([]contextKeyField) {
+			if value := entry.ctx.Value(contextKey.key); value != nil {
+				fields[contextKey.field] = coerceScalar(fieldValue(value))
+			}
+		}
+	}
+
+	if correlationId, ok := fields["request_id"].(string); ok && correlationId != "" {
+		carrier.Set(ScopeHeaderCorrelationId, correlationId)
+		delete(fields, "request_id")
+	}
+	if len(fields) > 0 {
+		data, err := json.Marshal(fields)
+		if err == nil {
+			encoded := base64.RawURLEncoding.EncodeToString(data)
+			if len(encoded) <= scopeFieldsMaxBytes {
+				carrier.Set(ScopeHeaderFields, encoded)
+			}
+		}
+	}
+	if entry.name != "" {
+		carrier.Set(ScopeHeaderName, entry.name)
+	}
+	if entry.hasLevel {
+		if levelString, ok := levelStringMapping[entry.level]; ok {
+			carrier.Set(ScopeHeaderLevel, strings.ToLower(levelString))
+		}
+	}
+}
+
+//restore the scope injected by an upstream service into a new entry
+//illegal headers are ignored, the entry has the fields that could be restored
+//params : carrier ScopeCarrier
+//return : *Entry
+func (logger *Logger) Extract(carrier ScopeCarrier) *Entry {
+	entry := &Entry{logger: logger, name: carrier.Get(ScopeHeaderName)}
+
+	fields := map[string]interface{}{}
+	if encoded := carrier.Get(ScopeHeaderFields); encoded != "" && len(encoded) <= scopeFieldsMaxBytes {
+		if data, err := base64.RawURLEncoding.DecodeString(encoded); err == nil {
+			// numbers are kept as json.Number, integers are not turned into floats
+			decoder := json.NewDecoder(bytes.NewReader(data))
+			decoder.UseNumber()
+			if decoder.Decode(&fields) != nil {
+				fields = map[string]interface{}{}
+			}
+		}
+	}
+	if correlationId := carrier.Get(ScopeHeaderCorrelationId); correlationId != "" {
+		fields["request_id"] = correlationId
+	}
+	if len(fields) > 0 {
+		entry.fields = fields
+	}
+
+	if levelString := carrier.Get(ScopeHeaderLevel); levelString != "" {
+		for level, s := range levelStringMapping {
+			if strings.EqualFold(s, levelString) {
+				entry.level = level
+				entry.hasLevel = true
+			}
+		}
+	}
+	return entry
+}
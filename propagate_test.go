@@ -0,0 +1,69 @@
+package go_logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestEntry_Inject(t *testing.T) {
+
+	logger := NewLogger()
+	ctx := context.WithValue(context.Background(), ContextKeyRequestId, "8d2f")
+	entry := logger.Named("payments").WithLevel(LoggerLevelWarning).
+		WithContext(ctx).WithFields(map[string]interface{}{"tenant": "acme", "shard": 3})
+
+	header := http.Header{}
+	entry.Inject(header)
+	if header.Get(ScopeHeaderCorrelationId) != "8d2f" || header.Get(ScopeHeaderName) != "payments" ||
+		header.Get(ScopeHeaderLevel) != "warning" || header.Get(ScopeHeaderFields) == "" {
+		t.Errorf("entry inject error: %v", header)
+	}
+
+	// an empty scope sets nothing
+	carrier := MapCarrier{}
+	logger.WithFields(nil).Inject(carrier)
+	if len(carrier) != 0 {
+		t.Errorf("entry inject empty scope error: %v", carrier)
+	}
+}
+
+func TestLogger_Extract(t *testing.T) {
+
+	upstream := NewLogger()
+	carrier := MapCarrier{}
+	upstream.Named("payments").WithLevel(LoggerLevelWarning).
+		WithFields(map[string]interface{}{"request_id": "8d2f", "tenant": "acme", "shard": 3}).Inject(carrier)
+
+	logger := NewLogger()
+	logger.Detach(CONSOLE_ADAPTER_NAME)
+	logger.Attach(CONSOLE_ADAPTER_NAME, LoggerLevelDebug, &ConsoleConfig{JsonFormat: true})
+	buffer := &bytes.Buffer{}
+	logger.outputList()[0].LoggerAbstract.(*AdapterConsole).write.writer = buffer
+
+	entry := logger.Extract(carrier)
+	entry.Info("dropped by the propagated level")
+	entry.Warning("charge failed")
+
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("extracted entry level error: %q", buffer.String())
+	}
+	msg := map[string]interface{}{}
+	if err := json.Unmarshal([]byte(lines[0]), &msg); err != nil {
+		t.Fatal(err.Error())
+	}
+	fields, _ := msg["fields"].(map[string]interface{})
+	if msg["name"] != "payments" || fields["request_id"] != "8d2f" || fields["tenant"] != "acme" || fields["shard"] != 3.0 {
+		t.Errorf("extracted entry scope error: %s", lines[0])
+	}
+
+	// illegal headers are ignored
+	entry = logger.Extract(MapCarrier{ScopeHeaderFields: "%%%", ScopeHeaderLevel: "verbose"})
+	if entry.fields != nil || entry.hasLevel {
+		t.Error("extract illegal headers must be ignored")
+	}
+}
@@ -0,0 +1,276 @@
+package go_logger
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// quota key of records of keys beyond MaxKeys in a window
+const quotaOtherKey = "_other"
+
+// quota of records and bytes per window of every value of a field, e.g. per tenant
+type QuotaConfig struct {
+
+	// field keying the quota, e.g. "tenant", "user_id", "api_key"; records without it are not limited
+	Field string
+
+	// quota window, default 1 minute
+	Window time.Duration
+
+	// max records per key and window, 0 is unlimited
+	MaxRecords int64
+
+	// max bytes of body, field names and values per key and window, 0 is unlimited
+	MaxBytes int64
+
+	// max keys tracked per window, records of further keys share the quota of key "_other", default 10000
+	MaxKeys int
+
+	// level of the over-quota summary records, default (LoggerLevelEmergency) is Warning
+	SummaryLevel int
+}
+
+// quota enforcement state
+type quota struct {
+	QuotaConfig
+	lock        sync.Mutex
+	windowStart time.Time
+	usages      map[string]*quotaUsage
+	timer       *time.Timer // writes the summaries when the window ends, nil if not armed
+}
+
+// usage of a key in the current window
+type quotaUsage struct {
+	records        int64
+	bytes          int64
+	droppedRecords int64
+	droppedBytes   int64
+}
+
+//set a quota limiting the records or bytes per window of every value of a field, so a noisy tenant
+//cannot flood the outputs shared by all tenants; over-quota records are dropped, and a summary
+//record per over-quota key with the dropped counts is written when the window ends
+//summaries of the current window are written by Flush and Close, and when the quota is changed
+//params : config *QuotaConfig, nil removes the quota
+//return : error
+func (logger *Logger) SetQuota(config *QuotaConfig) error {
+	if config == nil {
+		logger.replaceQuota(nil)
+		return nil
+	}
+	if config.Field == "" {
+		return errors.New("logger: quota Field cannot be empty!")
+	}
+	if config.Window < 0 || config.MaxRecords < 0 || config.MaxBytes < 0 || config.MaxKeys < 0 {
+		return errors.New("logger: quota Window, MaxRecords, MaxBytes and MaxKeys cannot be negative!")
+	}
+	if config.MaxRecords == 0 && config.MaxBytes == 0 {
+		return errors.New("logger: quota MaxRecords or MaxBytes must be set!")
+	}
+	if _, ok := levelStringMapping[config.SummaryLevel]; !ok {
+		return errors.New("logger: quota SummaryLevel is illegal!")
+	}
+	quotaConfig := *config
+	if quotaConfig.Window == 0 {
+		quotaConfig.Window = time.Minute
+	}
+	if quotaConfig.MaxKeys == 0 {
+		quotaConfig.MaxKeys = 10000
+	}
+	if quotaConfig.SummaryLevel == LoggerLevelEmergency {
+		quotaConfig.SummaryLevel = LoggerLevelWarning
+	}
+	logger.replaceQuota(newQuota(quotaConfig))
+	return nil
+}
+
+//replace the quota, summaries of the previous quota are written
+func (logger *Logger) replaceQuota(next *quota) {
+	logger.lock.Lock()
+	previous, _ := logger.quota.Load().(*quota)
+	logger.quota.Store(next)
+	logger.lock.Unlock()
+
+	if previous != nil {
+		logger.writeQuotaSummaries(previous, true)
+	}
+}
+
+//new quota with an empty window
+func newQuota(config QuotaConfig) *quota {
+	return &quota{
+		QuotaConfig: config,
+		windowStart: time.Now(),
+		usages:      map[string]*quotaUsage{},
+	}
+}
+
+//check the quota of a message, summaries of the last window are dispatched
+//return : false if the message is over quota
+func (logger *Logger) checkQuota(loggerMsg *loggerMessage) bool {
+	quota, _ := logger.quota.Load().(*quota)
+	if quota == nil {
+		return true
+	}
+	isWrite, summaries := quota.check(loggerMsg, time.Now())
+	if !isWrite {
+		quota.schedule(func() {
+			logger.writeQuotaSummaries(quota, false)
+		})
+	}
+	for _, summary := range summaries {
+		logger.dispatch(summary)
+	}
+	return isWrite
+}
+
+//write the summaries of a quota
+//params : quota *quota; all bool, false writes only the summaries of an ended window
+func (logger *Logger) writeQuotaSummaries(quota *quota, all bool) {
+	summaries, pending := quota.take(time.Now(), all)
+	if pending {
+		quota.schedule(func() {
+			logger.writeQuotaSummaries(quota, false)
+		})
+	}
+	for _, summary := range summaries {
+		logger.dispatch(summary)
+	}
+}
+
+//arm the timer writing the summaries when the window ends, nothing if armed
+func (quota *quota) schedule(write func()) {
+	quota.lock.Lock()
+	defer quota.lock.Unlock()
+
+	if quota.timer == nil {
+		quota.timer = time.AfterFunc(time.Until(quota.windowStart.Add(quota.Window)), write)
+	}
+}
+
+//take the summaries of an ended window and start a new window;
+//with all, the summaries of the current window are taken too and its usage is kept
+//return : summaries, whether records of the current window are dropped and not summarized
+func (quota *quota) take(now time.Time, all bool) ([]*loggerMessage, bool) {
+	quota.lock.Lock()
+	defer quota.lock.Unlock()
+
+	if quota.timer != nil {
+		quota.timer.Stop()
+		quota.timer = nil
+	}
+	if now.Sub(quota.windowStart) >= quota.Window {
+		summaries := quota.summaries(now)
+		quota.windowStart = now
+		quota.usages = map[string]*quotaUsage{}
+		return summaries, false
+	}
+	if !all {
+		for _, usage := range quota.usages {
+			if usage.droppedRecords > 0 {
+				return nil, true
+			}
+		}
+		return nil, false
+	}
+	summaries := quota.summaries(now)
+	for _, usage := range quota.usages {
+		usage.droppedRecords = 0
+		usage.droppedBytes = 0
+	}
+	return summaries, false
+}
+
+//count a message of the current window
+//return : whether to write it, summaries of the over-quota keys of the ended window
+func (quota *quota) check(loggerMsg *loggerMessage, now time.Time) (bool, []*loggerMessage) {
+	value, ok := loggerMsg.Fields[quota.Field]
+	quota.lock.Lock()
+	defer quota.lock.Unlock()
+
+	var summaries []*loggerMessage
+	if now.Sub(quota.windowStart) >= quota.Window {
+		summaries = quota.summaries(now)
+		quota.windowStart = now
+		quota.usages = map[string]*quotaUsage{}
+	}
+	if !ok {
+		return true, summaries
+	}
+
+	key, ok := formatValue(value)
+	if !ok {
+		key = fmt.Sprint(value)
+	}
+	usage, ok := quota.usages[key]
+	if !ok {
+		if len(quota.usages) >= quota.MaxKeys {
+			key = quotaOtherKey
+			usage = quota.usages[key]
+		}
+		if usage == nil {
+			usage = &quotaUsage{}
+			quota.usages[key] = usage
+		}
+	}
+	size := quotaBytes(loggerMsg)
+	if (quota.MaxRecords > 0 && usage.records+1 > quota.MaxRecords) || (quota.MaxBytes > 0 && usage.bytes+size > quota.MaxBytes) {
+		usage.droppedRecords++
+		usage.droppedBytes += size
+		return false, summaries
+	}
+	usage.records++
+	usage.bytes += size
+	return true, summaries
+}
+
+//summary records of the over-quota keys of the window, in key order
+func (quota *quota) summaries(now time.Time) []*loggerMessage {
+	keys := []string{}
+	for key, usage := range quota.usages {
+		if usage.droppedRecords > 0 {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	summaries := make([]*loggerMessage, 0, len(keys))
+	for _, key := range keys {
+		usage := quota.usages[key]
+		summaries = append(summaries, &loggerMessage{
+			Timestamp:         now.Unix(),
+			TimestampFormat:   now.Format("2006-01-02 15:04:05"),
+			Millisecond:       now.UnixNano() / 1e6,
+			MillisecondFormat: now.Format("2006-01-02 15:04:05.999"),
+			Level:             quota.SummaryLevel,
+			LevelString:       levelStringMapping[quota.SummaryLevel],
+			Body:              "logger: quota exceeded",
+			File:              "null",
+			Function:          "null",
+			Fields: map[string]interface{}{
+				quota.Field:       key,
+				"quota_window":    quota.Window.String(),
+				"dropped_records": usage.droppedRecords,
+				"dropped_bytes":   usage.droppedBytes,
+			},
+			SchemaVersion: LoggerMessageSchemaVersion,
+		})
+	}
+	return summaries
+}
+
+//bytes of body, field names and values of a message
+func quotaBytes(loggerMsg *loggerMessage) int64 {
+	size := len(loggerMsg.Body)
+	for key, value := range loggerMsg.Fields {
+		text, ok := formatValue(value)
+		if !ok {
+			text = fmt.Sprint(value)
+		}
+		size += len(key) + len(text)
+	}
+	return int64(size)
+}
@@ -0,0 +1,87 @@
+package go_logger
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLogger_SetQuota(t *testing.T) {
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("console", LoggerLevelDebug, &ConsoleConfig{
+		Format: "%level_string% %body% %fields%",
+	})
+	buffer := &syncBuffer{}
+	logger.outputList()[0].LoggerAbstract.(*AdapterConsole).write.writer = buffer
+
+	configs := []*QuotaConfig{
+		{MaxRecords: 1},
+		{Field: "tenant"},
+		{Field: "tenant", MaxRecords: -1},
+		{Field: "tenant", MaxRecords: 1, SummaryLevel: 100},
+	}
+	for _, config := range configs {
+		if logger.SetQuota(config) == nil {
+			t.Errorf("quota config %+v must be error", config)
+		}
+	}
+	if err := logger.SetQuota(&QuotaConfig{Field: "tenant", Window: 50 * time.Millisecond, MaxRecords: 2}); err != nil {
+		t.Fatal(err.Error())
+	}
+	drops := 0
+	logger.OnDrop(func(loggerMsg *LoggerMessage, reason string) {
+		if reason == DROP_REASON_QUOTA {
+			drops++
+		}
+	})
+
+	for i := 0; i < 4; i++ {
+		logger.Infow("logger quota", "tenant", "a")
+	}
+	logger.Infow("logger quota", "tenant", "b")
+	logger.Info("logger quota")
+	expected := "Info logger quota tenant=a\nInfo logger quota tenant=a\nInfo logger quota tenant=b\nInfo logger quota \n"
+	if buffer.String() != expected || drops != 2 {
+		t.Errorf("quota records %q, drops %d", buffer.String(), drops)
+	}
+
+	buffer.Reset()
+	time.Sleep(100 * time.Millisecond)
+	expected = "Warning logger: quota exceeded dropped_bytes=38 dropped_records=2 quota_window=50ms tenant=a\n"
+	if buffer.String() != expected {
+		t.Errorf("quota summary must be written when the window ends %q", buffer.String())
+	}
+
+	buffer.Reset()
+	logger.SetQuota(&QuotaConfig{Field: "tenant", MaxBytes: 30, MaxKeys: 1})
+	logger.Infow("logger quota bytes", "tenant", "a")
+	logger.Infow("logger quota bytes", "tenant", "a")
+	logger.Infow("logger quota", "tenant", "b")
+	logger.Infow("logger quota", "tenant", "c")
+	expected = "Info logger quota bytes tenant=a\nInfo logger quota tenant=b\n"
+	if buffer.String() != expected {
+		t.Errorf("quota bytes and other key records %q", buffer.String())
+	}
+	if usage := logger.quota.Load().(*quota).usages[quotaOtherKey]; usage == nil || usage.records != 1 || usage.droppedRecords != 1 {
+		t.Errorf("quota other key usage %+v", usage)
+	}
+
+	buffer.Reset()
+	logger.Flush()
+	expected = "Warning logger: quota exceeded dropped_bytes=19 dropped_records=1 quota_window=1m0s tenant=_other\n" +
+		"Warning logger: quota exceeded dropped_bytes=25 dropped_records=1 quota_window=1m0s tenant=a\n"
+	if buffer.String() != expected {
+		t.Errorf("quota summaries of the current window must be written by Flush %q", buffer.String())
+	}
+
+	buffer.Reset()
+	logger.Infow("logger quota bytes", "tenant", "a")
+	logger.SetQuota(nil)
+	logger.Infow("logger quota", "tenant", "a")
+	expected = "Warning logger: quota exceeded dropped_bytes=25 dropped_records=1 quota_window=1m0s tenant=a\n" +
+		"Info logger quota tenant=a\n"
+	if buffer.String() != expected {
+		t.Errorf("quota summaries must be written when the quota is removed %q", buffer.String())
+	}
+}
@@ -0,0 +1,69 @@
+package go_logger
+
+import (
+	"errors"
+	"sync"
+)
+
+// ring buffer of the most recent messages
+type recentBuffer struct {
+	lock     sync.Mutex
+	messages []*loggerMessage
+	next     int
+	full     bool
+}
+
+//keep the most recent messages in memory, dumped by fatal policy
+//params : size int, 0 to disable
+//return : error
+func (logger *Logger) SetRecentBuffer(size int) error {
+	if size < 0 {
+		return errors.New("logger: recent buffer size cannot be negative!")
+	}
+	logger.lock.Lock()
+	defer logger.lock.Unlock()
+
+	recent := (*recentBuffer)(nil)
+	if size > 0 {
+		recent = &recentBuffer{
+			messages: make([]*loggerMessage, size),
+		}
+	}
+	logger.recent.Store(recent)
+	if logger.crash != nil {
+		logger.crash.recent.Store(recent)
+	}
+	return nil
+}
+
+//add message to recent buffer, the oldest is overwritten
+func (recent *recentBuffer) add(loggerMsg *loggerMessage) {
+	if recent == nil {
+		return
+	}
+	recent.lock.Lock()
+	defer recent.lock.Unlock()
+
+	recent.messages[recent.next] = loggerMsg
+	recent.next++
+	if recent.next == len(recent.messages) {
+		recent.next = 0
+		recent.full = true
+	}
+}
+
+//recent messages, oldest first
+func (recent *recentBuffer) snapshot() []*loggerMessage {
+	if recent == nil {
+		return nil
+	}
+	recent.lock.Lock()
+	defer recent.lock.Unlock()
+
+	if !recent.full {
+		return append([]*loggerMessage{}, recent.messages[:recent.next]...)
+	}
+	messages := make([]*loggerMessage, 0, len(recent.messages))
+	messages = append(messages, recent.messages[recent.next:]...)
+	return append(messages, recent.messages[:recent.next]...)
+}
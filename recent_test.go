@@ -0,0 +1,29 @@
+package go_logger
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestLogger_SetRecentBuffer(t *testing.T) {
+
+	logger := NewLogger()
+	logger.Detach("console")
+	if logger.SetRecentBuffer(-1) == nil {
+		t.Error("negative recent buffer size must be error")
+	}
+	logger.SetRecentBuffer(3)
+
+	logger.Info("logger recent 1")
+	messages := logger.recent.Load().(*recentBuffer).snapshot()
+	if len(messages) != 1 || messages[0].Body != "logger recent 1" {
+		t.Error("recent buffer must keep written messages")
+	}
+	for i := 2; i <= 5; i++ {
+		logger.Info("logger recent " + strconv.Itoa(i))
+	}
+	messages = logger.recent.Load().(*recentBuffer).snapshot()
+	if len(messages) != 3 || messages[0].Body != "logger recent 3" || messages[2].Body != "logger recent 5" {
+		t.Error("recent buffer must keep the most recent messages, oldest first")
+	}
+}
@@ -0,0 +1,153 @@
+package go_logger
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// upper bounds of the record size histogram buckets, the last bucket is unbounded
+var recordSizeBuckets = []int64{64, 256, 1 << 10, 4 << 10, 16 << 10, 64 << 10, 256 << 10, 1 << 20}
+
+// min interval between warnings of oversized records of an output
+const recordSizeWarnInterval = time.Minute
+
+// encoded record size distribution of an output
+type RecordSizeStats struct {
+	Count      int64 `json:"count"`
+	TotalBytes int64 `json:"total_bytes"`
+	MaxBytes   int64 `json:"max_bytes"`
+
+	// records larger than the warn size
+	Oversized int64 `json:"oversized"`
+
+	// histogram, record count per size bucket
+	Buckets []RecordSizeBucket `json:"buckets"`
+}
+
+// record size histogram bucket
+type RecordSizeBucket struct {
+
+	// max bytes of records in the bucket, 0 is unbounded
+	UpperBytes int64 `json:"upper_bytes"`
+	Count      int64 `json:"count"`
+}
+
+// record size metrics of an output
+type recordSizes struct {
+	adapterName string
+	format      string // text format of the output, "" for JSON
+	jsonProfile string
+	warnBytes   int64
+
+	count      int64
+	totalBytes int64
+	maxBytes   int64
+	oversized  int64
+	buckets    []int64
+
+	lock     sync.Mutex
+	lastWarn time.Time
+}
+
+//track the encoded record size distribution of an attached output, the size is the record
+//encoded by the Format or JsonFormat (JsonProfile) of the adapter config, JSON if it has none
+//params : adapterName string, warnBytes int, a warning is printed for larger records, at most once a minute; 0 never warns
+//return : error
+func (logger *Logger) SetRecordSizeMetrics(adapterName string, warnBytes int) error {
+	if warnBytes < 0 {
+		return errors.New("logger: record size warn bytes cannot be negative!")
+	}
+	logger.lock.Lock()
+	defer logger.lock.Unlock()
+
+	return logger.updateOutput(adapterName, func(output *outputLogger) error {
+		sizes := &recordSizes{
+			adapterName: adapterName,
+			warnBytes:   int64(warnBytes),
+			buckets:     make([]int64, len(recordSizeBuckets)+1),
+		}
+		format, jsonFormat := configFormat(output.config)
+		if !jsonFormat {
+			sizes.format = format
+		}
+		sizes.jsonProfile = configJsonProfile(output.config)
+		output.recordSizes = sizes
+		return nil
+	})
+}
+
+//get the record size distribution of an output
+//params : adapterName string
+//return : RecordSizeStats, false if the output is not attached or not tracked
+func (logger *Logger) RecordSizes(adapterName string) (RecordSizeStats, bool) {
+	output := logger.output(adapterName)
+	if output == nil || output.recordSizes == nil {
+		return RecordSizeStats{}, false
+	}
+	return output.recordSizes.stats(), true
+}
+
+//count the encoded size of a record
+func (sizes *recordSizes) observe(loggerMsg *loggerMessage) {
+	var size int64
+	if sizes.format != "" {
+		size = int64(len(loggerMsg.formatText(sizes.format)))
+	} else {
+		size = int64(len(loggerMsg.formatJson(sizes.jsonProfile)))
+	}
+
+	atomic.AddInt64(&sizes.count, 1)
+	atomic.AddInt64(&sizes.totalBytes, size)
+	for {
+		max := atomic.LoadInt64(&sizes.maxBytes)
+		if size <= max || atomic.CompareAndSwapInt64(&sizes.maxBytes, max, size) {
+			break
+		}
+	}
+	bucket := len(recordSizeBuckets)
+	for i, upper := range recordSizeBuckets {
+		if size <= upper {
+			bucket = i
+			break
+		}
+	}
+	atomic.AddInt64(&sizes.buckets[bucket], 1)
+
+	if sizes.warnBytes == 0 || size <= sizes.warnBytes {
+		return
+	}
+	atomic.AddInt64(&sizes.oversized, 1)
+	sizes.lock.Lock()
+	warn := time.Since(sizes.lastWarn) >= recordSizeWarnInterval
+	if warn {
+		sizes.lastWarn = time.Now()
+	}
+	sizes.lock.Unlock()
+	if warn {
+		fmt.Fprintf(os.Stderr, "logger: record of %d bytes to adapter:%v exceeds %d bytes, body: %.64s\n",
+			size, sizes.adapterName, sizes.warnBytes, loggerMsg.Body)
+	}
+}
+
+//copy of the distribution
+func (sizes *recordSizes) stats() RecordSizeStats {
+	stats := RecordSizeStats{
+		Count:      atomic.LoadInt64(&sizes.count),
+		TotalBytes: atomic.LoadInt64(&sizes.totalBytes),
+		MaxBytes:   atomic.LoadInt64(&sizes.maxBytes),
+		Oversized:  atomic.LoadInt64(&sizes.oversized),
+		Buckets:    make([]RecordSizeBucket, 0, len(sizes.buckets)),
+	}
+	for i := range sizes.buckets {
+		bucket := RecordSizeBucket{Count: atomic.LoadInt64(&sizes.buckets[i])}
+		if i < len(recordSizeBuckets) {
+			bucket.UpperBytes = recordSizeBuckets[i]
+		}
+		stats.Buckets = append(stats.Buckets, bucket)
+	}
+	return stats
+}
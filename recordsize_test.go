@@ -0,0 +1,54 @@
+package go_logger
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestLogger_SetRecordSizeMetrics(t *testing.T) {
+
+	logger := NewLogger()
+	if logger.SetRecordSizeMetrics(CONSOLE_ADAPTER_NAME, -1) == nil {
+		t.Error("record size negative warn bytes must be error")
+	}
+	if logger.SetRecordSizeMetrics("file", 0) == nil {
+		t.Error("record size metrics of not attached adapter must be error")
+	}
+	if _, ok := logger.RecordSizes(CONSOLE_ADAPTER_NAME); ok {
+		t.Error("record sizes of not tracked output must be false")
+	}
+
+	logger.Detach(CONSOLE_ADAPTER_NAME)
+	logger.Attach(CONSOLE_ADAPTER_NAME, LoggerLevelDebug, &ConsoleConfig{Format: "%body%"})
+	buffer := &bytes.Buffer{}
+	logger.outputList()[0].LoggerAbstract.(*AdapterConsole).write.writer = buffer
+	err := logger.SetRecordSizeMetrics(CONSOLE_ADAPTER_NAME, 100)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	logger.Info("small")
+	logger.Info(strings.Repeat("l", 300))
+	logger.Info(strings.Repeat("l", 400))
+
+	stats, ok := logger.RecordSizes(CONSOLE_ADAPTER_NAME)
+	if !ok {
+		t.Fatal("record sizes of tracked output must be true")
+	}
+	if stats.Count != 3 || stats.MaxBytes != 400 || stats.Oversized != 2 {
+		t.Errorf("record size stats error: %+v", stats)
+	}
+	if stats.TotalBytes != 5+300+400 {
+		t.Errorf("record size total bytes error: %d", stats.TotalBytes)
+	}
+	expected := map[int64]int64{64: 1, 1 << 10: 2}
+	if len(stats.Buckets) != len(recordSizeBuckets)+1 {
+		t.Errorf("record size buckets error: %+v", stats.Buckets)
+	}
+	for _, bucket := range stats.Buckets {
+		if bucket.Count != expected[bucket.UpperBytes] {
+			t.Errorf("record size bucket %d count error: %d", bucket.UpperBytes, bucket.Count)
+		}
+	}
+}
@@ -0,0 +1,336 @@
+//go:build !tinygo && !logger_tiny
+// +build !tinygo,!logger_tiny
+
+package go_logger
+
+import (
+	"bufio"
+	"crypto/tls"
+	"errors"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const REDIS_ADAPTER_NAME = "redis"
+
+// adapter redis, messages are pushed to a list (LPUSH) or appended to a stream (XADD)
+// a consumer drains the list by BRPOP, or reads the stream by XREAD / XREADGROUP
+type AdapterRedis struct {
+	config *RedisConfig
+	pool   chan *redisConn // idle connections
+}
+
+// redis config
+type RedisConfig struct {
+
+	// server address, "127.0.0.1:6379"
+	Address string `config:"required"`
+
+	// AUTH username of redis 6 ACL, empty authenticates by Password only
+	Username string
+
+	// AUTH password, empty does not authenticate
+	Password string
+
+	// SELECT database, default 0
+	DB int
+
+	// connect by TLS, TLSConfig nil verifies the server by the host of Address
+	TLS       bool
+	TLSConfig *tls.Config
+
+	// list or stream key
+	Key string `config:"required"`
+
+	// append to the stream Key by XADD with fields level and message, default LPUSH to the list Key
+	Stream bool
+
+	// max length of the list or stream, the oldest messages are trimmed, 0 is unlimited
+	// streams are trimmed approximately (MAXLEN ~)
+	MaxLen int64
+
+	// idle connections kept, default 4
+	PoolSize int
+
+	// dial, read and write timeout, default 5 seconds
+	Timeout time.Duration
+
+	// message format, see ConsoleConfig Format, empty writes the message as JSON
+	Format string
+}
+
+// connection of the pool
+type redisConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// error reply of the server
+type redisError string
+
+func (err redisError) Error() string {
+	return "redis: " + string(err)
+}
+
+// commands not sent, no byte was written before the connection failed
+type redisSendError struct {
+	error
+}
+
+func (rc *RedisConfig) Name() string {
+	return REDIS_ADAPTER_NAME
+}
+
+func NewAdapterRedis() LoggerAbstract {
+	return &AdapterRedis{
+		config: &RedisConfig{},
+	}
+}
+
+func (adapterRedis *AdapterRedis) Init(redisConfig Config) error {
+	if redisConfig.Name() != REDIS_ADAPTER_NAME {
+		return errors.New("logger redis adapter init error, config must RedisConfig")
+	}
+
+	rc := redisConfig.(*RedisConfig)
+	adapterRedis.config = rc
+
+	if rc.Address == "" {
+		return errors.New("config Address cannot be empty!")
+	}
+	if rc.Key == "" {
+		return errors.New("config Key cannot be empty!")
+	}
+	if rc.DB < 0 || rc.MaxLen < 0 || rc.PoolSize < 0 || rc.Timeout < 0 {
+		return errors.New("config DB, MaxLen, PoolSize and Timeout cannot be negative!")
+	}
+	if rc.PoolSize == 0 {
+		rc.PoolSize = 4
+	}
+	if rc.Timeout == 0 {
+		rc.Timeout = 5 * time.Second
+	}
+	adapterRedis.pool = make(chan *redisConn, rc.PoolSize)
+
+	// check the server and the credentials
+	conn, err := adapterRedis.dial()
+	if err != nil {
+		return err
+	}
+	adapterRedis.put(conn)
+	return nil
+}
+
+//connect, authenticate and select the database
+func (adapterRedis *AdapterRedis) dial() (*redisConn, error) {
+	config := adapterRedis.config
+	conn, err := net.DialTimeout("tcp", config.Address, config.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	if config.TLS {
+		tlsConfig := config.TLSConfig
+		if tlsConfig == nil {
+			host, _, _ := net.SplitHostPort(config.Address)
+			tlsConfig = &tls.Config{ServerName: host}
+		}
+		conn = tls.Client(conn, tlsConfig)
+	}
+	redis := &redisConn{conn: conn, reader: bufio.NewReader(conn)}
+
+	commands := [][]string{}
+	if config.Password != "" {
+		if config.Username != "" {
+			commands = append(commands, []string{"AUTH", config.Username, config.Password})
+		} else {
+			commands = append(commands, []string{"AUTH", config.Password})
+		}
+	}
+	if config.DB != 0 {
+		commands = append(commands, []string{"SELECT", strconv.Itoa(config.DB)})
+	}
+	if len(commands) > 0 {
+		if err := redis.do(config.Timeout, commands...); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	return redis, nil
+}
+
+//idle connection of the pool, or a new connection
+func (adapterRedis *AdapterRedis) get() (*redisConn, bool, error) {
+	select {
+	case conn := <-adapterRedis.pool:
+		return conn, true, nil
+	default:
+	}
+	conn, err := adapterRedis.dial()
+	return conn, false, err
+}
+
+//return a connection to the pool, closed if the pool is full
+func (adapterRedis *AdapterRedis) put(conn *redisConn) {
+	select {
+	case adapterRedis.pool <- conn:
+	default:
+		conn.conn.Close()
+	}
+}
+
+func (adapterRedis *AdapterRedis) Write(loggerMsg *loggerMessage) error {
+	config := adapterRedis.config
+	message := ""
+	if config.Format == "" {
+		message = loggerMsg.formatJson("")
+	} else {
+		message = loggerMsg.formatText(config.Format)
+	}
+
+	var commands [][]string
+	if config.Stream {
+		command := []string{"XADD", config.Key}
+		if config.MaxLen > 0 {
+			command = append(command, "MAXLEN", "~", strconv.FormatInt(config.MaxLen, 10))
+		}
+		commands = [][]string{append(command, "*", "level", strings.ToLower(loggerMsg.LevelString), "message", message)}
+	} else {
+		commands = [][]string{{"LPUSH", config.Key, message}}
+		if config.MaxLen > 0 {
+			commands = append(commands, []string{"LTRIM", config.Key, "0", strconv.FormatInt(config.MaxLen-1, 10)})
+		}
+	}
+
+	conn, pooled, err := adapterRedis.get()
+	if err != nil {
+		return err
+	}
+	err = conn.do(config.Timeout, commands...)
+	if _, ok := err.(redisError); err != nil && !ok {
+		conn.conn.Close()
+		// an idle connection that failed to send is retried once on a new connection,
+		// commands sent may have been executed, e.g. a read timeout, and are not retried
+		if _, sendFailed := err.(redisSendError); !pooled || !sendFailed {
+			return err
+		}
+		if conn, err = adapterRedis.dial(); err != nil {
+			return err
+		}
+		err = conn.do(config.Timeout, commands...)
+		if _, ok := err.(redisError); err != nil && !ok {
+			conn.conn.Close()
+			return err
+		}
+	}
+	adapterRedis.put(conn)
+	return err
+}
+
+//send pipelined commands and read their replies, the first error reply is returned
+func (conn *redisConn) do(timeout time.Duration, commands ...[]string) error {
+	conn.conn.SetDeadline(time.Now().Add(timeout))
+	var b strings.Builder
+	for _, command := range commands {
+		b.WriteString("*" + strconv.Itoa(len(command)) + "\r\n")
+		for _, arg := range command {
+			b.WriteString("$" + strconv.Itoa(len(arg)) + "\r\n" + arg + "\r\n")
+		}
+	}
+	if n, err := io.WriteString(conn.conn, b.String()); err != nil {
+		if n == 0 {
+			return redisSendError{err}
+		}
+		return err
+	}
+	var replyErr error
+	for range commands {
+		_, err := redisReadReply(conn.reader)
+		if err == nil {
+			continue
+		}
+		if _, ok := err.(redisError); !ok {
+			return err
+		}
+		if replyErr == nil {
+			replyErr = err
+		}
+	}
+	return replyErr
+}
+
+//read a RESP reply, string, int64, nil or []interface{}, an error reply is redisError
+func redisReadReply(reader *bufio.Reader) (interface{}, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	if len(line) < 3 || !strings.HasSuffix(line, "\r\n") {
+		return nil, errors.New("redis: reply " + strconv.Quote(line) + " is illegal!")
+	}
+	value := line[1 : len(line)-2]
+	switch line[0] {
+	case '+':
+		return value, nil
+	case '-':
+		return nil, redisError(value)
+	case ':':
+		return strconv.ParseInt(value, 10, 64)
+	case '$':
+		size, err := strconv.Atoi(value)
+		if err != nil || size < 0 {
+			return nil, err
+		}
+		data := make([]byte, size+2)
+		if _, err := io.ReadFull(reader, data); err != nil {
+			return nil, err
+		}
+		return string(data[:size]), nil
+	case '*':
+		count, err := strconv.Atoi(value)
+		if err != nil || count < 0 {
+			return nil, err
+		}
+		values := make([]interface{}, 0, count)
+		for i := 0; i < count; i++ {
+			element, err := redisReadReply(reader)
+			if _, ok := err.(redisError); err != nil && !ok {
+				return nil, err
+			}
+			values = append(values, element)
+		}
+		return values, nil
+	}
+	return nil, errors.New("redis: reply " + strconv.Quote(line) + " is illegal!")
+}
+
+func (adapterRedis *AdapterRedis) Flush() {
+
+}
+
+//close the idle connections, the adapter must not be written after Close
+func (adapterRedis *AdapterRedis) Close() error {
+	for {
+		select {
+		case conn := <-adapterRedis.pool:
+			conn.conn.Close()
+		default:
+			return nil
+		}
+	}
+}
+
+func (adapterRedis *AdapterRedis) Name() string {
+	return REDIS_ADAPTER_NAME
+}
+
+//new empty config of the adapter, describes the config fields
+func (adapterRedis *AdapterRedis) NewConfig() Config {
+	return &RedisConfig{}
+}
+
+func init() {
+	Register(REDIS_ADAPTER_NAME, NewAdapterRedis)
+}
@@ -0,0 +1,221 @@
+//go:build !tinygo && !logger_tiny
+// +build !tinygo,!logger_tiny
+
+package go_logger
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fake redis server, records commands and replies by reply
+type redisServer struct {
+	listener net.Listener
+	lock     sync.Mutex
+	commands [][]string
+	conns    int
+	reply    func(command []string) string
+}
+
+func newRedisServer(t *testing.T, reply func(command []string) string) *redisServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	server := &redisServer{listener: listener, reply: reply}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			server.lock.Lock()
+			server.conns++
+			server.lock.Unlock()
+			go server.serve(conn)
+		}
+	}()
+	return server
+}
+
+func (server *redisServer) serve(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	for {
+		request, err := redisReadReply(reader)
+		if err != nil {
+			return
+		}
+		command := []string{}
+		for _, arg := range request.([]interface{}) {
+			command = append(command, arg.(string))
+		}
+		server.lock.Lock()
+		server.commands = append(server.commands, command)
+		server.lock.Unlock()
+		conn.Write([]byte(server.reply(command)))
+	}
+}
+
+func (server *redisServer) received() ([][]string, int) {
+	server.lock.Lock()
+	defer server.lock.Unlock()
+	return append([][]string{}, server.commands...), server.conns
+}
+
+func TestAdapterRedis_Init(t *testing.T) {
+
+	configs := []*RedisConfig{
+		{},
+		{Address: "127.0.0.1:6379"},
+		{Address: "127.0.0.1:6379", Key: "logs", MaxLen: -1},
+	}
+	for _, config := range configs {
+		if NewAdapterRedis().Init(config) == nil {
+			t.Errorf("redis adapter config %+v must be error", config)
+		}
+	}
+
+	server := newRedisServer(t, func(command []string) string {
+		return "-WRONGPASS invalid username-password pair\r\n"
+	})
+	defer server.listener.Close()
+	err := NewAdapterRedis().Init(&RedisConfig{Address: server.listener.Addr().String(), Key: "logs", Password: "bad"})
+	if err == nil || !strings.Contains(err.Error(), "WRONGPASS") {
+		t.Errorf("redis adapter must return the AUTH error, got %v", err)
+	}
+}
+
+func TestAdapterRedis_WriteList(t *testing.T) {
+
+	server := newRedisServer(t, func(command []string) string {
+		switch command[0] {
+		case "LPUSH":
+			return ":1\r\n"
+		}
+		return "+OK\r\n"
+	})
+	defer server.listener.Close()
+
+	redisAdapter := NewAdapterRedis()
+	err := redisAdapter.Init(&RedisConfig{
+		Address:  server.listener.Addr().String(),
+		Username: "app",
+		Password: "secret",
+		DB:       2,
+		Key:      "logs",
+		MaxLen:   100,
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer redisAdapter.(*AdapterRedis).Close()
+
+	for i := 0; i < 3; i++ {
+		err = redisAdapter.Write(&loggerMessage{Level: LoggerLevelInfo, LevelString: "Info", Body: "logger redis"})
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+
+	commands, conns := server.received()
+	if conns != 1 {
+		t.Errorf("redis adapter must reuse the pooled connection, %d connections", conns)
+	}
+	if len(commands) != 8 || strings.Join(commands[0], " ") != "AUTH app secret" || strings.Join(commands[1], " ") != "SELECT 2" {
+		t.Fatalf("redis adapter commands error: %v", commands)
+	}
+	if commands[2][0] != "LPUSH" || commands[2][1] != "logs" || strings.Join(commands[3], " ") != "LTRIM logs 0 99" {
+		t.Errorf("redis adapter list commands error: %v", commands)
+	}
+	message := map[string]interface{}{}
+	json.Unmarshal([]byte(commands[2][2]), &message)
+	if message["body"] != "logger redis" {
+		t.Errorf("redis adapter message must be JSON: %s", commands[2][2])
+	}
+}
+
+func TestAdapterRedis_WriteStream(t *testing.T) {
+
+	server := newRedisServer(t, func(command []string) string {
+		return "$15\r\n1700000000000-0\r\n"
+	})
+	defer server.listener.Close()
+
+	redisAdapter := NewAdapterRedis()
+	err := redisAdapter.Init(&RedisConfig{
+		Address: server.listener.Addr().String(),
+		Key:     "logs",
+		Stream:  true,
+		MaxLen:  1000,
+		Format:  "%body%",
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer redisAdapter.(*AdapterRedis).Close()
+
+	err = redisAdapter.Write(&loggerMessage{Level: LoggerLevelError, LevelString: "Error", Body: "logger redis stream"})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	commands, _ := server.received()
+	if len(commands) != 1 || strings.Join(commands[0], " ") != "XADD logs MAXLEN ~ 1000 * level error message logger redis stream" {
+		t.Errorf("redis adapter stream command error: %v", commands)
+	}
+}
+
+func TestAdapterRedis_WriteRetry(t *testing.T) {
+
+	lock := sync.Mutex{}
+	replies := 0
+	server := newRedisServer(t, func(command []string) string {
+		lock.Lock()
+		defer lock.Unlock()
+		replies++
+		// the third command times out after it is received
+		if replies == 3 {
+			return ""
+		}
+		return ":1\r\n"
+	})
+	defer server.listener.Close()
+
+	redisAdapter := NewAdapterRedis()
+	err := redisAdapter.Init(&RedisConfig{
+		Address: server.listener.Addr().String(),
+		Key:     "logs",
+		Format:  "%body%",
+		Timeout: 100 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer redisAdapter.(*AdapterRedis).Close()
+	adapterRedis := redisAdapter.(*AdapterRedis)
+
+	loggerMsg := &loggerMessage{Level: LoggerLevelInfo, LevelString: "Info", Body: "logger redis retry"}
+	if err := redisAdapter.Write(loggerMsg); err != nil {
+		t.Fatal(err.Error())
+	}
+	// the pooled connection fails to send, the commands are sent on a new connection
+	conn := <-adapterRedis.pool
+	conn.conn.Close()
+	adapterRedis.pool <- conn
+	if err := redisAdapter.Write(loggerMsg); err != nil {
+		t.Fatalf("redis adapter must retry commands not sent: %v", err)
+	}
+	// the commands sent are not retried after a read timeout
+	if err := redisAdapter.Write(loggerMsg); err == nil {
+		t.Error("redis adapter read timeout must be error")
+	}
+	commands, conns := server.received()
+	if len(commands) != 3 || conns != 2 {
+		t.Errorf("redis adapter must not retry commands sent, %d commands, %d connections", len(commands), conns)
+	}
+}
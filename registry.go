@@ -0,0 +1,196 @@
+package go_logger
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+	"sync"
+)
+
+// named loggers of the package-level registry
+var loggerRegistry = struct {
+	lock    sync.RWMutex
+	loggers map[string]*Logger
+}{loggers: map[string]*Logger{}}
+
+// loggers config file, JSON
+//
+//	{
+//	  "loggers": {
+//	    "access": {"outputs": [{"adapter": "file", "level": "info", "config": {"Filename": "./access.log"}}]},
+//	    "audit": {"async": 100, "outputs": [{"adapter": "api", "level": "notice", "config": {"Url": "http://audit/", "Method": "POST"}}]}
+//	  }
+//	}
+type RegistryConfig struct {
+	Loggers map[string]LoggerConfig `json:"loggers"`
+}
+
+// logger of the loggers config file
+type LoggerConfig struct {
+
+	// async channel length, 0 is sync
+	Async int `json:"async"`
+
+	// outputs, a logger without outputs writes nothing
+	Outputs []OutputConfig `json:"outputs"`
+}
+
+// output of a logger in the loggers config file
+type OutputConfig struct {
+
+	// registered adapter name, the adapter must implement ConfigFactory
+	Adapter string `json:"adapter"`
+
+	// level name, "emergency" ... "debug", default "debug"
+	Level string `json:"level"`
+
+	// adapter config, decoded into the adapter NewConfig(), keys are config field names
+	Config json.RawMessage `json:"config"`
+}
+
+//get a named logger of the registry
+//params : name string
+//return : *Logger, the default logger if name is not registered
+func Get(name string) *Logger {
+	loggerRegistry.lock.RLock()
+	logger, ok := loggerRegistry.loggers[name]
+	loggerRegistry.lock.RUnlock()
+	if !ok {
+		return Default()
+	}
+	return logger
+}
+
+//register a named logger, replaces the logger of the same name
+//params : name string, logger *Logger, nil to unregister
+func RegisterLogger(name string, logger *Logger) {
+	loggerRegistry.lock.Lock()
+	defer loggerRegistry.lock.Unlock()
+
+	if logger == nil {
+		delete(loggerRegistry.loggers, name)
+		return
+	}
+	loggerRegistry.loggers[name] = logger
+}
+
+//create and register the loggers of a JSON loggers config file
+//params : filename string
+//return : error, no logger is registered on error
+func LoadLoggers(filename string) error {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return err
+	}
+	config := RegistryConfig{}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return errors.New("logger: loggers config " + filename + " is illegal, error: " + err.Error())
+	}
+	return LoadLoggersConfig(config)
+}
+
+//create and register the loggers of a loggers config
+//params : config RegistryConfig
+//return : error, no logger is registered on error
+func LoadLoggersConfig(config RegistryConfig) error {
+	loggers := make(map[string]*Logger, len(config.Loggers))
+	for name, loggerConfig := range config.Loggers {
+		logger, err := newConfiguredLogger(loggerConfig)
+		if err != nil {
+			for _, created := range loggers {
+				created.closeOutputs()
+			}
+			return errors.New("logger: logger " + name + " " + err.Error())
+		}
+		loggers[name] = logger
+	}
+
+	loggerRegistry.lock.Lock()
+	defer loggerRegistry.lock.Unlock()
+	for name, logger := range loggers {
+		loggerRegistry.loggers[name] = logger
+	}
+	return nil
+}
+
+//new logger of a logger config, without the default console output
+func newConfiguredLogger(loggerConfig LoggerConfig) (*Logger, error) {
+	if loggerConfig.Async < 0 {
+		return nil, errors.New("async cannot be negative!")
+	}
+	logger := NewLogger()
+	logger.Detach(CONSOLE_ADAPTER_NAME)
+	for _, outputConfig := range loggerConfig.Outputs {
+		if err := logger.attachOutputConfig(outputConfig); err != nil {
+			logger.closeOutputs()
+			return nil, err
+		}
+	}
+	if loggerConfig.Async > 0 {
+		logger.SetAsync(loggerConfig.Async)
+	}
+	return logger, nil
+}
+
+//attach an output of the loggers config, errors are returned instead of exiting the process
+func (logger *Logger) attachOutputConfig(outputConfig OutputConfig) error {
+	adapterName := outputConfig.Adapter
+	logFun, ok := adapters[adapterName]
+	if !ok {
+		return errors.New("adapter " + adapterName + " is not registered!")
+	}
+	if logger.output(adapterName) != nil {
+		return errors.New("adapter " + adapterName + " already attached!")
+	}
+	level := LoggerLevelDebug
+	if outputConfig.Level != "" {
+		level = -1
+		for l, levelString := range levelStringMapping {
+			if strings.EqualFold(levelString, outputConfig.Level) {
+				level = l
+			}
+		}
+		if level == -1 {
+			return errors.New("adapter " + adapterName + " level " + outputConfig.Level + " is illegal!")
+		}
+	}
+
+	adapterLog := logFun()
+	factory, ok := adapterLog.(ConfigFactory)
+	if !ok {
+		return errors.New("adapter " + adapterName + " does not implement ConfigFactory!")
+	}
+	config := factory.NewConfig()
+	if len(outputConfig.Config) > 0 {
+		if err := json.Unmarshal(outputConfig.Config, config); err != nil {
+			return errors.New("adapter " + adapterName + " config is illegal, error: " + err.Error())
+		}
+	}
+	if err := checkConfigFormat(config); err != nil {
+		return err
+	}
+	if err := adapterLog.Init(config); err != nil {
+		return errors.New("adapter " + adapterName + " init failed, error: " + err.Error())
+	}
+
+	logger.lock.Lock()
+	defer logger.lock.Unlock()
+	logger.addOutput(&outputLogger{
+		outputState:    &outputState{level: int64(level)},
+		Name:           adapterName,
+		LoggerAbstract: adapterLog,
+		config:         config,
+	})
+	return nil
+}
+
+//close outputs implementing io.Closer, for loggers discarded before use
+func (logger *Logger) closeOutputs() {
+	for _, output := range logger.outputList() {
+		if closer, ok := output.LoggerAbstract.(io.Closer); ok {
+			closer.Close()
+		}
+	}
+}
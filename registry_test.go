@@ -0,0 +1,74 @@
+package go_logger
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadLoggers(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "go_logger")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer os.RemoveAll(dir)
+
+	accessFile := filepath.Join(dir, "access.log")
+	config := `{
+		"loggers": {
+			"access": {"outputs": [{"adapter": "file", "level": "info", "config": {"Filename": "` + filepath.ToSlash(accessFile) + `", "Format": "%level_string% %body%"}}]},
+			"audit": {"async": 10, "outputs": [{"adapter": "console", "level": "notice"}]}
+		}
+	}`
+	configFile := filepath.Join(dir, "loggers.json")
+	ioutil.WriteFile(configFile, []byte(config), 0644)
+
+	if err := LoadLoggers(configFile); err != nil {
+		t.Fatal(err.Error())
+	}
+	defer RegisterLogger("access", nil)
+	defer RegisterLogger("audit", nil)
+
+	access := Get("access")
+	access.Debug("logger registry debug")
+	access.Info("logger registry info")
+	access.Flush()
+	data, _ := ioutil.ReadFile(accessFile)
+	if string(data) != "Info logger registry info\r\n" {
+		t.Errorf("registry logger access output error: %q", string(data))
+	}
+	if len(access.outputList()) != 1 {
+		t.Error("registry logger must not have the default console output")
+	}
+	if Get("audit") == access || Get("audit").synchronous {
+		t.Error("registry logger audit must be async and independent")
+	}
+	if Get("unknown") != Default() {
+		t.Error("unknown registry logger must be the default logger")
+	}
+}
+
+func TestLoadLoggersConfigError(t *testing.T) {
+
+	tests := map[string]OutputConfig{
+		"is not registered":                {Adapter: "not_registered"},
+		"level trace is illegal":           {Adapter: "console", Level: "trace"},
+		"does not implement ConfigFactory": {Adapter: "count"},
+		"config is illegal":                {Adapter: "console", Config: []byte(`{"Color": "yes"}`)},
+		"init failed":                      {Adapter: "file", Config: []byte(`{"Filename": ""}`)},
+	}
+	for message, outputConfig := range tests {
+		err := LoadLoggersConfig(RegistryConfig{Loggers: map[string]LoggerConfig{
+			"broken": {Outputs: []OutputConfig{outputConfig}},
+		}})
+		if err == nil || !strings.Contains(err.Error(), message) {
+			t.Errorf("loggers config error must contain %q, got %v", message, err)
+		}
+	}
+	if Get("broken") != Default() {
+		t.Error("broken registry logger must not be registered")
+	}
+}
@@ -0,0 +1,95 @@
+package go_logger
+
+import (
+	"errors"
+)
+
+const RELAY_ADAPTER_NAME = "relay"
+
+// max relay hops of a message, guard against relay loops
+const relayMaxHops = 8
+
+// adapter relay, forward messages to another logger
+type AdapterRelay struct {
+	config *RelayConfig
+}
+
+// relay config
+type RelayConfig struct {
+
+	// target logger, messages pass its route rules, sampling and outputs levels
+	Logger *Logger `config:"required"`
+}
+
+func (rc *RelayConfig) Name() string {
+	return RELAY_ADAPTER_NAME
+}
+
+func NewAdapterRelay() LoggerAbstract {
+	return &AdapterRelay{
+		config: &RelayConfig{},
+	}
+}
+
+func (adapterRelay *AdapterRelay) Init(relayConfig Config) error {
+	if relayConfig.Name() != RELAY_ADAPTER_NAME {
+		return errors.New("logger relay adapter init error, config must RelayConfig")
+	}
+
+	rc := relayConfig.(*RelayConfig)
+	adapterRelay.config = rc
+
+	if rc.Logger == nil {
+		return errors.New("config Logger cannot be nil!")
+	}
+	return nil
+}
+
+func (adapterRelay *AdapterRelay) Write(loggerMsg *loggerMessage) error {
+	if loggerMsg.relayHops >= relayMaxHops {
+		return errors.New("logger relay adapter write error, relay loop detected")
+	}
+	return adapterRelay.config.Logger.relay(loggerMsg)
+}
+
+func (adapterRelay *AdapterRelay) Flush() {
+	adapterRelay.FlushError()
+}
+
+func (adapterRelay *AdapterRelay) FlushError() error {
+	return adapterRelay.config.Logger.Flush()
+}
+
+func (adapterRelay *AdapterRelay) Name() string {
+	return RELAY_ADAPTER_NAME
+}
+
+//new empty config of the adapter, describes the config fields
+func (adapterRelay *AdapterRelay) NewConfig() Config {
+	return &RelayConfig{}
+}
+
+//write message relayed from another logger, caller and time are kept
+//params : loggerMsg *loggerMessage
+//return : error
+func (logger *Logger) relay(loggerMsg *loggerMessage) error {
+	relayMsg := *loggerMsg
+	relayMsg.routeAdapters = nil
+	relayMsg.relayHops++
+
+	logger.stats.count(relayMsg.Level)
+	if !logger.route(&relayMsg) {
+		logger.drop(&relayMsg, DROP_REASON_ROUTE)
+	} else if !logger.sample(&relayMsg) {
+		logger.drop(&relayMsg, DROP_REASON_SAMPLED)
+	} else if !logger.checkQuota(&relayMsg) {
+		logger.drop(&relayMsg, DROP_REASON_QUOTA)
+	} else {
+		logger.dispatch(&relayMsg)
+	}
+	return nil
+}
+
+func init() {
+	Register(RELAY_ADAPTER_NAME, NewAdapterRelay)
+}
@@ -0,0 +1,63 @@
+package go_logger
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestAdapterRelay_Init(t *testing.T) {
+
+	relayAdapter := NewAdapterRelay()
+
+	err := relayAdapter.Init(&RelayConfig{})
+	if err == nil {
+		t.Error("relay adapter init without Logger must be error")
+	}
+	err = relayAdapter.Init(&RelayConfig{Logger: NewLogger()})
+	if err != nil {
+		t.Error(err.Error())
+	}
+}
+
+func TestAdapterRelay_Write(t *testing.T) {
+
+	alerting := NewLogger()
+	alerting.Detach("console")
+	alerting.Attach("console", LoggerLevelDebug, &ConsoleConfig{
+		Format: "%file% [%level_string%] %body%",
+	})
+	alertingBuffer := &bytes.Buffer{}
+	alerting.outputList()[0].LoggerAbstract.(*AdapterConsole).write.writer = alertingBuffer
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("console", LoggerLevelDebug, &ConsoleConfig{
+		Format: "%body%",
+	})
+	buffer := &bytes.Buffer{}
+	logger.outputList()[0].LoggerAbstract.(*AdapterConsole).write.writer = buffer
+	logger.Attach("relay", LoggerLevelError, &RelayConfig{Logger: alerting})
+
+	logger.Info("logger relay info")
+	logger.Error("logger relay error")
+	logger.Flush()
+
+	if buffer.String() != "logger relay info\nlogger relay error\n" {
+		t.Error("relay source logger output error: " + buffer.String())
+	}
+	if alertingBuffer.String() != loggerTestCallerFile("relay_test.go")+" [Error] logger relay error\n" {
+		t.Error("relay target logger output error: " + alertingBuffer.String())
+	}
+}
+
+func TestAdapterRelay_WriteLoop(t *testing.T) {
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("relay", LoggerLevelDebug, &RelayConfig{Logger: logger})
+
+	logger.Info("logger relay loop")
+	if logger.outputList()[0].errors != 1 {
+		t.Errorf("relay loop must be stopped with an error, errors %d", logger.outputList()[0].errors)
+	}
+}
@@ -0,0 +1,109 @@
+package go_logger
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// max bytes of a captured record line
+const replayMaxLineBytes = 16 << 20
+
+// replay options
+type ReplayOptions struct {
+
+	// replay speed, 1 is the original pace, 10 is ten times faster
+	// 0 replays as fast as the outputs accept
+	Speed float64
+
+	// rewrite record times to the replay time, for sinks rejecting old timestamps
+	ShiftTime bool
+}
+
+// replay result
+type ReplayStats struct {
+
+	// records written to the logger
+	Replayed int `json:"replayed"`
+
+	// lines that are not JSON records
+	Skipped int `json:"skipped"`
+}
+
+//replay a captured log file, see Replay
+//params : ctx context.Context, filename string, options ReplayOptions
+//return : ReplayStats, error
+func (logger *Logger) ReplayFile(ctx context.Context, filename string, options ReplayOptions) (ReplayStats, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return ReplayStats{}, err
+	}
+	defer file.Close()
+	return logger.Replay(ctx, file, options)
+}
+
+//replay records captured by a JsonFormat file or console output through the outputs of the logger,
+//at the original pace scaled by Speed, e.g. to load test a new sink with realistic data;
+//records pass route rules, sampling and output levels, caller and fields are kept
+//params : ctx context.Context, canceled to stop; reader io.Reader, JSON record per line; options ReplayOptions
+//return : ReplayStats, ctx or read error
+func (logger *Logger) Replay(ctx context.Context, reader io.Reader, options ReplayOptions) (ReplayStats, error) {
+	stats := ReplayStats{}
+	if options.Speed < 0 {
+		return stats, errors.New("logger: replay speed cannot be negative!")
+	}
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 64*1024), replayMaxLineBytes)
+	var firstMillisecond int64
+	var start time.Time
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		loggerMsg := &loggerMessage{}
+		if err := loggerMsg.UnmarshalJSON([]byte(line)); err != nil {
+			stats.Skipped++
+			continue
+		}
+
+		if stats.Replayed == 0 {
+			firstMillisecond = loggerMsg.Millisecond
+			start = time.Now()
+		}
+		if options.Speed > 0 {
+			offset := time.Duration(float64(loggerMsg.Millisecond-firstMillisecond) * float64(time.Millisecond) / options.Speed)
+			if wait := time.Until(start.Add(offset)); wait > 0 {
+				timer := time.NewTimer(wait)
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return stats, ctx.Err()
+				case <-timer.C:
+				}
+			}
+		}
+		if err := ctx.Err(); err != nil {
+			return stats, err
+		}
+
+		if options.ShiftTime {
+			now := time.Now()
+			loggerMsg.Timestamp = now.Unix()
+			loggerMsg.TimestampFormat = now.Format("2006-01-02 15:04:05")
+			loggerMsg.Millisecond = now.UnixNano() / 1e6
+			loggerMsg.MillisecondFormat = now.Format("2006-01-02 15:04:05.999")
+		}
+		if loggerMsg.LevelString == "" {
+			loggerMsg.LevelString = levelStringMapping[loggerMsg.Level]
+		}
+		logger.relay(loggerMsg)
+		stats.Replayed++
+	}
+	return stats, scanner.Err()
+}
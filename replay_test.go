@@ -0,0 +1,59 @@
+package go_logger
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLogger_Replay(t *testing.T) {
+
+	// captured by a JsonFormat file output, 200ms between the records
+	capture := strings.Join([]string{
+		`{"timestamp":1700000000,"millisecond":1700000000000,"level":3,"level_string":"Error","body":"logger replay first","file":"app.go","line":10,"function":"main.main","fields":{"user":"u1"}}`,
+		`not a record`,
+		``,
+		`{"timestamp":1700000000,"millisecond":1700000000200,"level":7,"level_string":"Debug","body":"logger replay second","file":"app.go","line":11,"function":"main.main"}`,
+	}, "\r\n")
+
+	logger := NewLogger()
+	logger.Detach(CONSOLE_ADAPTER_NAME)
+	logger.Attach(CONSOLE_ADAPTER_NAME, LoggerLevelDebug, &ConsoleConfig{Format: "%millisecond% [%level_string%] %file%:%line% %body%"})
+	buffer := &bytes.Buffer{}
+	logger.outputList()[0].LoggerAbstract.(*AdapterConsole).write.writer = buffer
+
+	if _, err := logger.Replay(context.Background(), strings.NewReader(capture), ReplayOptions{Speed: -1}); err == nil {
+		t.Error("replay negative speed must be error")
+	}
+
+	start := time.Now()
+	stats, err := logger.Replay(context.Background(), strings.NewReader(capture), ReplayOptions{Speed: 4})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if stats.Replayed != 2 || stats.Skipped != 1 {
+		t.Errorf("replay stats error: %+v", stats)
+	}
+	// 200ms at 4x speed
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("replay speed error: %v", elapsed)
+	}
+	expected := "1700000000000 [Error] app.go:10 logger replay first user=u1\n1700000000200 [Debug] app.go:11 logger replay second\n"
+	if buffer.String() != expected {
+		t.Errorf("replay output error: %q", buffer.String())
+	}
+
+	// canceled while waiting for the second record
+	buffer.Reset()
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	stats, err = logger.Replay(ctx, strings.NewReader(capture), ReplayOptions{Speed: 1, ShiftTime: true})
+	if err != context.DeadlineExceeded || stats.Replayed != 1 {
+		t.Errorf("replay cancel error: %v %+v", err, stats)
+	}
+	if strings.HasPrefix(buffer.String(), "1700000000000") {
+		t.Errorf("replay shift time error: %q", buffer.String())
+	}
+}
@@ -0,0 +1,141 @@
+package go_logger
+
+import (
+	"errors"
+	"math"
+	"regexp"
+	"strconv"
+)
+
+// message rewriter, called before routing, fields processing and formatting
+// it may change the body and the fields of the message, fields must be replaced, not modified in place
+type Rewriter func(loggerMsg *LoggerMessage)
+
+// body rewrite rule of NewRegexpRewriter
+type RewriteRule struct {
+
+	// match levels, empty matches all levels
+	Levels []int
+
+	// body regular expression, named groups (?P<name>...) are captured as fields
+	// fields of the call site are not overridden
+	Regexp string
+
+	// new body, $1 and ${name} are replaced by the captured groups, empty keeps the body
+	Body string
+
+	// captured integers, floats, "true" and "false" become int64, float64 and bool fields, default strings
+	ConvertValues bool
+
+	// continue to the next rule after a match, default the first matched rule stops the rewrite
+	Continue bool
+}
+
+// compiled rewrite rule
+type rewriteRule struct {
+	RewriteRule
+	levels map[int]bool
+	regexp *regexp.Regexp
+}
+
+//set message rewriters, replace existing rewriters, called in order
+//legacy unstructured messages can be structured without changing every call site, see NewRegexpRewriter
+//params : rewriters ...Rewriter, none to remove
+func (logger *Logger) SetRewriters(rewriters ...Rewriter) {
+	logger.lock.Lock()
+	defer logger.lock.Unlock()
+
+	logger.rewriters.Store(append([]Rewriter{}, rewriters...))
+}
+
+//new rewriter capturing body parts into fields by regular expression rules, e.g.
+//	`^user (?P<user>\S+) logged in from (?P<ip>\S+)$` with Body "user logged in"
+//rules are evaluated in order, see RewriteRule Continue
+//params : rules []RewriteRule
+//return : Rewriter, error
+func NewRegexpRewriter(rules []RewriteRule) (Rewriter, error) {
+	rewriteRules := make([]*rewriteRule, 0, len(rules))
+	for i, rule := range rules {
+		if rule.Regexp == "" {
+			return nil, errors.New("logger: rewrite rule " + strconv.Itoa(i) + " Regexp cannot be empty!")
+		}
+		bodyRegexp, err := regexp.Compile(rule.Regexp)
+		if err != nil {
+			return nil, err
+		}
+		rr := &rewriteRule{
+			RewriteRule: rule,
+			levels:      map[int]bool{},
+			regexp:      bodyRegexp,
+		}
+		for _, level := range rule.Levels {
+			rr.levels[level] = true
+		}
+		rewriteRules = append(rewriteRules, rr)
+	}
+
+	return func(loggerMsg *LoggerMessage) {
+		for _, rule := range rewriteRules {
+			if rule.rewrite(loggerMsg) && !rule.Continue {
+				return
+			}
+		}
+	}, nil
+}
+
+//rewrite the body and capture fields
+//return : true if the body matched
+func (rule *rewriteRule) rewrite(loggerMsg *loggerMessage) bool {
+	if len(rule.levels) > 0 && !rule.levels[loggerMsg.Level] {
+		return false
+	}
+	match := rule.regexp.FindStringSubmatchIndex(loggerMsg.Body)
+	if match == nil {
+		return false
+	}
+	fields := make(map[string]interface{}, len(loggerMsg.Fields)+len(rule.regexp.SubexpNames()))
+	for i, name := range rule.regexp.SubexpNames() {
+		if name == "" || match[2*i] < 0 {
+			continue
+		}
+		value := loggerMsg.Body[match[2*i]:match[2*i+1]]
+		if rule.ConvertValues {
+			fields[name] = rewriteValue(value)
+		} else {
+			fields[name] = value
+		}
+	}
+	for key, value := range loggerMsg.Fields {
+		fields[key] = value
+	}
+	loggerMsg.Fields = fields
+	if rule.Body != "" {
+		loggerMsg.Body = string(rule.regexp.ExpandString(nil, rule.Body, loggerMsg.Body, match))
+	}
+	return true
+}
+
+//captured value as int64, float64 or bool, otherwise the string, "NaN" and "Inf" are strings
+func rewriteValue(value string) interface{} {
+	if i, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return i
+	}
+	if f, err := strconv.ParseFloat(value, 64); err == nil && !math.IsInf(f, 0) && !math.IsNaN(f) {
+		return f
+	}
+	switch value {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	return value
+}
+
+//rewrite message by rewriters
+func (logger *Logger) rewrite(loggerMsg *loggerMessage) {
+	rewriters, _ := logger.rewriters.Load().([]Rewriter)
+	for _, rewriter := range rewriters {
+		rewriter(loggerMsg)
+	}
+}
@@ -0,0 +1,81 @@
+package go_logger
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestNewRegexpRewriter(t *testing.T) {
+
+	if _, err := NewRegexpRewriter([]RewriteRule{{}}); err == nil {
+		t.Error("rewrite rule without Regexp must be error")
+	}
+	if _, err := NewRegexpRewriter([]RewriteRule{{Regexp: "("}}); err == nil {
+		t.Error("rewrite rule of illegal Regexp must be error")
+	}
+
+	rewriter, err := NewRegexpRewriter([]RewriteRule{
+		{Regexp: `^user (?P<user>\S+) logged in from (?P<ip>\S+)$`, Body: "user ${user} logged in"},
+		{Regexp: `took (?P<took_ms>\d+)ms`, ConvertValues: true, Levels: []int{LoggerLevelInfo}, Continue: true},
+		{Regexp: `retry (?P<retry>\d+)`, ConvertValues: true},
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	loggerMsg := &loggerMessage{Level: LoggerLevelInfo, Body: "user alice logged in from 10.0.0.1",
+		Fields: map[string]interface{}{"ip": "call site"}}
+	fields := loggerMsg.Fields
+	rewriter(loggerMsg)
+	if loggerMsg.Body != "user alice logged in" || loggerMsg.Fields["user"] != "alice" || loggerMsg.Fields["ip"] != "call site" {
+		t.Errorf("regexp rewriter error: %+v", loggerMsg)
+	}
+	if len(fields) != 1 {
+		t.Error("regexp rewriter must not modify the fields in place")
+	}
+
+	loggerMsg = &loggerMessage{Level: LoggerLevelInfo, Body: "query took 35ms, retry 2"}
+	rewriter(loggerMsg)
+	if loggerMsg.Fields["took_ms"] != int64(35) || loggerMsg.Fields["retry"] != int64(2) ||
+		loggerMsg.Body != "query took 35ms, retry 2" {
+		t.Errorf("regexp rewriter continue error: %+v", loggerMsg)
+	}
+	loggerMsg = &loggerMessage{Level: LoggerLevelDebug, Body: "query took 35ms"}
+	rewriter(loggerMsg)
+	if loggerMsg.Fields != nil {
+		t.Errorf("regexp rewriter levels error: %+v", loggerMsg)
+	}
+}
+
+func TestLogger_SetRewriters(t *testing.T) {
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("console", LoggerLevelDebug, &ConsoleConfig{
+		Format: "%body% %fields%",
+	})
+	buffer := &bytes.Buffer{}
+	logger.outputList()[0].LoggerAbstract.(*AdapterConsole).write.writer = buffer
+
+	rewriter, err := NewRegexpRewriter([]RewriteRule{
+		{Regexp: `^order (?P<order>\d+) paid$`, Body: "order paid", ConvertValues: true},
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	logger.SetRewriters(rewriter, func(loggerMsg *LoggerMessage) {
+		loggerMsg.Body = strings.ToUpper(loggerMsg.Body)
+	})
+	logger.Infof("order %d paid", 42)
+	logger.WithField("currency", "eur").Info("order 43 paid")
+	logger.SetRewriters()
+	logger.Info("order 44 paid")
+
+	expected := "ORDER PAID order=42\n" +
+		"ORDER PAID currency=eur order=43\n" +
+		"order 44 paid \n"
+	if buffer.String() != expected {
+		t.Error("logger rewriters error: " + buffer.String())
+	}
+}
@@ -0,0 +1,34 @@
+package go_logger
+
+import (
+	"errors"
+)
+
+// optional interface of adapters writing files, rotated by RotateAll
+type Rotator interface {
+	Rotate() error
+}
+
+//flush, then rotate the outputs implementing Rotator, e.g. the file adapter,
+//so records logged before are in the rotated files
+//return : error of the first output failed to rotate
+func (logger *Logger) RotateAll() error {
+	// a failed flush is reported by Flush, rotation goes on
+	logger.Flush()
+
+	var err error
+	for _, output := range logger.outputList() {
+		adapter, ok := logger.Output(output.Name)
+		if !ok {
+			continue
+		}
+		rotator, ok := adapter.(Rotator)
+		if !ok {
+			continue
+		}
+		if rotateErr := rotator.Rotate(); rotateErr != nil && err == nil {
+			err = errors.New("logger: adapter " + output.Name + " rotate failed, error: " + rotateErr.Error())
+		}
+	}
+	return err
+}
@@ -0,0 +1,53 @@
+package go_logger
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLogger_RotateAll(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "go_logger")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer os.RemoveAll(dir)
+
+	logger := NewLogger()
+	logger.SetAsync(10)
+	err = logger.Attach("file", LoggerLevelDebug, &FileConfig{
+		Filename: filepath.Join(dir, "app.log"),
+		Format:   "%body%",
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	logger.Detach("console")
+	adapter, _ := logger.Output("file")
+	defer adapter.(*AdapterFile).Close()
+
+	logger.Info("logger before rotate all")
+	if err := logger.RotateAll(); err != nil {
+		t.Fatal(err.Error())
+	}
+	logger.Info("logger after rotate all")
+	logger.Flush()
+
+	files, _ := ioutil.ReadDir(dir)
+	if len(files) != 2 {
+		t.Fatalf("logger rotate all must keep 2 files, got %d", len(files))
+	}
+	for _, file := range files {
+		data, _ := ioutil.ReadFile(filepath.Join(dir, file.Name()))
+		expected := "logger before rotate all\r\n"
+		if file.Name() == "app.log" {
+			expected = "logger after rotate all\r\n"
+		}
+		if !strings.HasSuffix(string(data), expected) || strings.Count(string(data), "\n") != 1 {
+			t.Errorf("logger rotate all error: %s %q", file.Name(), string(data))
+		}
+	}
+}
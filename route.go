@@ -0,0 +1,143 @@
+package go_logger
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+const (
+	ROUTE_ACTION_ROUTE   = "route"
+	ROUTE_ACTION_DROP    = "drop"
+	ROUTE_ACTION_REWRITE = "rewrite"
+)
+
+// content-based route rule
+// rules are evaluated in order, "rewrite" continues to the next rule,
+// the first matched "route" or "drop" rule stops the evaluation.
+type RouteRule struct {
+
+	// match levels, empty matches all levels
+	Levels []int
+
+	// match "category" field value, empty matches all
+	Category string
+
+	// match field values, compared as string
+	Fields map[string]string
+
+	// match body by regular expression, empty matches all
+	BodyRegexp string
+
+	// action when matched
+	// "route" write to Adapters only
+	// "drop" drop the message
+	// "rewrite" set SetFields and continue
+	Action string
+
+	// route adapter names
+	Adapters []string
+
+	// rewrite fields
+	SetFields map[string]interface{}
+}
+
+// compiled route rule
+type routeRule struct {
+	RouteRule
+	levels     map[int]bool
+	adapters   map[string]bool
+	bodyRegexp *regexp.Regexp
+}
+
+//set content-based route rules, replace existing rules
+//params : rules []RouteRule, nil to remove
+//return : error
+func (logger *Logger) SetRouteRules(rules []RouteRule) error {
+	routeRules := make([]*routeRule, 0, len(rules))
+	for i, rule := range rules {
+		rr := &routeRule{
+			RouteRule: rule,
+			levels:    map[int]bool{},
+			adapters:  map[string]bool{},
+		}
+		switch rule.Action {
+		case ROUTE_ACTION_ROUTE:
+			if len(rule.Adapters) == 0 {
+				return errors.New("logger: route rule " + strconv.Itoa(i) + " Adapters cannot be empty!")
+			}
+		case ROUTE_ACTION_DROP, ROUTE_ACTION_REWRITE:
+		default:
+			return errors.New("logger: route rule " + strconv.Itoa(i) + " Action must be one of the 'route', 'drop', 'rewrite'!")
+		}
+		for _, level := range rule.Levels {
+			rr.levels[level] = true
+		}
+		for _, adapterName := range rule.Adapters {
+			rr.adapters[adapterName] = true
+		}
+		if rule.BodyRegexp != "" {
+			bodyRegexp, err := regexp.Compile(rule.BodyRegexp)
+			if err != nil {
+				return err
+			}
+			rr.bodyRegexp = bodyRegexp
+		}
+		routeRules = append(routeRules, rr)
+	}
+
+	logger.lock.Lock()
+	defer logger.lock.Unlock()
+
+	logger.routeRules.Store(routeRules)
+	return nil
+}
+
+//route message by rules
+//return : false if message is dropped
+func (logger *Logger) route(loggerMsg *loggerMessage) bool {
+	routeRules, _ := logger.routeRules.Load().([]*routeRule)
+	for _, rule := range routeRules {
+		if !rule.match(loggerMsg) {
+			continue
+		}
+		switch rule.Action {
+		case ROUTE_ACTION_DROP:
+			return false
+		case ROUTE_ACTION_ROUTE:
+			loggerMsg.routeAdapters = rule.adapters
+			return true
+		case ROUTE_ACTION_REWRITE:
+			fields := make(map[string]interface{}, len(loggerMsg.Fields)+len(rule.SetFields))
+			for key, value := range loggerMsg.Fields {
+				fields[key] = value
+			}
+			for key, value := range rule.SetFields {
+				fields[key] = value
+			}
+			loggerMsg.Fields = fields
+		}
+	}
+	return true
+}
+
+//message matched by rule
+func (rule *routeRule) match(loggerMsg *loggerMessage) bool {
+	if len(rule.levels) > 0 && !rule.levels[loggerMsg.Level] {
+		return false
+	}
+	if rule.Category != "" && fmt.Sprint(loggerMsg.Fields["category"]) != rule.Category {
+		return false
+	}
+	for key, value := range rule.Fields {
+		fieldValue, ok := loggerMsg.Fields[key]
+		if !ok || fmt.Sprint(fieldValue) != value {
+			return false
+		}
+	}
+	if rule.bodyRegexp != nil && !rule.bodyRegexp.MatchString(loggerMsg.Body) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,38 @@
+package go_logger
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestLogger_SetRouteRules(t *testing.T) {
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("console", LoggerLevelDebug, &ConsoleConfig{
+		Format: "%body% %fields%",
+	})
+	buffer := &bytes.Buffer{}
+	logger.outputList()[0].LoggerAbstract.(*AdapterConsole).write.writer = buffer
+
+	err := logger.SetRouteRules([]RouteRule{
+		{BodyRegexp: "^health", Action: ROUTE_ACTION_DROP},
+		{Category: "payment", Action: ROUTE_ACTION_REWRITE, SetFields: map[string]interface{}{"team": "pay"}},
+		{Levels: []int{LoggerLevelDebug}, Action: ROUTE_ACTION_ROUTE, Adapters: []string{"file"}},
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	logger.Info("healthcheck ok")
+	logger.Debug("logger route debug test")
+	logger.Infow("charge", "category", "payment")
+	if buffer.String() != "charge category=payment team=pay\n" {
+		t.Error("logger route rules error: " + buffer.String())
+	}
+
+	err = logger.SetRouteRules([]RouteRule{{Action: "forward"}})
+	if err == nil {
+		t.Error("route rule illegal action must be error")
+	}
+}
@@ -0,0 +1,97 @@
+package go_logger
+
+import (
+	"errors"
+	"runtime"
+	"strconv"
+	"time"
+)
+
+// background task logging runtime metrics
+type runtimeMetrics struct {
+	stopChan chan struct{}
+	numGC    uint32 // NumGC of the last record
+}
+
+//log runtime metrics every interval as a "logger: runtime metrics" record with fields
+//goroutines, heap_alloc_bytes, heap_inuse_bytes, heap_objects, sys_bytes, next_gc_bytes,
+//gc_count, gc_cpu_fraction, and gc_pauses, gc_pause_total_ms, gc_pause_max_ms of the GCs since the last record
+//params : interval time.Duration, 0 stops; level int
+//return : error
+func (logger *Logger) SetRuntimeMetrics(interval time.Duration, level int) error {
+	if interval < 0 {
+		return errors.New("logger: runtime metrics interval cannot be negative!")
+	}
+	if _, ok := levelStringMapping[level]; !ok {
+		return errors.New("logger: level " + strconv.Itoa(level) + " is illegal!")
+	}
+	logger.lock.Lock()
+	defer logger.lock.Unlock()
+
+	logger.stopRuntimeMetrics()
+	if interval == 0 {
+		return nil
+	}
+	metrics := &runtimeMetrics{stopChan: make(chan struct{})}
+	memStats := &runtime.MemStats{}
+	runtime.ReadMemStats(memStats)
+	metrics.numGC = memStats.NumGC
+	logger.runtimeMetrics = metrics
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-metrics.stopChan:
+				return
+			case <-ticker.C:
+			}
+			logger.writer(1, level, "logger: runtime metrics", metrics.fields(memStats))
+		}
+	}()
+	return nil
+}
+
+//stop logging runtime metrics, called with lock held
+func (logger *Logger) stopRuntimeMetrics() {
+	if logger.runtimeMetrics == nil {
+		return
+	}
+	close(logger.runtimeMetrics.stopChan)
+	logger.runtimeMetrics = nil
+}
+
+//fields of the current runtime metrics, memStats is reused
+func (metrics *runtimeMetrics) fields(memStats *runtime.MemStats) map[string]interface{} {
+	runtime.ReadMemStats(memStats)
+
+	// PauseNs is a ring of the last 256 GC pauses
+	gcCount := memStats.NumGC - metrics.numGC
+	if gcCount > uint32(len(memStats.PauseNs)) {
+		gcCount = uint32(len(memStats.PauseNs))
+	}
+	var pauseTotal, pauseMax uint64
+	for i := uint32(0); i < gcCount; i++ {
+		pause := memStats.PauseNs[(memStats.NumGC-i+255)%256]
+		pauseTotal += pause
+		if pause > pauseMax {
+			pauseMax = pause
+		}
+	}
+	metrics.numGC = memStats.NumGC
+
+	return map[string]interface{}{
+		"goroutines":        runtime.NumGoroutine(),
+		"heap_alloc_bytes":  memStats.HeapAlloc,
+		"heap_inuse_bytes":  memStats.HeapInuse,
+		"heap_objects":      memStats.HeapObjects,
+		"sys_bytes":         memStats.Sys,
+		"next_gc_bytes":     memStats.NextGC,
+		"gc_count":          memStats.NumGC,
+		"gc_cpu_fraction":   memStats.GCCPUFraction,
+		"gc_pauses":         gcCount,
+		"gc_pause_total_ms": float64(pauseTotal) / 1e6,
+		"gc_pause_max_ms":   float64(pauseMax) / 1e6,
+	}
+}
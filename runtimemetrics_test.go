@@ -0,0 +1,72 @@
+package go_logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// writer safe for the runtime metrics goroutine
+type lockedBuffer struct {
+	lock   sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.buffer.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.buffer.String()
+}
+
+func TestLogger_SetRuntimeMetrics(t *testing.T) {
+
+	logger := NewLogger()
+	if logger.SetRuntimeMetrics(-time.Second, LoggerLevelInfo) == nil || logger.SetRuntimeMetrics(time.Second, 100) == nil {
+		t.Error("runtime metrics illegal interval or level must return error")
+	}
+	logger.Detach("console")
+	logger.Attach("console", LoggerLevelDebug, &ConsoleConfig{JsonFormat: true})
+	buffer := &lockedBuffer{}
+	logger.outputList()[0].LoggerAbstract.(*AdapterConsole).write.writer = buffer
+
+	if err := logger.SetRuntimeMetrics(10*time.Millisecond, LoggerLevelNotice); err != nil {
+		t.Fatal(err.Error())
+	}
+	runtime.GC()
+	deadline := time.Now().Add(5 * time.Second)
+	for !strings.Contains(buffer.String(), "\n") {
+		if time.Now().After(deadline) {
+			t.Fatal("runtime metrics must be logged every interval")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	logger.SetRuntimeMetrics(0, LoggerLevelNotice)
+
+	record := map[string]interface{}{}
+	line := strings.SplitN(buffer.String(), "\n", 2)[0]
+	if err := json.Unmarshal([]byte(line), &record); err != nil {
+		t.Fatal(err.Error())
+	}
+	fields, _ := record["fields"].(map[string]interface{})
+	if record["body"] != "logger: runtime metrics" || record["level_string"] != "Notice" ||
+		fields["goroutines"].(float64) < 1 || fields["heap_alloc_bytes"].(float64) <= 0 || fields["gc_pauses"].(float64) < 1 {
+		t.Error("runtime metrics record error: " + line)
+	}
+
+	// stopped, no more records
+	count := strings.Count(buffer.String(), "\n")
+	time.Sleep(30 * time.Millisecond)
+	if strings.Count(buffer.String(), "\n") != count {
+		t.Error("runtime metrics must stop on interval 0")
+	}
+}
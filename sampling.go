@@ -0,0 +1,208 @@
+package go_logger
+
+import (
+	"errors"
+	"hash/fnv"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// field name of suppressed repeats count
+const repeatedField = "repeated"
+
+// error sampler, identical Error+ messages are written once per interval
+type errorSampler struct {
+	lock      sync.Mutex
+	interval  time.Duration
+	expiry    time.Duration
+	samples   map[uint64]*errorSample
+	lastSweep time.Time
+	exemplars int         // example records kept per sample
+	timer     *time.Timer // writes pending counts of ended intervals, nil if not armed
+}
+
+// sample of identical messages
+type errorSample struct {
+	lastWrite  time.Time
+	lastSeen   time.Time
+	suppressed int
+	lastMsg    *loggerMessage
+	exemplars  *exemplarSet // suppressed example records, nil if not kept
+}
+
+//set error sampling, identical Error+ messages (same level and body) are written
+//on first occurrence, repeats in interval are suppressed and counted in the "repeated"
+//field of the next written one, or of a copy of the last repeat written when the interval
+//ends without one; samples not seen for expiry are removed
+//pending counts are written by Flush and Close, and when the sampling is changed
+//params : interval time.Duration, 0 to disable; expiry time.Duration, default 10 * interval
+//return : error
+func (logger *Logger) SetErrorSampling(interval time.Duration, expiry time.Duration) error {
+	if interval < 0 || expiry < 0 {
+		return errors.New("logger: error sampling interval and expiry cannot be negative!")
+	}
+	if expiry == 0 {
+		expiry = 10 * interval
+	}
+	if expiry < interval {
+		return errors.New("logger: error sampling expiry cannot be less than interval!")
+	}
+	var sampler *errorSampler
+	if interval > 0 {
+		sampler = &errorSampler{
+			interval: interval,
+			expiry:   expiry,
+			samples:  map[uint64]*errorSample{},
+		}
+	}
+	logger.lock.Lock()
+	previous, _ := logger.errorSampler.Load().(*errorSampler)
+	logger.errorSampler.Store(sampler)
+	logger.lock.Unlock()
+
+	if previous != nil {
+		logger.writeSamples(previous, true)
+	}
+	return nil
+}
+
+//sample message
+//return : false if the message is suppressed
+func (logger *Logger) sample(loggerMsg *loggerMessage) bool {
+	sampler, _ := logger.errorSampler.Load().(*errorSampler)
+	if sampler == nil || loggerMsg.Level > LoggerLevelError {
+		return true
+	}
+	isWrite, expired := sampler.check(loggerMsg, time.Now())
+	if !isWrite {
+		sampler.schedule(sampler.interval, func() {
+			logger.writeSamples(sampler, false)
+		})
+	}
+	for _, expiredMsg := range expired {
+		logger.dispatch(expiredMsg)
+	}
+	return isWrite
+}
+
+//write pending counts of a sampler
+//params : sampler *errorSampler; all bool, false writes only the counts of ended intervals
+func (logger *Logger) writeSamples(sampler *errorSampler, all bool) {
+	pending, next := sampler.pending(time.Now(), all)
+	if next > 0 {
+		sampler.schedule(next, func() {
+			logger.writeSamples(sampler, false)
+		})
+	}
+	for _, loggerMsg := range pending {
+		logger.dispatch(loggerMsg)
+	}
+}
+
+//arm the timer writing pending counts, nothing if armed
+func (sampler *errorSampler) schedule(delay time.Duration, write func()) {
+	sampler.lock.Lock()
+	defer sampler.lock.Unlock()
+
+	if sampler.timer == nil {
+		sampler.timer = time.AfterFunc(delay, write)
+	}
+}
+
+//take pending counts, counts of samples in interval are kept unless all
+//return : messages with pending counts, delay until the next interval end, 0 if nothing is pending
+func (sampler *errorSampler) pending(now time.Time, all bool) ([]*loggerMessage, time.Duration) {
+	sampler.lock.Lock()
+	defer sampler.lock.Unlock()
+
+	if sampler.timer != nil {
+		sampler.timer.Stop()
+		sampler.timer = nil
+	}
+	var pending []*loggerMessage
+	var next time.Duration
+	for _, s := range sampler.samples {
+		if s.suppressed == 0 {
+			continue
+		}
+		if wait := sampler.interval - now.Sub(s.lastWrite); !all && wait > 0 {
+			if next == 0 || wait < next {
+				next = wait
+			}
+			continue
+		}
+		pending = append(pending, withRepeated(s.lastMsg, s.suppressed, s.exemplars))
+		s.lastWrite = now
+		s.suppressed = 0
+		s.lastMsg = nil
+		s.exemplars = nil
+	}
+	return pending, next
+}
+
+//check message, return whether to write it and expired messages with pending counts
+func (sampler *errorSampler) check(loggerMsg *loggerMessage, now time.Time) (bool, []*loggerMessage) {
+	sampler.lock.Lock()
+	defer sampler.lock.Unlock()
+
+	var expired []*loggerMessage
+	if now.Sub(sampler.lastSweep) >= sampler.interval {
+		sampler.lastSweep = now
+		for key, s := range sampler.samples {
+			if now.Sub(s.lastSeen) < sampler.expiry {
+				continue
+			}
+			if s.suppressed > 0 {
+				expired = append(expired, withRepeated(s.lastMsg, s.suppressed, s.exemplars))
+			}
+			delete(sampler.samples, key)
+		}
+	}
+
+	h := fnv.New64a()
+	h.Write([]byte(strconv.Itoa(loggerMsg.Level)))
+	h.Write([]byte(loggerMsg.Body))
+	key := h.Sum64()
+
+	s, ok := sampler.samples[key]
+	if !ok {
+		sampler.samples[key] = &errorSample{lastWrite: now, lastSeen: now}
+		return true, expired
+	}
+	s.lastSeen = now
+	if now.Sub(s.lastWrite) < sampler.interval {
+		s.suppressed++
+		s.lastMsg = loggerMsg
+		if sampler.exemplars > 0 {
+			if s.exemplars == nil {
+				s.exemplars = newExemplarSet(sampler.exemplars)
+			}
+			s.exemplars.add(loggerMsg)
+		}
+		return false, expired
+	}
+	if s.suppressed > 0 {
+		*loggerMsg = *withRepeated(loggerMsg, s.suppressed, s.exemplars)
+	}
+	s.lastWrite = now
+	s.suppressed = 0
+	s.lastMsg = nil
+	s.exemplars = nil
+	return true, expired
+}
+
+//copy message with repeated count field and suppressed example records
+func withRepeated(loggerMsg *loggerMessage, repeated int, exemplars *exemplarSet) *loggerMessage {
+	repeatedMsg := *loggerMsg
+	fields := make(map[string]interface{}, len(loggerMsg.Fields)+2)
+	for key, value := range loggerMsg.Fields {
+		fields[key] = value
+	}
+	fields[repeatedField] = repeated
+	if records := exemplars.records(); records != nil {
+		fields[exemplarsField] = records
+	}
+	repeatedMsg.Fields = fields
+	return &repeatedMsg
+}
@@ -0,0 +1,81 @@
+package go_logger
+
+import (
+	"testing"
+	"time"
+)
+
+func TestErrorSampler_check(t *testing.T) {
+
+	sampler := &errorSampler{
+		interval: time.Minute,
+		expiry:   time.Hour,
+		samples:  map[uint64]*errorSample{},
+	}
+	now := time.Now()
+	newMsg := func() *loggerMessage {
+		return &loggerMessage{Level: LoggerLevelError, Body: "connection refused"}
+	}
+
+	isWrite, _ := sampler.check(newMsg(), now)
+	if !isWrite {
+		t.Error("error sampler first occurrence must be written")
+	}
+	for i := 0; i < 3; i++ {
+		isWrite, _ = sampler.check(newMsg(), now.Add(time.Second))
+		if isWrite {
+			t.Error("error sampler repeat in interval must be suppressed")
+		}
+	}
+	loggerMsg := newMsg()
+	isWrite, _ = sampler.check(loggerMsg, now.Add(2*time.Minute))
+	if !isWrite || loggerMsg.Fields[repeatedField] != 3 {
+		t.Error("error sampler repeat after interval must be written with repeated count")
+	}
+
+	sampler.check(newMsg(), now.Add(150*time.Second))
+	_, expired := sampler.check(&loggerMessage{Level: LoggerLevelError, Body: "other"}, now.Add(3*time.Hour))
+	if len(expired) != 1 || expired[0].Fields[repeatedField] != 1 {
+		t.Error("error sampler expired sample must be written with pending count")
+	}
+}
+
+func TestLogger_SetErrorSamplingPending(t *testing.T) {
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("console", LoggerLevelDebug, &ConsoleConfig{
+		Format: "%body% %fields%",
+	})
+	buffer := &syncBuffer{}
+	logger.outputList()[0].LoggerAbstract.(*AdapterConsole).write.writer = buffer
+	if logger.SetErrorSampling(time.Minute, time.Second) == nil {
+		t.Error("error sampling expiry less than interval must be error")
+	}
+	logger.SetErrorSampling(50*time.Millisecond, 0)
+
+	for i := 0; i < 3; i++ {
+		logger.Error("connection refused")
+	}
+	time.Sleep(100 * time.Millisecond)
+	if buffer.String() != "connection refused \nconnection refused repeated=2\n" {
+		t.Errorf("pending count must be written when the interval ends: %q", buffer.String())
+	}
+
+	logger.SetErrorSampling(time.Minute, 0)
+	buffer.Reset()
+	for i := 0; i < 3; i++ {
+		logger.Error("connection refused")
+	}
+	logger.Flush()
+	if buffer.String() != "connection refused \nconnection refused repeated=2\n" {
+		t.Errorf("pending count must be written by Flush: %q", buffer.String())
+	}
+
+	buffer.Reset()
+	logger.Error("connection refused")
+	logger.SetErrorSampling(0, 0)
+	if buffer.String() != "connection refused repeated=1\n" {
+		t.Errorf("pending count must be written when sampling is changed: %q", buffer.String())
+	}
+}
@@ -0,0 +1,124 @@
+package go_logger
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// message body and string field values sanitizer
+type Sanitizer struct {
+
+	// replacement of invalid UTF-8 bytes, default "�"
+	InvalidReplacement string
+
+	// replace control characters (including "\n" and "\r", except "\t")
+	ReplaceControl bool
+
+	// replacement of control characters, default " "
+	ControlReplacement string
+
+	// max bytes of body, truncated on rune boundary, 0 is unlimited
+	MaxBodyBytes int
+
+	// max bytes of string field values, truncated on rune boundary, 0 is unlimited
+	MaxFieldBytes int
+
+	// suffix appended to truncated string, default "..."
+	TruncateSuffix string
+}
+
+//set message sanitizer
+//params : sanitizer *Sanitizer, nil to remove
+func (logger *Logger) SetSanitizer(sanitizer *Sanitizer) {
+	logger.lock.Lock()
+	defer logger.lock.Unlock()
+
+	if sanitizer != nil {
+		s := *sanitizer
+		if s.InvalidReplacement == "" {
+			s.InvalidReplacement = "�"
+		}
+		if s.ControlReplacement == "" {
+			s.ControlReplacement = " "
+		}
+		if s.TruncateSuffix == "" {
+			s.TruncateSuffix = "..."
+		}
+		sanitizer = &s
+	}
+	logger.sanitizer.Store(sanitizer)
+}
+
+//sanitize message body and string field values
+func (logger *Logger) sanitize(loggerMsg *loggerMessage) {
+	sanitizer, _ := logger.sanitizer.Load().(*Sanitizer)
+	if sanitizer == nil {
+		return
+	}
+	loggerMsg.Body = sanitizer.sanitizeString(loggerMsg.Body, sanitizer.MaxBodyBytes)
+	if len(loggerMsg.Fields) == 0 {
+		return
+	}
+	fields := make(map[string]interface{}, len(loggerMsg.Fields))
+	for key, value := range loggerMsg.Fields {
+		if s, ok := value.(string); ok {
+			value = sanitizer.sanitizeString(s, sanitizer.MaxFieldBytes)
+		}
+		fields[sanitizer.sanitizeString(key, 0)] = value
+	}
+	loggerMsg.Fields = fields
+}
+
+//replace invalid UTF-8 and control characters, truncate to maxBytes on rune boundary
+func (sanitizer *Sanitizer) sanitizeString(s string, maxBytes int) string {
+	if sanitizer.isClean(s) {
+		return truncateString(s, maxBytes, sanitizer.TruncateSuffix)
+	}
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		switch {
+		case r == utf8.RuneError && size == 1:
+			b.WriteString(sanitizer.InvalidReplacement)
+		case sanitizer.ReplaceControl && r != '\t' && unicode.IsControl(r):
+			b.WriteString(sanitizer.ControlReplacement)
+		default:
+			b.WriteString(s[i : i+size])
+		}
+		i += size
+	}
+	return truncateString(b.String(), maxBytes, sanitizer.TruncateSuffix)
+}
+
+//string needs no replacement
+func (sanitizer *Sanitizer) isClean(s string) bool {
+	if !utf8.ValidString(s) {
+		return false
+	}
+	if sanitizer.ReplaceControl {
+		for _, r := range s {
+			if r != '\t' && unicode.IsControl(r) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+//truncate string to maxBytes (suffix included) on rune boundary, 0 is unlimited
+func truncateString(s string, maxBytes int, suffix string) string {
+	if maxBytes <= 0 || len(s) <= maxBytes {
+		return s
+	}
+	cut := maxBytes - len(suffix)
+	if cut < 0 {
+		cut = 0
+		suffix = suffix[:maxBytes]
+	}
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + suffix
+}
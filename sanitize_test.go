@@ -0,0 +1,40 @@
+package go_logger
+
+import (
+	"testing"
+)
+
+func TestTruncateString(t *testing.T) {
+
+	if truncateString("hello world", 8, "...") != "hello..." {
+		t.Error("truncate string error")
+	}
+	// "日" is 3 bytes, must not be cut in the middle
+	if truncateString("日本語", 7, "..") != "日.." {
+		t.Error("truncate string rune boundary error: " + truncateString("日本語", 7, ".."))
+	}
+	if truncateString("hello", 0, "...") != "hello" {
+		t.Error("truncate string unlimited error")
+	}
+}
+
+func TestLogger_SetSanitizer(t *testing.T) {
+
+	logger := NewLogger()
+	logger.SetSanitizer(&Sanitizer{
+		ReplaceControl: true,
+		MaxBodyBytes:   12,
+	})
+
+	loggerMsg := &loggerMessage{
+		Body:   "bad\xff\nline and more",
+		Fields: map[string]interface{}{"path": "a\x00b"},
+	}
+	logger.sanitize(loggerMsg)
+	if loggerMsg.Body != "bad� li..." {
+		t.Error("sanitizer body error: " + loggerMsg.Body)
+	}
+	if loggerMsg.Fields["path"] != "a b" {
+		t.Error("sanitizer fields error")
+	}
+}
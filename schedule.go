@@ -0,0 +1,156 @@
+package go_logger
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cron-like schedule, an output is active when the time matches
+type cronSchedule struct {
+	minute   uint64
+	hour     uint64
+	dom      uint64
+	month    uint64
+	dow      uint64
+	domStar  bool
+	dowStar  bool
+	location *time.Location
+	spec     string
+}
+
+// field bounds: minute, hour, day of month, month, day of week (0 or 7 is Sunday)
+var cronScheduleBounds = [5][2]int{
+	{0, 59},
+	{0, 23},
+	{1, 31},
+	{1, 12},
+	{0, 7},
+}
+
+//set the schedule of an attached output, the output only writes when the time matches
+//schedule is "minute hour day-of-month month day-of-week", optional "CRON_TZ=Asia/Shanghai " prefix
+//fields are "*", "1", "1-5", "*/2", "1-10/2", "5/10" (from 5 to the max every 10) or lists "1,3,5",
+//day of week 0 or 7 is Sunday
+//	"* 9-17 * * 1-5" business hours on weekdays
+//	"* 0-1,4-23 * * *" inactive 02:00-03:59 every day
+//params : adapterName string, schedule string, empty schedule to remove
+//return : error
+func (logger *Logger) SetSchedule(adapterName string, schedule string) error {
+	logger.lock.Lock()
+	defer logger.lock.Unlock()
+
+	var cs *cronSchedule
+	if schedule != "" {
+		var err error
+		cs, err = parseCronSchedule(schedule)
+		if err != nil {
+			return err
+		}
+	}
+	return logger.updateOutput(adapterName, func(output *outputLogger) error {
+		output.schedule = cs
+		return nil
+	})
+}
+
+//parse cron-like schedule
+func parseCronSchedule(schedule string) (*cronSchedule, error) {
+	cs := &cronSchedule{location: time.Local, spec: strings.TrimSpace(schedule)}
+
+	schedule = strings.TrimSpace(schedule)
+	if strings.HasPrefix(schedule, "CRON_TZ=") || strings.HasPrefix(schedule, "TZ=") {
+		i := strings.IndexAny(schedule, " \t")
+		if i == -1 {
+			return nil, errors.New("logger: schedule " + schedule + " has no fields!")
+		}
+		zone := schedule[strings.Index(schedule, "=")+1 : i]
+		location, err := time.LoadLocation(zone)
+		if err != nil {
+			return nil, err
+		}
+		cs.location = location
+		schedule = strings.TrimSpace(schedule[i:])
+	}
+
+	fields := strings.Fields(schedule)
+	if len(fields) != 5 {
+		return nil, errors.New("logger: schedule " + schedule + " must have 5 fields!")
+	}
+	bits := [5]*uint64{&cs.minute, &cs.hour, &cs.dom, &cs.month, &cs.dow}
+	for i, field := range fields {
+		b, err := parseCronField(field, cronScheduleBounds[i][0], cronScheduleBounds[i][1])
+		if err != nil {
+			return nil, err
+		}
+		*bits[i] = b
+	}
+	// day of week 7 is Sunday
+	if cs.dow&(1<<7) != 0 {
+		cs.dow = cs.dow&^(1<<7) | 1
+	}
+	cs.domStar = fields[2] == "*"
+	cs.dowStar = fields[4] == "*"
+	return cs, nil
+}
+
+//parse cron field, support "*", "1", "1-5", "*/2", "1-10/2", "5/10" (5-max/10), "1,3,5"
+func parseCronField(field string, min int, max int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		step := 1
+		hasStep := false
+		if i := strings.Index(part, "/"); i != -1 {
+			s, err := strconv.Atoi(part[i+1:])
+			if err != nil || s <= 0 {
+				return 0, errors.New("logger: schedule field " + field + " step is illegal!")
+			}
+			step = s
+			hasStep = true
+			part = part[:i]
+		}
+		start, end := min, max
+		if part != "*" {
+			bounds := strings.SplitN(part, "-", 2)
+			var err error
+			start, err = strconv.Atoi(bounds[0])
+			if err != nil {
+				return 0, errors.New("logger: schedule field " + field + " is illegal!")
+			}
+			end = start
+			if len(bounds) == 2 {
+				end, err = strconv.Atoi(bounds[1])
+				if err != nil {
+					return 0, errors.New("logger: schedule field " + field + " is illegal!")
+				}
+			} else if hasStep {
+				end = max
+			}
+		}
+		if start < min || end > max || start > end {
+			return 0, errors.New("logger: schedule field " + field + " is out of range!")
+		}
+		for v := start; v <= end; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
+
+//time is matched by schedule
+func (cs *cronSchedule) match(t time.Time) bool {
+	t = t.In(cs.location)
+	if cs.minute&(1<<uint(t.Minute())) == 0 ||
+		cs.hour&(1<<uint(t.Hour())) == 0 ||
+		cs.month&(1<<uint(t.Month())) == 0 {
+		return false
+	}
+	domMatch := cs.dom&(1<<uint(t.Day())) != 0
+	dowMatch := cs.dow&(1<<uint(t.Weekday())) != 0
+	// like cron, if both day fields are restricted, either matches
+	if !cs.domStar && !cs.dowStar {
+		return domMatch || dowMatch
+	}
+	return domMatch && dowMatch
+}
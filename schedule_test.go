@@ -0,0 +1,115 @@
+package go_logger
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCronSchedule(t *testing.T) {
+
+	cs, err := parseCronSchedule("CRON_TZ=UTC * 9-17 * * 1-5")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	// 2024-05-01 is Wednesday
+	if !cs.match(time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC)) {
+		t.Error("schedule must match business hours")
+	}
+	if cs.match(time.Date(2024, 5, 1, 18, 0, 0, 0, time.UTC)) {
+		t.Error("schedule must not match after business hours")
+	}
+	if cs.match(time.Date(2024, 5, 4, 10, 30, 0, 0, time.UTC)) {
+		t.Error("schedule must not match saturday")
+	}
+
+	cs, _ = parseCronSchedule("*/15 * 1 * 0")
+	if !cs.match(time.Date(2024, 5, 1, 10, 45, 0, 0, time.Local)) {
+		t.Error("schedule must match day of month")
+	}
+	if cs.match(time.Date(2024, 5, 2, 10, 45, 0, 0, time.Local)) {
+		t.Error("schedule must not match day of month or week")
+	}
+
+	_, err = parseCronSchedule("* 25 * * *")
+	if err == nil {
+		t.Error("schedule out of range must be error")
+	}
+}
+
+func TestParseCronField(t *testing.T) {
+
+	fields := []struct {
+		field    string
+		min, max int
+		values   []int
+	}{
+		{"*", 0, 6, []int{0, 1, 2, 3, 4, 5, 6}},
+		{"5", 0, 59, []int{5}},
+		{"1-5", 0, 59, []int{1, 2, 3, 4, 5}},
+		{"*/20", 0, 59, []int{0, 20, 40}},
+		{"1-10/4", 0, 59, []int{1, 5, 9}},
+		{"5/10", 0, 59, []int{5, 15, 25, 35, 45, 55}},
+		{"20/2", 0, 23, []int{20, 22}},
+		{"1,3,5", 0, 59, []int{1, 3, 5}},
+		{"7", 0, 7, []int{7}},
+	}
+	for _, f := range fields {
+		bits, err := parseCronField(f.field, f.min, f.max)
+		if err != nil {
+			t.Errorf("schedule field %s error: %v", f.field, err)
+			continue
+		}
+		var expected uint64
+		for _, v := range f.values {
+			expected |= 1 << uint(v)
+		}
+		if bits != expected {
+			t.Errorf("schedule field %s bits %b, expected %b", f.field, bits, expected)
+		}
+	}
+
+	for _, field := range []string{"5/0", "60", "10-5", "a/2", "5-a"} {
+		if _, err := parseCronField(field, 0, 59); err == nil {
+			t.Errorf("schedule field %s must be error", field)
+		}
+	}
+}
+
+func TestParseCronScheduleSunday(t *testing.T) {
+
+	// 2024-05-05 is Sunday
+	sunday := time.Date(2024, 5, 5, 10, 0, 0, 0, time.UTC)
+	schedules := map[string]bool{
+		"CRON_TZ=UTC * * * * 7":   true,
+		"CRON_TZ=UTC * * * * 0":   true,
+		"CRON_TZ=UTC * * * * 5-7": true,
+		"CRON_TZ=UTC * * * * 1-6": false,
+		"CRON_TZ=UTC * * * * 6/2": false,
+	}
+	for schedule, match := range schedules {
+		cs, err := parseCronSchedule(schedule)
+		if err != nil {
+			t.Errorf("schedule %s error: %v", schedule, err)
+			continue
+		}
+		if cs.match(sunday) != match {
+			t.Errorf("schedule %s must match sunday %v", schedule, match)
+		}
+	}
+	if _, err := parseCronSchedule("* * * * 8"); err == nil {
+		t.Error("schedule day of week 8 must be error")
+	}
+}
+
+func TestLogger_SetSchedule(t *testing.T) {
+
+	logger := NewLogger()
+	if logger.SetSchedule("file", "* * * * *") == nil {
+		t.Error("schedule of not attached adapter must be error")
+	}
+	err := logger.SetSchedule("console", "* * 31 2 *")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	logger.Info("logger schedule test, never output")
+}
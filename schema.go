@@ -0,0 +1,111 @@
+package go_logger
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+)
+
+// JSON record schema version
+//
+//	1 : timestamp, timestamp_format, millisecond, millisecond_format, level, level_string, body, file, line, function
+//	2 : add schema_version and fields
+//	3 : add name
+const LoggerMessageSchemaVersion = 3
+
+// migrate a decoded JSON record between two adjacent schema versions
+type schemaMigration struct {
+	upgrade   func(record map[string]interface{})
+	downgrade func(record map[string]interface{})
+}
+
+// key is the lower version of the two adjacent versions
+var schemaMigrations = map[int]schemaMigration{
+	1: {
+		upgrade: func(record map[string]interface{}) {
+			record["schema_version"] = 2
+		},
+		downgrade: func(record map[string]interface{}) {
+			// version 1 has no fields, append them to body
+			fields, ok := record["fields"].(map[string]interface{})
+			if ok && len(fields) > 0 {
+				body, _ := record["body"].(string)
+				record["body"] = body + " " + loggerFieldsFormat(fields)
+			}
+			delete(record, "fields")
+			delete(record, "schema_version")
+		},
+	},
+	2: {
+		upgrade: func(record map[string]interface{}) {
+			record["schema_version"] = 3
+		},
+		downgrade: func(record map[string]interface{}) {
+			// version 2 has no name, keep it as a field
+			if name, ok := record["name"]; ok {
+				fields, _ := record["fields"].(map[string]interface{})
+				if fields == nil {
+					fields = map[string]interface{}{}
+				}
+				if _, ok := fields["name"]; !ok {
+					fields["name"] = name
+				}
+				record["fields"] = fields
+				delete(record, "name")
+			}
+			record["schema_version"] = 2
+		},
+	},
+}
+
+//get schema version of JSON record, record without schema_version is version 1
+//params : record map[string]interface{}
+//return : version
+func JsonRecordSchemaVersion(record map[string]interface{}) int {
+	switch version := record["schema_version"].(type) {
+	case float64:
+		return int(version)
+	case int:
+		return version
+	case json.Number:
+		v, _ := version.Int64()
+		return int(v)
+	}
+	return 1
+}
+
+//convert JSON record of any schema version to the given version
+//params : record map[string]interface{}, version int
+//return : error
+func ConvertJsonRecord(record map[string]interface{}, version int) error {
+	if version < 1 || version > LoggerMessageSchemaVersion {
+		return errors.New("logger: schema version " + strconv.Itoa(version) + " is illegal!")
+	}
+	current := JsonRecordSchemaVersion(record)
+	if current < 1 || current > LoggerMessageSchemaVersion {
+		return errors.New("logger: record schema version " + strconv.Itoa(current) + " is unknown!")
+	}
+	for ; current < version; current++ {
+		schemaMigrations[current].upgrade(record)
+	}
+	for ; current > version; current-- {
+		schemaMigrations[current-1].downgrade(record)
+	}
+	return nil
+}
+
+//decode a JSON record line and convert it to the given version
+//params : data []byte, version int
+//return : record, error
+func DecodeJsonRecord(data []byte, version int) (map[string]interface{}, error) {
+	record := map[string]interface{}{}
+	err := json.Unmarshal(data, &record)
+	if err != nil {
+		return nil, err
+	}
+	err = ConvertJsonRecord(record, version)
+	if err != nil {
+		return nil, err
+	}
+	return record, nil
+}
@@ -0,0 +1,54 @@
+package go_logger
+
+import (
+	"testing"
+)
+
+func TestDecodeJsonRecord(t *testing.T) {
+
+	oldRecord := `{"timestamp":1521791201,"level":6,"level_string":"Info","body":"logger schema test"}`
+	record, err := DecodeJsonRecord([]byte(oldRecord), LoggerMessageSchemaVersion)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if JsonRecordSchemaVersion(record) != LoggerMessageSchemaVersion {
+		t.Error("json record upgrade error")
+	}
+
+	loggerMsg := &loggerMessage{
+		Body:          "logger schema test",
+		Fields:        map[string]interface{}{"user": "tom"},
+		SchemaVersion: LoggerMessageSchemaVersion,
+	}
+	jsonByte, _ := loggerMsg.MarshalJSON()
+	record, err = DecodeJsonRecord(jsonByte, 1)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if record["body"] != "logger schema test user=tom" {
+		t.Error("json record downgrade error")
+	}
+	if _, ok := record["schema_version"]; ok {
+		t.Error("json record downgrade must remove schema_version")
+	}
+
+	loggerMsg.Name = "payments"
+	jsonByte, _ = loggerMsg.MarshalJSON()
+	record, err = DecodeJsonRecord(jsonByte, 2)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	fields, _ := record["fields"].(map[string]interface{})
+	if _, ok := record["name"]; ok || fields["name"] != "payments" || JsonRecordSchemaVersion(record) != 2 {
+		t.Errorf("json record downgrade must move name to fields: %v", record)
+	}
+	record, _ = DecodeJsonRecord(jsonByte, 1)
+	if _, ok := record["name"]; ok || record["body"] != "logger schema test name=payments user=tom" {
+		t.Errorf("json record downgrade to version 1 must not keep name: %v", record)
+	}
+
+	_, err = DecodeJsonRecord(jsonByte, 100)
+	if err == nil {
+		t.Error("json record convert to illegal version must be error")
+	}
+}
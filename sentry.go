@@ -0,0 +1,345 @@
+//go:build !tinygo && !logger_tiny
+// +build !tinygo,!logger_tiny
+
+package go_logger
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	mathrand "math/rand"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const SENTRY_ADAPTER_NAME = "sentry"
+
+// max length of Sentry tag keys and values
+const (
+	sentryTagKeyMaxLength   = 32
+	sentryTagValueMaxLength = 200
+)
+
+// adapter sentry, Error and more severe messages are sent as Sentry events to the store API
+// an event has the message, the call stack as an exception stack trace and fields as tags
+type AdapterSentry struct {
+	lock     sync.Mutex // guards rand
+	config   *SentryConfig
+	client   *http.Client
+	store    string // store api url
+	auth     string // X-Sentry-Auth header
+	minLevel int
+	rand     *mathrand.Rand
+}
+
+// sentry config
+type SentryConfig struct {
+
+	// project DSN, "https://<key>@o0.ingest.sentry.io/<project>"
+	Dsn string `config:"required"`
+
+	// least severe level sent, "Error", "Critical", "Alert" or "Emergency", default "Error"
+	// less severe messages are never sent, whatever the output level
+	MinLevel string
+
+	// probability (0 ~ 1] that an event is sent, default 1
+	SampleRate float64
+
+	// event environment, e.g. "production", default none
+	Environment string
+
+	// event release, e.g. "api@1.4.2", default none
+	Release string
+
+	// event server name, default os.Hostname()
+	ServerName string
+
+	// request timeout, default 5 seconds
+	Timeout time.Duration
+}
+
+// frame of a Sentry stack trace
+type sentryFrame struct {
+	Filename string `json:"filename"`
+	AbsPath  string `json:"abs_path,omitempty"`
+	Function string `json:"function"`
+	Module   string `json:"module,omitempty"`
+	Lineno   int    `json:"lineno"`
+	InApp    bool   `json:"in_app"`
+}
+
+// Sentry event
+type sentryEvent struct {
+	EventId     string                 `json:"event_id"`
+	Timestamp   float64                `json:"timestamp"`
+	Level       string                 `json:"level"`
+	Logger      string                 `json:"logger,omitempty"`
+	Platform    string                 `json:"platform"`
+	Message     string                 `json:"message"`
+	Culprit     string                 `json:"culprit,omitempty"`
+	ServerName  string                 `json:"server_name,omitempty"`
+	Environment string                 `json:"environment,omitempty"`
+	Release     string                 `json:"release,omitempty"`
+	Tags        map[string]string      `json:"tags,omitempty"`
+	Extra       map[string]interface{} `json:"extra,omitempty"`
+	Fingerprint []string               `json:"fingerprint,omitempty"`
+	Exception   []sentryException      `json:"exception"`
+}
+
+// exception of a Sentry event
+type sentryException struct {
+	Type       string `json:"type"`
+	Value      string `json:"value"`
+	Stacktrace struct {
+		Frames []sentryFrame `json:"frames"`
+	} `json:"stacktrace"`
+}
+
+func (sc *SentryConfig) Name() string {
+	return SENTRY_ADAPTER_NAME
+}
+
+func NewAdapterSentry() LoggerAbstract {
+	return &AdapterSentry{
+		config: &SentryConfig{},
+	}
+}
+
+func (adapterSentry *AdapterSentry) Init(sentryConfig Config) error {
+	if sentryConfig.Name() != SENTRY_ADAPTER_NAME {
+		return errors.New("logger sentry adapter init error, config must SentryConfig")
+	}
+
+	sc := sentryConfig.(*SentryConfig)
+	adapterSentry.config = sc
+
+	if sc.Dsn == "" {
+		return errors.New("config Dsn cannot be empty!")
+	}
+	dsn, err := url.Parse(sc.Dsn)
+	if err != nil {
+		return errors.New("config Dsn " + sc.Dsn + " is illegal, " + err.Error())
+	}
+	slash := strings.LastIndex(dsn.Path, "/")
+	if dsn.Host == "" || dsn.User == nil || dsn.User.Username() == "" || slash < 0 || dsn.Path[slash+1:] == "" {
+		return errors.New("config Dsn " + sc.Dsn + " is illegal, needs key, host and project!")
+	}
+	adapterSentry.store = dsn.Scheme + "://" + dsn.Host + dsn.Path[:slash] + "/api/" + dsn.Path[slash+1:] + "/store/"
+	adapterSentry.auth = "Sentry sentry_version=7, sentry_client=go-logger/" + Version + ", sentry_key=" + dsn.User.Username()
+	if secret, ok := dsn.User.Password(); ok {
+		adapterSentry.auth += ", sentry_secret=" + secret
+	}
+
+	if sc.MinLevel == "" {
+		sc.MinLevel = levelStringMapping[LoggerLevelError]
+	}
+	adapterSentry.minLevel = -1
+	for level := LoggerLevelEmergency; level <= LoggerLevelError; level++ {
+		if strings.EqualFold(levelStringMapping[level], sc.MinLevel) {
+			adapterSentry.minLevel = level
+		}
+	}
+	if adapterSentry.minLevel == -1 {
+		return errors.New("config MinLevel " + sc.MinLevel + " must be Error or more severe!")
+	}
+	if sc.SampleRate < 0 || sc.SampleRate > 1 {
+		return errors.New("config SampleRate must between 0 and 1!")
+	}
+	if sc.SampleRate == 0 {
+		sc.SampleRate = 1
+	}
+	if sc.ServerName == "" {
+		sc.ServerName, _ = os.Hostname()
+	}
+	if sc.Timeout < 0 {
+		return errors.New("config Timeout cannot be negative!")
+	}
+	if sc.Timeout == 0 {
+		sc.Timeout = 5 * time.Second
+	}
+	adapterSentry.client = &http.Client{Timeout: sc.Timeout}
+	adapterSentry.rand = mathrand.New(mathrand.NewSource(time.Now().UnixNano()))
+	return nil
+}
+
+//the logger captures the call stack of Error+ messages for the sentry adapter
+func (adapterSentry *AdapterSentry) writesStack() {}
+
+func (adapterSentry *AdapterSentry) Write(loggerMsg *loggerMessage) error {
+	config := adapterSentry.config
+	if loggerMsg.Level > adapterSentry.minLevel {
+		return nil
+	}
+	if config.SampleRate < 1 {
+		// rand.Rand is not safe for concurrent use
+		adapterSentry.lock.Lock()
+		sampled := adapterSentry.rand.Float64() < config.SampleRate
+		adapterSentry.lock.Unlock()
+		if !sampled {
+			return nil
+		}
+	}
+
+	body, err := json.Marshal(adapterSentry.event(loggerMsg))
+	if err != nil {
+		return err
+	}
+	return adapterSentry.request(body)
+}
+
+//Sentry event of a message
+func (adapterSentry *AdapterSentry) event(loggerMsg *loggerMessage) *sentryEvent {
+	config := adapterSentry.config
+	event := &sentryEvent{
+		EventId:     sentryEventId(),
+		Timestamp:   float64(loggerMsg.Millisecond) / 1000,
+		Level:       "fatal",
+		Logger:      loggerMsg.Name,
+		Platform:    "go",
+		Message:     loggerMsg.Body,
+		Culprit:     loggerMsg.Function,
+		ServerName:  config.ServerName,
+		Environment: config.Environment,
+		Release:     config.Release,
+	}
+	if loggerMsg.Level == LoggerLevelError {
+		event.Level = "error"
+	}
+
+	// fields are tags, values of long keys are extra data
+	for key, value := range loggerMsg.Fields {
+		if key == fingerprintField {
+			event.Fingerprint = []string{fmt.Sprint(value)}
+		}
+		if len(key) > sentryTagKeyMaxLength {
+			if event.Extra == nil {
+				event.Extra = map[string]interface{}{}
+			}
+			event.Extra[key] = value
+			continue
+		}
+		tag, ok := formatValue(value)
+		if !ok {
+			tag = fmt.Sprint(value)
+		}
+		if event.Tags == nil {
+			event.Tags = map[string]string{}
+		}
+		event.Tags[key] = truncateString(strings.Replace(tag, "\n", " ", -1), sentryTagValueMaxLength, "...")
+	}
+
+	exception := sentryException{Type: loggerMsg.errorType, Value: loggerMsg.Body}
+	if exception.Type == "" {
+		exception.Type = loggerMsg.LevelString
+	}
+	exception.Stacktrace.Frames = sentryFrames(loggerMsg)
+	event.Exception = []sentryException{exception}
+	return event
+}
+
+//stack trace frames of a message, outermost first as Sentry expects
+//the caller is the only frame if the stack was not captured
+func sentryFrames(loggerMsg *loggerMessage) []sentryFrame {
+	if len(loggerMsg.stack) == 0 {
+		module, function := sentryFunction(loggerMsg.Function)
+		return []sentryFrame{{
+			Filename: loggerMsg.File,
+			AbsPath:  loggerMsg.filePath,
+			Function: function,
+			Module:   module,
+			Lineno:   loggerMsg.Line,
+			InApp:    true,
+		}}
+	}
+
+	frames := []sentryFrame{}
+	callersFrames := runtime.CallersFrames(loggerMsg.stack)
+	for {
+		frame, more := callersFrames.Next()
+		module, function := sentryFunction(frame.Function)
+		_, filename := path.Split(frame.File)
+		frames = append(frames, sentryFrame{
+			Filename: filename,
+			AbsPath:  frame.File,
+			Function: function,
+			Module:   module,
+			Lineno:   frame.Line,
+			InApp:    module != "runtime" && !strings.HasPrefix(module, "runtime/"),
+		})
+		if !more {
+			break
+		}
+	}
+	for i, j := 0, len(frames)-1; i < j; i, j = i+1, j-1 {
+		frames[i], frames[j] = frames[j], frames[i]
+	}
+	return frames
+}
+
+//split a qualified function name into the package path and the function
+//e.g. "github.com/a/b.(*T).M" is "github.com/a/b" and "(*T).M"
+func sentryFunction(name string) (string, string) {
+	slash := strings.LastIndex(name, "/")
+	dot := strings.Index(name[slash+1:], ".")
+	if dot < 0 {
+		return "", name
+	}
+	return name[:slash+1+dot], name[slash+2+dot:]
+}
+
+//random event id, 32 hex digits
+func sentryEventId() string {
+	id := make([]byte, 16)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+//post an event to the store api
+func (adapterSentry *AdapterSentry) request(body []byte) error {
+	request, err := http.NewRequest("POST", adapterSentry.store, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("X-Sentry-Auth", adapterSentry.auth)
+
+	response, err := adapterSentry.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		message, _ := ioutil.ReadAll(io.LimitReader(response.Body, 512))
+		return errors.New("request " + adapterSentry.store + " failed, code=" + strconv.Itoa(response.StatusCode) + ", " + strings.TrimSpace(string(message)))
+	}
+	return nil
+}
+
+func (adapterSentry *AdapterSentry) Flush() {
+
+}
+
+func (adapterSentry *AdapterSentry) Name() string {
+	return SENTRY_ADAPTER_NAME
+}
+
+//new empty config of the adapter, describes the config fields
+func (adapterSentry *AdapterSentry) NewConfig() Config {
+	return &SentryConfig{}
+}
+
+func init() {
+	Register(SENTRY_ADAPTER_NAME, NewAdapterSentry)
+}
@@ -0,0 +1,84 @@
+package go_logger
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+//silence an attached output for duration, it is re-enabled automatically
+//a notice record is written when the silence starts and ends
+//params : adapterName string, duration time.Duration, duration <= 0 ends the silence
+//return : error
+func (logger *Logger) Silence(adapterName string, duration time.Duration) error {
+	if duration <= 0 {
+		return logger.unsilence(adapterName, 0)
+	}
+
+	logger.lock.Lock()
+	output := logger.output(adapterName)
+	logger.lock.Unlock()
+	if output == nil {
+		return errors.New("logger: adapter " + adapterName + " is not attached!")
+	}
+
+	until := time.Now().Add(duration)
+	logger.writer(2, LoggerLevelNotice, "logger: adapter "+adapterName+" silenced", map[string]interface{}{
+		"adapter":       adapterName,
+		"silence_until": until.Format("2006-01-02 15:04:05"),
+	})
+
+	logger.lock.Lock()
+	defer logger.lock.Unlock()
+
+	atomic.StoreInt64(&output.silenceUntil, until.UnixNano())
+	if output.silenceTimer != nil {
+		output.silenceTimer.Stop()
+	}
+	output.silenceTimer = time.AfterFunc(duration, func() {
+		logger.unsilence(adapterName, until.UnixNano())
+	})
+	return nil
+}
+
+//end silence of output, if silenceUntil is not 0, only end the matched silence
+func (logger *Logger) unsilence(adapterName string, silenceUntil int64) error {
+	logger.lock.Lock()
+	output := logger.output(adapterName)
+	if output == nil {
+		logger.lock.Unlock()
+		return errors.New("logger: adapter " + adapterName + " is not attached!")
+	}
+	until := atomic.LoadInt64(&output.silenceUntil)
+	if until == 0 || (silenceUntil != 0 && until != silenceUntil) {
+		logger.lock.Unlock()
+		return nil
+	}
+	atomic.StoreInt64(&output.silenceUntil, 0)
+	if output.silenceTimer != nil {
+		output.silenceTimer.Stop()
+		output.silenceTimer = nil
+	}
+	logger.lock.Unlock()
+
+	logger.writer(2, LoggerLevelNotice, "logger: adapter "+adapterName+" silence ended", map[string]interface{}{
+		"adapter": adapterName,
+	})
+	return nil
+}
+
+//get attached output by adapter name after lock
+func (logger *Logger) output(adapterName string) *outputLogger {
+	for _, output := range logger.outputList() {
+		if output.Name == adapterName {
+			return output
+		}
+	}
+	return nil
+}
+
+//output is silenced now
+func (output *outputLogger) isSilenced() bool {
+	until := atomic.LoadInt64(&output.silenceUntil)
+	return until != 0 && time.Now().UnixNano() < until
+}
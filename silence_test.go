@@ -0,0 +1,38 @@
+package go_logger
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLogger_Silence(t *testing.T) {
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("console", LoggerLevelDebug, &ConsoleConfig{
+		Format: "%body%",
+	})
+	buffer := &bytes.Buffer{}
+	logger.outputList()[0].LoggerAbstract.(*AdapterConsole).write.writer = buffer
+
+	if logger.Silence("file", time.Second) == nil {
+		t.Error("silence not attached adapter must be error")
+	}
+	err := logger.Silence("console", 50*time.Millisecond)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	logger.Info("logger silence test")
+	time.Sleep(100 * time.Millisecond)
+	logger.Info("logger silence ended test")
+
+	lines := strings.Split(strings.TrimSpace(buffer.String()), "\n")
+	if len(lines) != 3 ||
+		!strings.HasPrefix(lines[0], "logger: adapter console silenced") ||
+		!strings.HasPrefix(lines[1], "logger: adapter console silence ended") ||
+		lines[2] != "logger silence ended test" {
+		t.Error("logger silence error: " + buffer.String())
+	}
+}
@@ -0,0 +1,51 @@
+package go_logger
+
+import "time"
+
+// clock skew compensation of an output
+type clockSkew struct {
+	offset   time.Duration
+	sendTime bool
+}
+
+//set clock skew compensation of an attached output, for remote sinks with strict timestamp windows
+//the offset is added to the message time; with sendTime, the "event_time_ms" field keeps
+//the original event time and the "send_time_ms" field records when the output writes it
+//params : adapterName string, offset time.Duration, sendTime bool; offset 0 and sendTime false to remove
+//return : error
+func (logger *Logger) SetClockSkew(adapterName string, offset time.Duration, sendTime bool) error {
+	logger.lock.Lock()
+	defer logger.lock.Unlock()
+
+	var skew *clockSkew
+	if offset != 0 || sendTime {
+		skew = &clockSkew{
+			offset:   offset,
+			sendTime: sendTime,
+		}
+	}
+	return logger.updateOutput(adapterName, func(output *outputLogger) error {
+		output.clockSkew = skew
+		return nil
+	})
+}
+
+//apply clock skew to the output copy of message
+func (skew *clockSkew) apply(loggerMsg *loggerMessage) {
+	if skew.sendTime {
+		fields := make(map[string]interface{}, len(loggerMsg.Fields)+2)
+		for key, value := range loggerMsg.Fields {
+			fields[key] = value
+		}
+		fields["event_time_ms"] = loggerMsg.Millisecond
+		fields["send_time_ms"] = time.Now().UnixNano() / 1e6
+		loggerMsg.Fields = fields
+	}
+	if skew.offset != 0 {
+		t := time.Unix(0, loggerMsg.Millisecond*1e6).Add(skew.offset)
+		loggerMsg.Timestamp = t.Unix()
+		loggerMsg.TimestampFormat = t.Format("2006-01-02 15:04:05")
+		loggerMsg.Millisecond = t.UnixNano() / 1e6
+		loggerMsg.MillisecondFormat = t.Format("2006-01-02 15:04:05.999")
+	}
+}
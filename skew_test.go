@@ -0,0 +1,41 @@
+package go_logger
+
+import (
+	"bytes"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestLogger_SetClockSkew(t *testing.T) {
+
+	logger := NewLogger()
+	if logger.SetClockSkew("file", time.Second, false) == nil {
+		t.Error("not attached adapter must be error")
+	}
+	logger.Detach("console")
+	logger.Attach("console", LoggerLevelDebug, &ConsoleConfig{
+		Format: "%millisecond% %body% %fields%",
+	})
+	buffer := &bytes.Buffer{}
+	logger.outputList()[0].LoggerAbstract.(*AdapterConsole).write.writer = buffer
+	logger.SetRecentBuffer(1)
+
+	logger.SetClockSkew("console", time.Hour, true)
+	logger.Info("logger clock skew test")
+
+	loggerMsg := logger.recent.Load().(*recentBuffer).snapshot()[0]
+	if loggerMsg.Fields != nil {
+		t.Error("clock skew must not change the shared message")
+	}
+	shifted := loggerMsg.Millisecond + int64(time.Hour/time.Millisecond)
+	expectedPrefix := []byte(strconv.FormatInt(shifted, 10) + " logger clock skew test event_time_ms=" + strconv.FormatInt(loggerMsg.Millisecond, 10) + " send_time_ms=")
+	if !bytes.HasPrefix(buffer.Bytes(), expectedPrefix) {
+		t.Error("clock skew output error: " + buffer.String())
+	}
+
+	logger.SetClockSkew("console", 0, false)
+	if logger.outputList()[0].clockSkew != nil {
+		t.Error("clock skew offset 0 without send time must remove it")
+	}
+}
@@ -0,0 +1,82 @@
+package go_logger
+
+import (
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// read-only snapshot of logger configuration
+type Snapshot struct {
+	Adapters          []AdapterSnapshot `json:"adapters"`
+	Async             bool              `json:"async"`
+	AsyncChanLen      int               `json:"async_chan_len"`
+	QueueTTL          string            `json:"queue_ttl"`
+	DeadLetterAdapter string            `json:"dead_letter_adapter"`
+	StrictMode        int               `json:"strict_mode"`
+	StrictOrdering    bool              `json:"strict_ordering"`
+	Disabled          bool              `json:"disabled"`
+	RecentBufferSize  int               `json:"recent_buffer_size"`
+	Tags              map[string]string `json:"tags,omitempty"`
+}
+
+// snapshot of an attached output
+type AdapterSnapshot struct {
+	Name        string `json:"name"`
+	Level       int    `json:"level"`
+	LevelString string `json:"level_string"`
+	JsonFormat  bool   `json:"json_format"`
+	Format      string `json:"format"`
+	Schedule    string `json:"schedule"`
+	Silenced    bool   `json:"silenced"`
+	Retries     int    `json:"retries"`
+	Workers     int    `json:"workers"`
+
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+//get a read-only snapshot of the current configuration, for debug endpoints
+//adapter configs are not included, only their Format and JsonFormat fields
+//return : Snapshot
+func (logger *Logger) Config() Snapshot {
+	logger.lock.Lock()
+	defer logger.lock.Unlock()
+
+	outputs := logger.outputList()
+	snapshot := Snapshot{
+		Adapters:          make([]AdapterSnapshot, 0, len(outputs)),
+		Async:             !logger.synchronous,
+		QueueTTL:          time.Duration(atomic.LoadInt64(&logger.queueTTL)).String(),
+		DeadLetterAdapter: logger.deadLetterAdapter.Load().(string),
+		StrictMode:        int(atomic.LoadInt32(&logger.strictMode)),
+		StrictOrdering:    logger.strictOrdering,
+		Disabled:          logger.Disabled(),
+	}
+	tags, _ := logger.tags.Load().(map[string]string)
+	snapshot.Tags = copyTags(tags)
+	if !logger.synchronous {
+		snapshot.AsyncChanLen = cap(logger.msgChan)
+	}
+	if recent, _ := logger.recent.Load().(*recentBuffer); recent != nil {
+		snapshot.RecentBufferSize = len(recent.messages)
+	}
+	for _, output := range outputs {
+		adapterSnapshot := AdapterSnapshot{
+			Name:        output.Name,
+			Level:       output.getLevel(),
+			LevelString: strings.ToLower(levelStringMapping[output.getLevel()]),
+			Silenced:    output.isSilenced(),
+			Retries:     output.retries,
+			Tags:        copyTags(output.tags),
+		}
+		if offload := output.offload; offload != nil && !offload.isStopped() {
+			adapterSnapshot.Workers = offload.workers
+		}
+		if output.schedule != nil {
+			adapterSnapshot.Schedule = output.schedule.spec
+		}
+		adapterSnapshot.Format, adapterSnapshot.JsonFormat = configFormat(output.config)
+		snapshot.Adapters = append(snapshot.Adapters, adapterSnapshot)
+	}
+	return snapshot
+}
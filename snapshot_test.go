@@ -0,0 +1,33 @@
+package go_logger
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestLogger_Config(t *testing.T) {
+
+	logger := NewLogger()
+	logger.Attach("file", LoggerLevelError, &FileConfig{
+		Filename:   "./test.log",
+		JsonFormat: true,
+	})
+	logger.SetSchedule("file", "* 9-17 * * 1-5")
+	logger.SetAsync(20)
+
+	snapshot := logger.Config()
+	if !snapshot.Async || snapshot.AsyncChanLen != 20 || len(snapshot.Adapters) != 2 {
+		t.Errorf("logger config snapshot error: %+v", snapshot)
+	}
+	console := snapshot.Adapters[0]
+	if console.Name != "console" || console.LevelString != "debug" || console.Format != defaultLoggerMessageFormat {
+		t.Errorf("logger config console snapshot error: %+v", console)
+	}
+	file := snapshot.Adapters[1]
+	if file.Name != "file" || file.Level != LoggerLevelError || !file.JsonFormat || file.Schedule != "* 9-17 * * 1-5" {
+		t.Errorf("logger config file snapshot error: %+v", file)
+	}
+	if _, err := json.Marshal(snapshot); err != nil {
+		t.Error(err.Error())
+	}
+}
@@ -0,0 +1,112 @@
+package go_logger
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// soak test duration per mode, e.g. go test -race -run TestLogger_Soak -logger.soak 10m
+var soakDuration = flag.Duration("logger.soak", 200*time.Millisecond, "duration of TestLogger_Soak per mode")
+
+// discarding adapter attached and detached by the soak test
+type adapterDiscard struct {
+	adapterCount
+}
+
+func (adapter *adapterDiscard) Name() string {
+	return "discard"
+}
+
+func init() {
+	Register("discard", func() LoggerAbstract {
+		return &adapterDiscard{}
+	})
+}
+
+//concurrent producers with attach/detach churn, level changes and flush storms,
+//the stable output must receive every message
+func TestLogger_Soak(t *testing.T) {
+
+	for _, async := range []bool{false, true} {
+		logger := NewLogger()
+		logger.Detach(CONSOLE_ADAPTER_NAME)
+		logger.Attach("count", LoggerLevelDebug, &countConfig{})
+		count := logger.outputList()[0].LoggerAbstract.(*adapterCount)
+		if async {
+			logger.SetAsync(100)
+		}
+
+		stop := make(chan struct{})
+		background := sync.WaitGroup{}
+		// attach/detach churn and level changes
+		background.Add(1)
+		go func() {
+			defer background.Done()
+			for level := 0; ; level++ {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				logger.Attach("discard", LoggerLevelDebug, &countConfig{})
+				logger.SetLevel("discard", level%(LoggerLevelDebug+1))
+				logger.Config()
+				logger.Detach("discard")
+			}
+		}()
+		// flush storms
+		for i := 0; i < 2; i++ {
+			background.Add(1)
+			go func() {
+				defer background.Done()
+				for {
+					select {
+					case <-stop:
+						return
+					default:
+					}
+					logger.Flush()
+				}
+			}()
+		}
+
+		var produced int64
+		producers := sync.WaitGroup{}
+		deadline := time.Now().Add(*soakDuration)
+		ctx := context.WithValue(context.Background(), ContextKeyRequestId, "soak")
+		for i := 0; i < 8; i++ {
+			producers.Add(1)
+			go func(i int) {
+				defer producers.Done()
+				entry := logger.Named("soak").WithField("producer", i)
+				for j := 0; time.Now().Before(deadline); j++ {
+					switch j % 4 {
+					case 0:
+						logger.Infow("logger soak", "j", j, "err", errors.New("soak"))
+					case 1:
+						logger.DebugCtxf(ctx, "logger soak %d", j)
+					case 2:
+						entry.Warningf("logger soak %d", j)
+					case 3:
+						entry.WithField("j", j).Notice("logger soak")
+					}
+					atomic.AddInt64(&produced, 1)
+				}
+			}(i)
+		}
+		producers.Wait()
+		close(stop)
+		background.Wait()
+		logger.Flush()
+
+		if writes := atomic.LoadInt64(&count.writes); writes != atomic.LoadInt64(&produced) {
+			t.Errorf("async %v, soak output received %d of %d messages", async, writes, produced)
+		}
+		logger.Close()
+	}
+}
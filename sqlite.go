@@ -0,0 +1,282 @@
+//go:build !tinygo && !logger_tiny
+// +build !tinygo,!logger_tiny
+
+package go_logger
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const SQLITE_ADAPTER_NAME = "sqlite"
+
+// rows between prunes of MaxRows
+const sqlitePruneInterval = 1000
+
+// adapter sqlite, a row per message in a local database file in WAL mode, searchable by Query
+// the sqlite database/sql driver is imported by the application, e.g.
+//	import _ "github.com/mattn/go-sqlite3"  // DriverName "sqlite3"
+//	import _ "modernc.org/sqlite"           // DriverName "sqlite"
+type AdapterSqlite struct {
+	lock    sync.Mutex
+	config  *SqliteConfig
+	db      *sql.DB
+	insert  *sql.Stmt
+	table   string // quoted table name
+	inserts int    // rows since the last prune
+}
+
+// sqlite config
+type SqliteConfig struct {
+
+	// database file, created if not exist, "./logs.db"
+	Filename string `config:"required"`
+
+	// database/sql driver name, default "sqlite3"
+	DriverName string
+
+	// table, created if not exist, default "logs"
+	//	id INTEGER PRIMARY KEY, timestamp INTEGER (unix millisecond), level INTEGER, level_string TEXT,
+	//	name TEXT, body TEXT, file TEXT, line INTEGER, function TEXT, fields TEXT (JSON object)
+	Table string
+
+	// max rows kept, the oldest rows are deleted every 1000 inserts, 0 keeps all rows
+	MaxRows int
+
+	// wait for a database locked by another process, default 5 seconds
+	BusyTimeout time.Duration
+}
+
+// query of AdapterSqlite Query, zero fields do not filter
+type SqliteQuery struct {
+
+	// messages of the levels
+	Levels []int
+
+	// messages at or after Since and before Until
+	Since time.Time
+	Until time.Time
+
+	// messages of the named child logger
+	Name string
+
+	// messages with the body containing the text, case insensitive for ASCII
+	Contains string
+
+	// max messages, newest first, default 100
+	Limit int
+}
+
+func (sc *SqliteConfig) Name() string {
+	return SQLITE_ADAPTER_NAME
+}
+
+func NewAdapterSqlite() LoggerAbstract {
+	return &AdapterSqlite{
+		config: &SqliteConfig{},
+	}
+}
+
+func (adapterSqlite *AdapterSqlite) Init(sqliteConfig Config) error {
+	if sqliteConfig.Name() != SQLITE_ADAPTER_NAME {
+		return errors.New("logger sqlite adapter init error, config must SqliteConfig")
+	}
+
+	sc := sqliteConfig.(*SqliteConfig)
+	adapterSqlite.config = sc
+
+	if sc.Filename == "" {
+		return errors.New("config Filename cannot be empty!")
+	}
+	if sc.MaxRows < 0 || sc.BusyTimeout < 0 {
+		return errors.New("config MaxRows and BusyTimeout cannot be negative!")
+	}
+	if sc.DriverName == "" {
+		sc.DriverName = "sqlite3"
+	}
+	if sc.Table == "" {
+		sc.Table = "logs"
+	}
+	if sc.BusyTimeout == 0 {
+		sc.BusyTimeout = 5 * time.Second
+	}
+	adapterSqlite.table = `"` + strings.Replace(sc.Table, `"`, `""`, -1) + `"`
+
+	db, err := sql.Open(sc.DriverName, sc.Filename)
+	if err != nil {
+		return err
+	}
+	// one connection, sqlite has a single writer and pragmas are per connection
+	db.SetMaxOpenConns(1)
+	statements := []string{
+		"PRAGMA journal_mode=WAL",
+		"PRAGMA synchronous=NORMAL",
+		"PRAGMA busy_timeout=" + strconv.FormatInt(int64(sc.BusyTimeout/time.Millisecond), 10),
+		"CREATE TABLE IF NOT EXISTS " + adapterSqlite.table + " (" +
+			"id INTEGER PRIMARY KEY AUTOINCREMENT, timestamp INTEGER NOT NULL, level INTEGER NOT NULL, level_string TEXT NOT NULL, " +
+			"name TEXT NOT NULL, body TEXT NOT NULL, file TEXT NOT NULL, line INTEGER NOT NULL, function TEXT NOT NULL, fields TEXT NOT NULL)",
+		"CREATE INDEX IF NOT EXISTS " + sqliteIndex(sc.Table, "timestamp") + " ON " + adapterSqlite.table + " (timestamp)",
+		"CREATE INDEX IF NOT EXISTS " + sqliteIndex(sc.Table, "level") + " ON " + adapterSqlite.table + " (level, timestamp)",
+	}
+	for _, statement := range statements {
+		if _, err := db.Exec(statement); err != nil {
+			db.Close()
+			return err
+		}
+	}
+	insert, err := db.Prepare("INSERT INTO " + adapterSqlite.table +
+		" (timestamp, level, level_string, name, body, file, line, function, fields) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)")
+	if err != nil {
+		db.Close()
+		return err
+	}
+	adapterSqlite.db = db
+	adapterSqlite.insert = insert
+	return adapterSqlite.prune()
+}
+
+//quoted index name of a table column
+func sqliteIndex(table string, column string) string {
+	return `"` + strings.Replace(table, `"`, `""`, -1) + "_" + column + `"`
+}
+
+func (adapterSqlite *AdapterSqlite) Write(loggerMsg *loggerMessage) error {
+	fields := "{}"
+	if len(loggerMsg.Fields) > 0 {
+		data, err := json.Marshal(loggerMsg.Fields)
+		if err != nil {
+			return err
+		}
+		fields = string(data)
+	}
+
+	adapterSqlite.lock.Lock()
+	defer adapterSqlite.lock.Unlock()
+
+	_, err := adapterSqlite.insert.Exec(loggerMsg.Millisecond, loggerMsg.Level, loggerMsg.LevelString, loggerMsg.Name,
+		loggerMsg.Body, loggerMsg.File, loggerMsg.Line, loggerMsg.Function, fields)
+	if err != nil {
+		return err
+	}
+	adapterSqlite.inserts++
+	if adapterSqlite.inserts < sqlitePruneInterval {
+		return nil
+	}
+	return adapterSqlite.prune()
+}
+
+//delete the oldest rows beyond MaxRows
+func (adapterSqlite *AdapterSqlite) prune() error {
+	adapterSqlite.inserts = 0
+	if adapterSqlite.config.MaxRows == 0 {
+		return nil
+	}
+	_, err := adapterSqlite.db.Exec("DELETE FROM "+adapterSqlite.table+
+		" WHERE id <= (SELECT MAX(id) FROM "+adapterSqlite.table+") - ?", adapterSqlite.config.MaxRows)
+	return err
+}
+
+//search written messages, newest first
+//params : query SqliteQuery
+//return : []*LoggerMessage, error
+func (adapterSqlite *AdapterSqlite) Query(query SqliteQuery) ([]*LoggerMessage, error) {
+	if adapterSqlite.db == nil {
+		return nil, errors.New("logger: sqlite adapter is not initialized!")
+	}
+	conditions := []string{}
+	args := []interface{}{}
+	if len(query.Levels) > 0 {
+		placeholders := make([]string, len(query.Levels))
+		for i, level := range query.Levels {
+			placeholders[i] = "?"
+			args = append(args, level)
+		}
+		conditions = append(conditions, "level IN ("+strings.Join(placeholders, ", ")+")")
+	}
+	if !query.Since.IsZero() {
+		conditions = append(conditions, "timestamp >= ?")
+		args = append(args, query.Since.UnixNano()/1e6)
+	}
+	if !query.Until.IsZero() {
+		conditions = append(conditions, "timestamp < ?")
+		args = append(args, query.Until.UnixNano()/1e6)
+	}
+	if query.Name != "" {
+		conditions = append(conditions, "name = ?")
+		args = append(args, query.Name)
+	}
+	if query.Contains != "" {
+		conditions = append(conditions, `body LIKE ? ESCAPE '\'`)
+		args = append(args, "%"+strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(query.Contains)+"%")
+	}
+	limit := query.Limit
+	if limit <= 0 {
+		limit = 100
+	}
+	statement := "SELECT timestamp, level, level_string, name, body, file, line, function, fields FROM " + adapterSqlite.table
+	if len(conditions) > 0 {
+		statement += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	statement += " ORDER BY id DESC LIMIT " + strconv.Itoa(limit)
+
+	rows, err := adapterSqlite.db.Query(statement, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	messages := []*LoggerMessage{}
+	for rows.Next() {
+		loggerMsg := &LoggerMessage{SchemaVersion: LoggerMessageSchemaVersion}
+		fields := ""
+		err := rows.Scan(&loggerMsg.Millisecond, &loggerMsg.Level, &loggerMsg.LevelString, &loggerMsg.Name,
+			&loggerMsg.Body, &loggerMsg.File, &loggerMsg.Line, &loggerMsg.Function, &fields)
+		if err != nil {
+			return nil, err
+		}
+		if fields != "{}" {
+			if err := json.Unmarshal([]byte(fields), &loggerMsg.Fields); err != nil {
+				return nil, err
+			}
+		}
+		t := time.Unix(0, loggerMsg.Millisecond*int64(time.Millisecond))
+		loggerMsg.Timestamp = t.Unix()
+		loggerMsg.TimestampFormat = t.Format("2006-01-02 15:04:05")
+		loggerMsg.MillisecondFormat = t.Format("2006-01-02 15:04:05.999")
+		messages = append(messages, loggerMsg)
+	}
+	return messages, rows.Err()
+}
+
+func (adapterSqlite *AdapterSqlite) Flush() {
+
+}
+
+//close the database, the adapter must not be written after Close
+func (adapterSqlite *AdapterSqlite) Close() error {
+	adapterSqlite.lock.Lock()
+	defer adapterSqlite.lock.Unlock()
+
+	if adapterSqlite.db == nil {
+		return nil
+	}
+	adapterSqlite.insert.Close()
+	return adapterSqlite.db.Close()
+}
+
+func (adapterSqlite *AdapterSqlite) Name() string {
+	return SQLITE_ADAPTER_NAME
+}
+
+//new empty config of the adapter, describes the config fields
+func (adapterSqlite *AdapterSqlite) NewConfig() Config {
+	return &SqliteConfig{}
+}
+
+func init() {
+	Register(SQLITE_ADAPTER_NAME, NewAdapterSqlite)
+}
@@ -0,0 +1,209 @@
+//go:build !tinygo && !logger_tiny
+// +build !tinygo,!logger_tiny
+
+package go_logger
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// statement executed by the fake sqlite driver
+type sqliteStatement struct {
+	query string
+	args  []driver.Value
+}
+
+// fake sqlite database/sql driver, statements are recorded
+// inserted rows are kept, queries return them newest first without filtering
+type sqliteDriver struct {
+	lock       sync.Mutex
+	statements []sqliteStatement
+	rows       [][]driver.Value
+}
+
+var fakeSqlite = &sqliteDriver{}
+
+func init() {
+	sql.Register("sqlitefake", fakeSqlite)
+}
+
+func (d *sqliteDriver) Open(name string) (driver.Conn, error) {
+	if name == "" {
+		return nil, errors.New("unable to open database file")
+	}
+	return &sqliteConn{driver: d}, nil
+}
+
+func (d *sqliteDriver) reset() {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.statements = nil
+	d.rows = nil
+}
+
+func (d *sqliteDriver) executed() []sqliteStatement {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return append([]sqliteStatement{}, d.statements...)
+}
+
+type sqliteConn struct {
+	driver *sqliteDriver
+}
+
+func (c *sqliteConn) Prepare(query string) (driver.Stmt, error) {
+	return &sqliteStmt{driver: c.driver, query: query}, nil
+}
+
+func (c *sqliteConn) Close() error {
+	return nil
+}
+
+func (c *sqliteConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("transactions are not supported")
+}
+
+type sqliteStmt struct {
+	driver *sqliteDriver
+	query  string
+}
+
+func (s *sqliteStmt) Close() error {
+	return nil
+}
+
+func (s *sqliteStmt) NumInput() int {
+	return -1
+}
+
+func (s *sqliteStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.driver.lock.Lock()
+	defer s.driver.lock.Unlock()
+	s.driver.statements = append(s.driver.statements, sqliteStatement{s.query, args})
+	if strings.HasPrefix(s.query, "INSERT") {
+		s.driver.rows = append(s.driver.rows, args)
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (s *sqliteStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.driver.lock.Lock()
+	defer s.driver.lock.Unlock()
+	s.driver.statements = append(s.driver.statements, sqliteStatement{s.query, args})
+	rows := &sqliteRows{}
+	for i := len(s.driver.rows) - 1; i >= 0; i-- {
+		rows.rows = append(rows.rows, s.driver.rows[i])
+	}
+	return rows, nil
+}
+
+type sqliteRows struct {
+	rows [][]driver.Value
+}
+
+func (r *sqliteRows) Columns() []string {
+	return []string{"timestamp", "level", "level_string", "name", "body", "file", "line", "function", "fields"}
+}
+
+func (r *sqliteRows) Close() error {
+	return nil
+}
+
+func (r *sqliteRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+func TestAdapterSqlite_Init(t *testing.T) {
+
+	configs := []*SqliteConfig{
+		{},
+		{Filename: "./logs.db", MaxRows: -1},
+		{Filename: "./logs.db", DriverName: "unknown"},
+	}
+	for _, config := range configs {
+		if NewAdapterSqlite().Init(config) == nil {
+			t.Errorf("sqlite adapter config %+v must be error", config)
+		}
+	}
+
+	fakeSqlite.reset()
+	sqliteAdapter := NewAdapterSqlite()
+	err := sqliteAdapter.Init(&SqliteConfig{Filename: "./logs.db", DriverName: "sqlitefake", Table: "app", MaxRows: 10})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer sqliteAdapter.(*AdapterSqlite).Close()
+
+	queries := []string{}
+	for _, statement := range fakeSqlite.executed() {
+		queries = append(queries, statement.query)
+	}
+	if len(queries) != 7 || queries[0] != "PRAGMA journal_mode=WAL" || queries[2] != "PRAGMA busy_timeout=5000" ||
+		!strings.HasPrefix(queries[3], `CREATE TABLE IF NOT EXISTS "app" (id INTEGER PRIMARY KEY`) ||
+		queries[4] != `CREATE INDEX IF NOT EXISTS "app_timestamp" ON "app" (timestamp)` ||
+		!strings.HasPrefix(queries[6], `DELETE FROM "app" WHERE id <=`) {
+		t.Errorf("sqlite init statements %q", queries)
+	}
+}
+
+func TestAdapterSqlite_Write(t *testing.T) {
+
+	fakeSqlite.reset()
+	sqliteAdapter := NewAdapterSqlite()
+	err := sqliteAdapter.Init(&SqliteConfig{Filename: "./logs.db", DriverName: "sqlitefake"})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer sqliteAdapter.(*AdapterSqlite).Close()
+
+	messages := []*loggerMessage{
+		{Millisecond: 1500000000123, Level: LoggerLevelError, LevelString: "Error", Body: "logger sqlite 1", Name: "db",
+			File: "main.go", Line: 10, Function: "main.main", Fields: map[string]interface{}{"user": "u1", "cost": 12}},
+		{Millisecond: 1500000000124, Level: LoggerLevelInfo, LevelString: "Info", Body: "logger sqlite 2"},
+	}
+	for _, loggerMsg := range messages {
+		if err := sqliteAdapter.Write(loggerMsg); err != nil {
+			t.Fatal(err.Error())
+		}
+	}
+	statements := fakeSqlite.executed()
+	insert := statements[len(statements)-2]
+	if !strings.HasPrefix(insert.query, `INSERT INTO "logs" (timestamp, level,`) || len(insert.args) != 9 ||
+		insert.args[4] != "logger sqlite 1" || insert.args[8] != `{"cost":12,"user":"u1"}` {
+		t.Errorf("sqlite insert statement %v", insert)
+	}
+
+	found, err := sqliteAdapter.(*AdapterSqlite).Query(SqliteQuery{
+		Levels:   []int{LoggerLevelError, LoggerLevelInfo},
+		Since:    time.Unix(1500000000, 0),
+		Name:     "db",
+		Contains: "50%_off",
+		Limit:    10,
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	statements = fakeSqlite.executed()
+	query := statements[len(statements)-1]
+	expected := `SELECT timestamp, level, level_string, name, body, file, line, function, fields FROM "logs" ` +
+		`WHERE level IN (?, ?) AND timestamp >= ? AND name = ? AND body LIKE ? ESCAPE '\' ORDER BY id DESC LIMIT 10`
+	if query.query != expected || len(query.args) != 5 || query.args[2] != int64(1500000000000) || query.args[4] != `%50\%\_off%` {
+		t.Errorf("sqlite query statement %v", query)
+	}
+	if len(found) != 2 || found[1].Body != "logger sqlite 1" || found[1].Level != LoggerLevelError || found[1].Line != 10 ||
+		found[1].Name != "db" || found[1].Fields["user"] != "u1" || found[1].Timestamp != 1500000000 || found[0].Fields != nil {
+		t.Errorf("sqlite query messages %+v", found)
+	}
+}
@@ -0,0 +1,51 @@
+package go_logger
+
+import (
+	"os"
+	"runtime"
+	"runtime/debug"
+	"strings"
+)
+
+//log a startup record at info level with version, build info, GOMAXPROCS,
+//selected environment variables and attached adapters
+//call it after attaching adapters, so every output begins with reproducibility context
+//params : envKeys ...string, environment variables to record
+func (logger *Logger) LogStartup(envKeys ...string) {
+	fields := map[string]interface{}{
+		"logger_version": Version,
+		"go_version":     runtime.Version(),
+		"go_os":          runtime.GOOS,
+		"go_arch":        runtime.GOARCH,
+		"gomaxprocs":     runtime.GOMAXPROCS(0),
+		"num_cpu":        runtime.NumCPU(),
+		"pid":            os.Getpid(),
+	}
+	if hostname, err := os.Hostname(); err == nil {
+		fields["hostname"] = hostname
+	}
+	if buildInfo, ok := debug.ReadBuildInfo(); ok {
+		fields["main_path"] = buildInfo.Main.Path
+		fields["main_version"] = buildInfo.Main.Version
+	}
+	if len(envKeys) > 0 {
+		env := make(map[string]string, len(envKeys))
+		for _, key := range envKeys {
+			if value, ok := os.LookupEnv(key); ok {
+				env[key] = value
+			}
+		}
+		fields["env"] = env
+	}
+
+	outputs := []string{}
+	for _, output := range logger.outputList() {
+		outputs = append(outputs, output.Name+":"+strings.ToLower(levelStringMapping[output.getLevel()]))
+	}
+	logger.lock.Lock()
+	fields["async"] = !logger.synchronous
+	logger.lock.Unlock()
+	fields["adapters"] = strings.Join(outputs, ",")
+
+	logger.writer(2, LoggerLevelInfo, "logger: startup", fields)
+}
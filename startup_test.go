@@ -0,0 +1,30 @@
+package go_logger
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestLogger_LogStartup(t *testing.T) {
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("console", LoggerLevelInfo, &ConsoleConfig{
+		JsonFormat: true,
+	})
+	buffer := &bytes.Buffer{}
+	logger.outputList()[0].LoggerAbstract.(*AdapterConsole).write.writer = buffer
+
+	os.Setenv("LOGGER_STARTUP_TEST", "on")
+	defer os.Unsetenv("LOGGER_STARTUP_TEST")
+	logger.LogStartup("LOGGER_STARTUP_TEST")
+
+	output := buffer.String()
+	if !strings.Contains(output, `"adapters":"console:info"`) ||
+		!strings.Contains(output, `"LOGGER_STARTUP_TEST":"on"`) ||
+		!strings.Contains(output, `"gomaxprocs":`) {
+		t.Error("logger startup record error: " + output)
+	}
+}
@@ -0,0 +1,115 @@
+package go_logger
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+)
+
+// records buffered until the logger is configured
+type startupBuffer struct {
+	lock     sync.Mutex
+	messages []*loggerMessage
+	size     int
+	dropped  int
+	ready    bool
+}
+
+//buffer records instead of writing them until Ready is called, so records logged before
+//the outputs are configured (e.g. from package init functions) reach the configured outputs;
+//at most size records are kept, the oldest are dropped
+//the default logger buffers from package initialization if built with -tags logger_startup_buffer
+//params : size int
+//return : error
+func (logger *Logger) SetStartupBuffer(size int) error {
+	if size <= 0 {
+		return errors.New("logger: startup buffer size must be positive!")
+	}
+	logger.lock.Lock()
+	defer logger.lock.Unlock()
+
+	if buffer, _ := logger.startup.Load().(*startupBuffer); buffer != nil && !buffer.isReady() {
+		return errors.New("logger: startup buffer is already set!")
+	}
+	logger.startup.Store(&startupBuffer{size: size})
+	return nil
+}
+
+//write the buffered startup records to the current outputs in order and stop buffering,
+//a warning record counts the dropped records; records logged meanwhile may interleave
+//return : error if no startup buffer is set
+func (logger *Logger) Ready() error {
+	buffer, _ := logger.startup.Load().(*startupBuffer)
+	if buffer == nil {
+		return errors.New("logger: startup buffer is not set!")
+	}
+	messages, dropped := buffer.drain()
+	if dropped > 0 {
+		logger.writer(2, LoggerLevelWarning, "logger: startup buffer full, "+strconv.Itoa(dropped)+" records dropped", nil)
+	}
+	for _, loggerMsg := range messages {
+		logger.dispatch(loggerMsg)
+	}
+	return nil
+}
+
+//buffer a dispatched message if the logger is not ready
+//return : true if buffered
+func (logger *Logger) bufferStartup(loggerMsg *loggerMessage) bool {
+	buffer, _ := logger.startup.Load().(*startupBuffer)
+	if buffer == nil {
+		return false
+	}
+	return buffer.add(loggerMsg)
+}
+
+//move the buffered startup records to another logger, for SetDefault
+func (logger *Logger) handOverStartup(to *Logger) {
+	buffer, _ := logger.startup.Load().(*startupBuffer)
+	if buffer == nil || buffer.isReady() {
+		return
+	}
+	messages, dropped := buffer.drain()
+	if dropped > 0 {
+		to.writer(2, LoggerLevelWarning, "logger: startup buffer full, "+strconv.Itoa(dropped)+" records dropped", nil)
+	}
+	for _, loggerMsg := range messages {
+		to.relay(loggerMsg)
+	}
+}
+
+//add message, false if ready
+func (buffer *startupBuffer) add(loggerMsg *loggerMessage) bool {
+	buffer.lock.Lock()
+	defer buffer.lock.Unlock()
+
+	if buffer.ready {
+		return false
+	}
+	if len(buffer.messages) == buffer.size {
+		copy(buffer.messages, buffer.messages[1:])
+		buffer.messages = buffer.messages[:buffer.size-1]
+		buffer.dropped++
+	}
+	buffer.messages = append(buffer.messages, loggerMsg)
+	return true
+}
+
+//set ready, return buffered messages and dropped count
+func (buffer *startupBuffer) drain() ([]*loggerMessage, int) {
+	buffer.lock.Lock()
+	defer buffer.lock.Unlock()
+
+	buffer.ready = true
+	messages, dropped := buffer.messages, buffer.dropped
+	buffer.messages = nil
+	buffer.dropped = 0
+	return messages, dropped
+}
+
+func (buffer *startupBuffer) isReady() bool {
+	buffer.lock.Lock()
+	defer buffer.lock.Unlock()
+
+	return buffer.ready
+}
@@ -0,0 +1,7 @@
+//go:build !logger_startup_buffer
+// +build !logger_startup_buffer
+
+package go_logger
+
+// the default logger writes records from package initialization immediately
+const defaultStartupBufferSize = 0
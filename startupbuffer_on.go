@@ -0,0 +1,8 @@
+//go:build logger_startup_buffer
+// +build logger_startup_buffer
+
+package go_logger
+
+// the default logger buffers records until Ready or SetDefault, selected by build tag
+//	go build -tags logger_startup_buffer
+const defaultStartupBufferSize = 1000
@@ -0,0 +1,79 @@
+package go_logger
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestLogger_SetStartupBuffer(t *testing.T) {
+
+	logger := NewLogger()
+	if logger.SetStartupBuffer(0) == nil {
+		t.Error("startup buffer size 0 must be rejected")
+	}
+	if logger.Ready() == nil {
+		t.Error("ready without startup buffer must return error")
+	}
+	if err := logger.SetStartupBuffer(2); err != nil {
+		t.Fatal(err.Error())
+	}
+	if logger.SetStartupBuffer(2) == nil {
+		t.Error("startup buffer set twice must return error")
+	}
+
+	logger.Info("logger startup 1")
+	logger.Info("logger startup 2")
+	logger.Info("logger startup 3")
+
+	logger.Detach("console")
+	logger.Attach("console", LoggerLevelDebug, &ConsoleConfig{
+		Format: "[%level_string%] %body%",
+	})
+	buffer := &bytes.Buffer{}
+	logger.outputList()[0].LoggerAbstract.(*AdapterConsole).write.writer = buffer
+	if buffer.Len() != 0 {
+		t.Error("startup records must be buffered until ready: " + buffer.String())
+	}
+
+	if err := logger.Ready(); err != nil {
+		t.Fatal(err.Error())
+	}
+	logger.Info("logger startup 4")
+
+	expected := "[Warning] logger: startup buffer full, 1 records dropped\n" +
+		"[Info] logger startup 2\n" +
+		"[Info] logger startup 3\n" +
+		"[Info] logger startup 4\n"
+	if buffer.String() != expected {
+		t.Error("startup buffer output error: " + buffer.String())
+	}
+	if err := logger.SetStartupBuffer(2); err != nil {
+		t.Error("startup buffer must be settable again after ready: " + err.Error())
+	}
+}
+
+func TestSetDefault_StartupBuffer(t *testing.T) {
+
+	previous := Default()
+	defer SetDefault(previous)
+
+	early := NewLogger()
+	early.SetStartupBuffer(10)
+	SetDefault(early)
+	Info("logger startup early")
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("console", LoggerLevelDebug, &ConsoleConfig{
+		Format: "[%level_string%] %body%",
+	})
+	buffer := &bytes.Buffer{}
+	logger.outputList()[0].LoggerAbstract.(*AdapterConsole).write.writer = buffer
+	SetDefault(logger)
+	Info("logger startup configured")
+
+	expected := "[Info] logger startup early\n[Info] logger startup configured\n"
+	if buffer.String() != expected {
+		t.Error("startup records must be handed over by SetDefault: " + buffer.String())
+	}
+}
@@ -0,0 +1,103 @@
+package go_logger
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// logger pipeline stats
+type loggerStats struct {
+	levels     [LoggerLevelDebug + 1]int64 // written messages per level
+	unknown    int64                       // written messages of illegal level
+	dropped    int64                       // dropped messages
+	stale      int64                       // stale messages in async queue
+	overflowed int64                       // async enqueue timeout messages
+	start      time.Time                   // logger start time
+
+	deprecations sync.Map // feature -> *int64 uses of DeprecationWarn
+}
+
+func newLoggerStats() *loggerStats {
+	return &loggerStats{
+		start: time.Now(),
+	}
+}
+
+//count message of level
+func (stats *loggerStats) count(level int) {
+	if level < 0 || level >= len(stats.levels) {
+		atomic.AddInt64(&stats.unknown, 1)
+		return
+	}
+	atomic.AddInt64(&stats.levels[level], 1)
+}
+
+//count dropped message
+func (stats *loggerStats) drop() {
+	atomic.AddInt64(&stats.dropped, 1)
+}
+
+//summary fields of stats and outputs
+func (logger *Logger) statsFields() map[string]interface{} {
+	stats := logger.stats
+	var total int64
+	levels := map[string]int64{}
+	for level := range stats.levels {
+		count := atomic.LoadInt64(&stats.levels[level])
+		total += count
+		levels[strings.ToLower(levelStringMapping[level])] = count
+	}
+	unknown := atomic.LoadInt64(&stats.unknown)
+	if unknown > 0 {
+		total += unknown
+		levels["unknown"] = unknown
+	}
+
+	adapterErrors := map[string]int64{}
+	for _, output := range logger.outputList() {
+		adapterErrors[output.Name] = atomic.LoadInt64(&output.errors)
+	}
+
+	fields := map[string]interface{}{
+		"uptime":         time.Since(stats.start).String(),
+		"total":          total,
+		"levels":         levels,
+		"dropped":        atomic.LoadInt64(&stats.dropped),
+		"stale":          atomic.LoadInt64(&stats.stale),
+		"overflowed":     atomic.LoadInt64(&stats.overflowed),
+		"adapter_errors": adapterErrors,
+	}
+	if deprecations := stats.deprecationCounts(); deprecations != nil {
+		fields["deprecations"] = deprecations
+	}
+	return fields
+}
+
+//write a shutdown summary record at info level with uptime, totals per level,
+//dropped and stale counts and adapter error counts, then drain the async queue,
+//flush all outputs and stop the async worker and runtime metrics; later messages are written synchronously
+//Close is idempotent, only the first call writes the summary
+//return : AdapterErrors of outputs failed to flush
+func (logger *Logger) Close() error {
+	if !atomic.CompareAndSwapInt32(&logger.closed, 0, 1) {
+		return nil
+	}
+	logger.writeSummaries()
+	logger.writer(2, LoggerLevelInfo, "logger: shutdown", logger.statsFields())
+
+	logger.lock.Lock()
+	defer logger.lock.Unlock()
+	logger.stopRuntimeMetrics()
+	logger.asyncLock.Lock()
+	defer logger.asyncLock.Unlock()
+
+	defer logger.stopOutputWorkers()
+	if logger.synchronous {
+		return logger.flushOutputs()
+	}
+	err := logger.stopAsyncWrite()
+	logger.synchronous = true
+	return err
+}
@@ -0,0 +1,37 @@
+package go_logger
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestLogger_Close(t *testing.T) {
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("console", LoggerLevelDebug, &ConsoleConfig{
+		JsonFormat: true,
+	})
+	buffer := &bytes.Buffer{}
+	logger.outputList()[0].LoggerAbstract.(*AdapterConsole).write.writer = buffer
+	logger.SetRouteRules([]RouteRule{{BodyRegexp: "^drop", Action: ROUTE_ACTION_DROP}})
+
+	logger.Info("logger close test")
+	logger.Error("logger close test")
+	logger.Error("drop logger close test")
+	buffer.Reset()
+
+	err := logger.Close()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	output := buffer.String()
+	if !strings.Contains(output, `"body":"logger: shutdown"`) ||
+		!strings.Contains(output, `"total":3`) ||
+		!strings.Contains(output, `"dropped":1`) ||
+		!strings.Contains(output, `"error":2`) ||
+		!strings.Contains(output, `"adapter_errors":{"console":0}`) {
+		t.Error("logger shutdown summary error: " + output)
+	}
+}
@@ -0,0 +1,51 @@
+package go_logger
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"sync"
+)
+
+// io.Writer writing every line as a message at a level
+type levelWriter struct {
+	lock   sync.Mutex
+	logger *Logger
+	level  int
+	buffer []byte // incomplete line
+}
+
+//new io.Writer writing every line as a message at level, for libraries accepting an io.Writer
+//an incomplete line is buffered until its newline
+//params : level int
+//return : io.Writer
+func (logger *Logger) LevelWriter(level int) io.Writer {
+	return &levelWriter{logger: logger, level: level}
+}
+
+//new standard library logger writing at level, e.g. http.Server.ErrorLog
+//params : level int
+//return : *log.Logger
+func (logger *Logger) StdLogger(level int) *log.Logger {
+	return log.New(logger.LevelWriter(level), "", 0)
+}
+
+func (lw *levelWriter) Write(p []byte) (int, error) {
+	lw.lock.Lock()
+	defer lw.lock.Unlock()
+
+	lw.buffer = append(lw.buffer, p...)
+	for {
+		i := bytes.IndexByte(lw.buffer, '\n')
+		if i == -1 {
+			break
+		}
+		line := bytes.TrimSuffix(lw.buffer[:i], []byte{'\r'})
+		lw.buffer = lw.buffer[i+1:]
+		lw.logger.writer(2, lw.level, string(line), nil)
+	}
+	if len(lw.buffer) == 0 {
+		lw.buffer = nil
+	}
+	return len(p), nil
+}
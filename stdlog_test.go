@@ -0,0 +1,49 @@
+package go_logger
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestLogger_StdLogger(t *testing.T) {
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("console", LoggerLevelDebug, &ConsoleConfig{
+		Format: "%level_string% %body%",
+	})
+	buffer := &bytes.Buffer{}
+	logger.outputList()[0].LoggerAbstract.(*AdapterConsole).write.writer = buffer
+
+	stdLogger := logger.StdLogger(LoggerLevelError)
+	stdLogger.Printf("http: TLS handshake error from %s", "10.0.0.1")
+	stdLogger.Println("http: panic serving")
+	if buffer.String() != "Error http: TLS handshake error from 10.0.0.1\nError http: panic serving\n" {
+		t.Error("std logger error: " + buffer.String())
+	}
+}
+
+func TestLogger_LevelWriter(t *testing.T) {
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("console", LoggerLevelDebug, &ConsoleConfig{
+		Format: "%level_string% %body%",
+	})
+	buffer := &bytes.Buffer{}
+	logger.outputList()[0].LoggerAbstract.(*AdapterConsole).write.writer = buffer
+
+	writer := logger.LevelWriter(LoggerLevelWarning)
+	fmt.Fprint(writer, "line 1\r\nline")
+	if buffer.String() != "Warning line 1\n" {
+		t.Error("level writer must buffer incomplete line: " + buffer.String())
+	}
+	n, err := fmt.Fprint(writer, " 2\nline 3\n")
+	if n != 10 || err != nil {
+		t.Errorf("level writer write result error: %d, %v", n, err)
+	}
+	if buffer.String() != "Warning line 1\nWarning line 2\nWarning line 3\n" {
+		t.Error("level writer lines error: " + buffer.String())
+	}
+}
@@ -0,0 +1,50 @@
+package go_logger
+
+import (
+	"fmt"
+	"os"
+	"sync/atomic"
+)
+
+const (
+	// production: misuse is silently tolerated
+	LoggerStrictOff = iota
+	// misuse is reported to stderr and returned as error by Writer
+	LoggerStrictError
+	// misuse panics, recommended in tests
+	LoggerStrictPanic
+)
+
+var loggerMessageFormatTokens = map[string]bool{
+	"%timestamp%":          true,
+	"%timestamp_format%":   true,
+	"%millisecond%":        true,
+	"%millisecond_format%": true,
+	"%level%":              true,
+	"%level_string%":       true,
+	"%body%":               true,
+	"%file%":               true,
+	"%line%":               true,
+	"%function%":           true,
+	"%name%":               true,
+	"%fields%":             true,
+}
+
+//set logger strict mode
+//params : mode LoggerStrictOff | LoggerStrictError | LoggerStrictPanic
+func (logger *Logger) SetStrictMode(mode int) {
+	atomic.StoreInt32(&logger.strictMode, int32(mode))
+}
+
+//report logging misuse by strict mode
+//return : nil if strict mode is off
+func (logger *Logger) misuse(err error) error {
+	switch atomic.LoadInt32(&logger.strictMode) {
+	case LoggerStrictError:
+		fmt.Fprintf(os.Stderr, "logger: strict mode misuse, error: %v\n", err)
+		return err
+	case LoggerStrictPanic:
+		panic(err)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package go_logger
+
+import (
+	"testing"
+)
+
+func TestLogger_SetStrictMode(t *testing.T) {
+
+	logger := NewLogger()
+	if logger.Writer(100, "logger illegal level test") != nil {
+		t.Error("strict mode off must tolerate illegal level")
+	}
+
+	logger.SetStrictMode(LoggerStrictError)
+	if logger.Writer(100, "logger illegal level test") == nil {
+		t.Error("strict mode error must return illegal level error")
+	}
+	if logger.writerw(LoggerLevelInfo, "logger odd key-values test", []interface{}{"key"}) == nil {
+		t.Error("strict mode error must return odd key-values error")
+	}
+	if logger.writerw(LoggerLevelInfo, "logger non-string key test", []interface{}{1, "value"}) == nil {
+		t.Error("strict mode error must return non-string key error")
+	}
+}
+
+func TestLogger_SetStrictModePanic(t *testing.T) {
+
+	logger := NewLogger()
+	logger.SetStrictMode(LoggerStrictPanic)
+
+	defer func() {
+		if recover() == nil {
+			t.Error("strict mode panic must panic")
+		}
+	}()
+	logger.Infow("logger odd key-values test", "key")
+}
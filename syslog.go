@@ -0,0 +1,287 @@
+//go:build !tinygo && !logger_tiny
+// +build !tinygo,!logger_tiny
+
+package go_logger
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const SYSLOG_ADAPTER_NAME = "syslog"
+
+// syslog facility codes
+var syslogFacilities = map[string]int{
+	"kern": 0, "user": 1, "mail": 2, "daemon": 3, "auth": 4, "syslog": 5, "lpr": 6, "news": 7,
+	"uucp": 8, "cron": 9, "authpriv": 10, "ftp": 11,
+	"local0": 16, "local1": 17, "local2": 18, "local3": 19,
+	"local4": 20, "local5": 21, "local6": 22, "local7": 23,
+}
+
+// adapter syslog, RFC 3164 or RFC 5424 messages over udp, tcp, unixgram or unix
+// logger levels are syslog severities, Emergency is 0 ... Debug is 7
+type AdapterSyslog struct {
+	lock     sync.Mutex
+	conn     net.Conn
+	config   *SyslogConfig
+	facility int
+	hostname string
+	tag      string
+	pid      string
+}
+
+// syslog config
+type SyslogConfig struct {
+
+	// network, udp, tcp, unixgram or unix, default udp
+	// tcp and unix messages are framed by octet counting (RFC 5424) or a trailing newline (RFC 3164)
+	Network string
+
+	// address, "127.0.0.1:514", or "/dev/log" for unixgram
+	Address string `config:"required"`
+
+	// facility, kern, user, mail, daemon, auth, syslog, lpr, news, uucp, cron, authpriv, ftp, local0 ~ local7
+	// default user
+	Facility string
+
+	// tag (APP-NAME of RFC 5424), default the program name
+	Tag string
+
+	// hostname, default os.Hostname()
+	Hostname string
+
+	// RFC 5424 messages, default RFC 3164
+	RFC5424 bool
+
+	// RFC 5424 structured data SD-ID of the message fields, e.g. "fields@32473"
+	// fields are params of the element instead of appended to the message, empty appends fields
+	StructuredDataId string
+
+	// message format, default "%body%", see ConsoleConfig Format
+	Format string
+}
+
+func (sc *SyslogConfig) Name() string {
+	return SYSLOG_ADAPTER_NAME
+}
+
+func NewAdapterSyslog() LoggerAbstract {
+	return &AdapterSyslog{
+		config: &SyslogConfig{},
+	}
+}
+
+func (adapterSyslog *AdapterSyslog) Init(syslogConfig Config) error {
+	if syslogConfig.Name() != SYSLOG_ADAPTER_NAME {
+		return errors.New("logger syslog adapter init error, config must SyslogConfig")
+	}
+
+	sc := syslogConfig.(*SyslogConfig)
+	adapterSyslog.config = sc
+
+	if sc.Network == "" {
+		sc.Network = "udp"
+	}
+	if sc.Network != "udp" && sc.Network != "tcp" && sc.Network != "unixgram" && sc.Network != "unix" {
+		return errors.New("config Network must be one of the 'udp', 'tcp', 'unixgram', 'unix'!")
+	}
+	if sc.Address == "" {
+		return errors.New("config Address cannot be empty!")
+	}
+	if sc.Facility == "" {
+		sc.Facility = "user"
+	}
+	facility, ok := syslogFacilities[sc.Facility]
+	if !ok {
+		return errors.New("config Facility " + sc.Facility + " is illegal!")
+	}
+	if sc.StructuredDataId != "" && !sc.RFC5424 {
+		return errors.New("config StructuredDataId needs RFC5424!")
+	}
+	if sc.Format == "" {
+		sc.Format = "%body%"
+	}
+	adapterSyslog.facility = facility
+
+	adapterSyslog.tag = sc.Tag
+	if adapterSyslog.tag == "" {
+		adapterSyslog.tag = filepath.Base(os.Args[0])
+	}
+	adapterSyslog.hostname = sc.Hostname
+	if adapterSyslog.hostname == "" {
+		adapterSyslog.hostname, _ = os.Hostname()
+	}
+	if adapterSyslog.hostname == "" {
+		adapterSyslog.hostname = "localhost"
+	}
+	adapterSyslog.pid = strconv.Itoa(os.Getpid())
+
+	adapterSyslog.lock.Lock()
+	defer adapterSyslog.lock.Unlock()
+	return adapterSyslog.connect()
+}
+
+//dial the syslog server, called with lock held
+func (adapterSyslog *AdapterSyslog) connect() error {
+	conn, err := net.DialTimeout(adapterSyslog.config.Network, adapterSyslog.config.Address, 5*time.Second)
+	if err != nil {
+		return err
+	}
+	adapterSyslog.conn = conn
+	return nil
+}
+
+func (adapterSyslog *AdapterSyslog) Write(loggerMsg *loggerMessage) error {
+	message := adapterSyslog.message(loggerMsg)
+
+	adapterSyslog.lock.Lock()
+	defer adapterSyslog.lock.Unlock()
+
+	// reconnect once, e.g. a restarted tcp server
+	if adapterSyslog.conn != nil {
+		if _, err := adapterSyslog.conn.Write(message); err == nil {
+			return nil
+		}
+		adapterSyslog.conn.Close()
+		adapterSyslog.conn = nil
+	}
+	if err := adapterSyslog.connect(); err != nil {
+		return err
+	}
+	_, err := adapterSyslog.conn.Write(message)
+	return err
+}
+
+//framed syslog message
+func (adapterSyslog *AdapterSyslog) message(loggerMsg *loggerMessage) []byte {
+	config := adapterSyslog.config
+	severity := loggerMsg.Level
+	if severity < LoggerLevelEmergency || severity > LoggerLevelDebug {
+		severity = LoggerLevelDebug
+	}
+	priority := "<" + strconv.Itoa(adapterSyslog.facility*8+severity) + ">"
+	timestamp := time.Unix(0, loggerMsg.Millisecond*int64(time.Millisecond))
+
+	var message string
+	if config.RFC5424 {
+		msgId := "-"
+		if loggerMsg.Name != "" {
+			msgId = syslogName(loggerMsg.Name)
+		}
+		structuredData := "-"
+		body := loggerMsg
+		if config.StructuredDataId != "" {
+			if len(loggerMsg.Fields) > 0 {
+				structuredData = syslogStructuredData(config.StructuredDataId, loggerMsg.Fields)
+			}
+			bodyMsg := *loggerMsg
+			bodyMsg.Fields = nil
+			body = &bodyMsg
+		}
+		message = priority + "1 " + timestamp.Format("2006-01-02T15:04:05.000Z07:00") + " " +
+			syslogName(adapterSyslog.hostname) + " " + syslogName(adapterSyslog.tag) + " " + adapterSyslog.pid + " " +
+			msgId + " " + structuredData + " " + loggerMessageFormat(config.Format, body)
+	} else {
+		message = priority + timestamp.Format(time.Stamp) + " " + adapterSyslog.hostname + " " +
+			adapterSyslog.tag + "[" + adapterSyslog.pid + "]: " + loggerMsg.formatText(config.Format)
+	}
+
+	if config.Network == "tcp" || config.Network == "unix" {
+		if config.RFC5424 {
+			return []byte(strconv.Itoa(len(message)) + " " + message)
+		}
+		return []byte(strings.Replace(message, "\n", " ", -1) + "\n")
+	}
+	return []byte(message)
+}
+
+//RFC 5424 header name, printable ASCII without spaces, at most 48 characters
+func syslogName(name string) string {
+	b := []byte(name)
+	for i, c := range b {
+		if c < 33 || c > 126 {
+			b[i] = '_'
+		}
+	}
+	if len(b) > 48 {
+		b = b[:48]
+	}
+	return string(b)
+}
+
+//RFC 5424 structured data element of fields, params are sorted by name
+func syslogStructuredData(id string, fields map[string]interface{}) string {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	b.WriteString("[" + syslogParamName(id))
+	for _, key := range keys {
+		value, ok := formatValue(fields[key])
+		if !ok {
+			value = fmt.Sprint(fields[key])
+		}
+		value = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`).Replace(value)
+		b.WriteString(" " + syslogParamName(key) + `="` + value + `"`)
+	}
+	b.WriteString("]")
+	return b.String()
+}
+
+//RFC 5424 SD-NAME, printable ASCII without '=', ' ', ']' and '"', at most 32 characters
+func syslogParamName(name string) string {
+	b := []byte(syslogName(name))
+	for i, c := range b {
+		if c == '=' || c == ']' || c == '"' {
+			b[i] = '_'
+		}
+	}
+	if len(b) > 32 {
+		b = b[:32]
+	}
+	if len(b) == 0 {
+		return "_"
+	}
+	return string(b)
+}
+
+func (adapterSyslog *AdapterSyslog) Flush() {
+
+}
+
+//close the connection, the adapter must not be written after Close
+func (adapterSyslog *AdapterSyslog) Close() error {
+	adapterSyslog.lock.Lock()
+	defer adapterSyslog.lock.Unlock()
+
+	if adapterSyslog.conn == nil {
+		return nil
+	}
+	err := adapterSyslog.conn.Close()
+	adapterSyslog.conn = nil
+	return err
+}
+
+func (adapterSyslog *AdapterSyslog) Name() string {
+	return SYSLOG_ADAPTER_NAME
+}
+
+//new empty config of the adapter, describes the config fields
+func (adapterSyslog *AdapterSyslog) NewConfig() Config {
+	return &SyslogConfig{}
+}
+
+func init() {
+	Register(SYSLOG_ADAPTER_NAME, NewAdapterSyslog)
+}
@@ -0,0 +1,132 @@
+//go:build !tinygo && !logger_tiny
+// +build !tinygo,!logger_tiny
+
+package go_logger
+
+import (
+	"bufio"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAdapterSyslog_Init(t *testing.T) {
+
+	configs := []*SyslogConfig{
+		{},
+		{Address: "127.0.0.1:514", Network: "http"},
+		{Address: "127.0.0.1:514", Facility: "local9"},
+		{Address: "127.0.0.1:514", StructuredDataId: "fields@32473"},
+	}
+	for _, config := range configs {
+		if NewAdapterSyslog().Init(config) == nil {
+			t.Errorf("syslog adapter config %+v must be error", config)
+		}
+	}
+}
+
+func TestAdapterSyslog_WriteRFC3164(t *testing.T) {
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer conn.Close()
+
+	syslogAdapter := NewAdapterSyslog()
+	err = syslogAdapter.Init(&SyslogConfig{
+		Address:  conn.LocalAddr().String(),
+		Facility: "local0",
+		Tag:      "app",
+		Hostname: "host",
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer syslogAdapter.(*AdapterSyslog).Close()
+
+	now := time.Now()
+	err = syslogAdapter.Write(&loggerMessage{
+		Millisecond: now.UnixNano() / 1e6,
+		Level:       LoggerLevelError,
+		Body:        "logger syslog",
+		Fields:      map[string]interface{}{"user": "u1"},
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	buffer := make([]byte, 1024)
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	n, _, err := conn.ReadFrom(buffer)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	// local0 (16) * 8 + error (3)
+	expected := "<131>" + now.Format(time.Stamp) + " host app[" + strconv.Itoa(os.Getpid()) + "]: logger syslog user=u1"
+	if string(buffer[:n]) != expected {
+		t.Errorf("syslog adapter RFC 3164 message error: %q", string(buffer[:n]))
+	}
+}
+
+func TestAdapterSyslog_WriteRFC5424(t *testing.T) {
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer listener.Close()
+
+	syslogAdapter := NewAdapterSyslog()
+	err = syslogAdapter.Init(&SyslogConfig{
+		Network:          "tcp",
+		Address:          listener.Addr().String(),
+		Tag:              "app",
+		Hostname:         "host",
+		RFC5424:          true,
+		StructuredDataId: "fields@32473",
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer syslogAdapter.(*AdapterSyslog).Close()
+
+	server, err := listener.Accept()
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer server.Close()
+
+	now := time.Now()
+	err = syslogAdapter.Write(&loggerMessage{
+		Millisecond: now.UnixNano() / 1e6,
+		Level:       LoggerLevelInfo,
+		Body:        "logger syslog",
+		Name:        "payments",
+		Fields:      map[string]interface{}{"user": "u1", "note": `a "b"]`},
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+
+	server.SetReadDeadline(time.Now().Add(5 * time.Second))
+	reader := bufio.NewReader(server)
+	length, err := reader.ReadString(' ')
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	size, _ := strconv.Atoi(strings.TrimSpace(length))
+	message := make([]byte, size)
+	if _, err := reader.Read(message); err != nil {
+		t.Fatal(err.Error())
+	}
+	// user (1) * 8 + info (6)
+	expected := "<14>1 " + time.Unix(0, now.UnixNano()/1e6*1e6).Format("2006-01-02T15:04:05.000Z07:00") +
+		" host app " + strconv.Itoa(os.Getpid()) + ` payments [fields@32473 note="a \"b\"\]" user="u1"] logger syslog`
+	if string(message) != expected {
+		t.Errorf("syslog adapter RFC 5424 message error: %q", string(message))
+	}
+}
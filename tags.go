@@ -0,0 +1,64 @@
+package go_logger
+
+//set static tags of the logger, merged into the fields of every record of every output
+//e.g. {"env": "prod", "region": "eu-west-1"}, call sites do not have to add environment metadata
+//tags of an output and fields of the call site override logger tags of the same key
+//params : tags map[string]string, nil to remove
+func (logger *Logger) SetTags(tags map[string]string) {
+	logger.lock.Lock()
+	defer logger.lock.Unlock()
+
+	logger.tags.Store(copyTags(tags))
+}
+
+//attach a logger adapter with static tags merged into every record of the output
+//params : adapterName string, level int, config Config, tags map[string]string
+//return : error
+func (logger *Logger) AttachWithTags(adapterName string, level int, config Config, tags map[string]string) error {
+	logger.lock.Lock()
+	defer logger.lock.Unlock()
+
+	return logger.attachTagged(adapterName, level, config, copyTags(tags))
+}
+
+//set static tags of an attached output, merged into every record of the output
+//fields of the call site override tags of the same key
+//params : adapterName string, tags map[string]string, nil to remove
+//return : error
+func (logger *Logger) SetAdapterTags(adapterName string, tags map[string]string) error {
+	logger.lock.Lock()
+	defer logger.lock.Unlock()
+
+	outputTags := copyTags(tags)
+	return logger.updateOutput(adapterName, func(output *outputLogger) error {
+		output.tags = outputTags
+		return nil
+	})
+}
+
+//copy of tags, nil if empty
+func copyTags(tags map[string]string) map[string]string {
+	if len(tags) == 0 {
+		return nil
+	}
+	copied := make(map[string]string, len(tags))
+	for key, value := range tags {
+		copied[key] = value
+	}
+	return copied
+}
+
+//fields with logger tags and output tags, output tags override logger tags, fields override both
+func mergeTags(loggerTags map[string]string, outputTags map[string]string, fields map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(loggerTags)+len(outputTags)+len(fields))
+	for key, value := range loggerTags {
+		merged[key] = value
+	}
+	for key, value := range outputTags {
+		merged[key] = value
+	}
+	for key, value := range fields {
+		merged[key] = value
+	}
+	return merged
+}
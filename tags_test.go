@@ -0,0 +1,63 @@
+package go_logger
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLogger_SetTags(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "go_logger")
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "tags.log")
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.AttachWithTags("console", LoggerLevelDebug, &ConsoleConfig{
+		Format: "%body% %fields%",
+	}, map[string]string{"region": "eu-west-1", "service": "api"})
+	logger.Attach("file", LoggerLevelDebug, &FileConfig{
+		Filename: filename,
+		Format:   "%body% %fields%",
+	})
+	buffer := &bytes.Buffer{}
+	logger.outputList()[0].LoggerAbstract.(*AdapterConsole).write.writer = buffer
+	logger.SetTags(map[string]string{"env": "prod", "region": "us-east-1"})
+
+	logger.Info("logger tags")
+	logger.WithField("service", "worker").Info("logger tags field")
+	if err := logger.SetAdapterTags("api", nil); err == nil {
+		t.Error("tags of an adapter not attached must be error")
+	}
+	snapshot := logger.Config()
+	if snapshot.Tags["env"] != "prod" || snapshot.Adapters[0].Tags["service"] != "api" || snapshot.Adapters[1].Tags != nil {
+		t.Errorf("logger tags snapshot error: %+v", snapshot)
+	}
+	logger.SetAdapterTags("console", nil)
+	logger.SetTags(nil)
+	logger.Info("logger tags removed")
+	logger.Flush()
+
+	expected := "logger tags env=prod region=eu-west-1 service=api\n" +
+		"logger tags field env=prod region=eu-west-1 service=worker\n" +
+		"logger tags removed \n"
+	if buffer.String() != expected {
+		t.Error("logger tags error: " + buffer.String())
+	}
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	expected = "logger tags env=prod region=us-east-1\r\n" +
+		"logger tags field env=prod region=us-east-1 service=worker\r\n" +
+		"logger tags removed \r\n"
+	if string(data) != expected {
+		t.Error("logger tags of file output error: " + string(data))
+	}
+}
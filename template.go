@@ -0,0 +1,139 @@
+//go:build !tinygo && !logger_tiny
+// +build !tinygo,!logger_tiny
+
+package go_logger
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+)
+
+// max cached templates, the cache is reset when exceeded
+const templateCacheSize = 1000
+
+// functions of template formats, besides the text/template builtins
+//	upper, lower  change the case of a string, {{upper .LevelString}}
+//	json          JSON encoding of a value, {{json .Fields}}
+//	fields        fields as key=value pairs, as the %fields% token
+//	time          millisecond as a time layout, {{time .Millisecond "15:04:05.000"}}
+var templateFuncs = template.FuncMap{
+	"upper":  strings.ToUpper,
+	"lower":  strings.ToLower,
+	"json":   templateJson,
+	"fields": loggerFieldsFormat,
+	"time":   templateTime,
+}
+
+var (
+	templateLock  sync.RWMutex
+	templateCache = map[string]*templateFormat{}
+)
+
+// parsed template format
+type templateFormat struct {
+	template *template.Template
+	err      error
+}
+
+//register a function of template formats, call before attaching adapters using it
+//fn must be a function of 1 result, or 2 results of which the second is an error
+//params : name string, fn interface{}
+//return : error
+func RegisterTemplateFunc(name string, fn interface{}) error {
+	fnType := reflect.TypeOf(fn)
+	if fnType == nil || fnType.Kind() != reflect.Func {
+		return errors.New("logger: template func " + name + " must be a function!")
+	}
+	errorType := reflect.TypeOf((*error)(nil)).Elem()
+	if fnType.NumOut() != 1 && (fnType.NumOut() != 2 || fnType.Out(1) != errorType) {
+		return errors.New("logger: template func " + name + " must return 1 value, or a value and an error!")
+	}
+	if name == "" {
+		return errors.New("logger: template func name cannot be empty!")
+	}
+
+	templateLock.Lock()
+	defer templateLock.Unlock()
+
+	funcs := make(template.FuncMap, len(templateFuncs)+1)
+	for funcName, templateFunc := range templateFuncs {
+		funcs[funcName] = templateFunc
+	}
+	funcs[name] = fn
+	templateFuncs = funcs
+	// templates are parsed again with the new function
+	templateCache = map[string]*templateFormat{}
+	return nil
+}
+
+//is a text/template format, containing "{{"
+func isTemplateFormat(format string) bool {
+	return strings.Contains(format, "{{")
+}
+
+//parsed template of format, cached
+func parseTemplateFormat(format string) *templateFormat {
+	templateLock.RLock()
+	parsed, ok := templateCache[format]
+	templateLock.RUnlock()
+	if ok {
+		return parsed
+	}
+
+	templateLock.Lock()
+	defer templateLock.Unlock()
+
+	if parsed, ok := templateCache[format]; ok {
+		return parsed
+	}
+	parsed = &templateFormat{}
+	parsed.template, parsed.err = template.New("format").Funcs(templateFuncs).Option("missingkey=zero").Parse(format)
+	if len(templateCache) >= templateCacheSize {
+		templateCache = map[string]*templateFormat{}
+	}
+	templateCache[format] = parsed
+	return parsed
+}
+
+//message formatted by a text/template format, the record is the template data
+//a template error writes the message by the default format with a template_error field
+//return : text, false if format is not a template format
+func loggerMessageTemplate(format string, loggerMsg *loggerMessage) (string, bool) {
+	if !isTemplateFormat(format) {
+		return "", false
+	}
+	parsed := parseTemplateFormat(format)
+	err := parsed.err
+	if err == nil {
+		var b strings.Builder
+		if err = parsed.template.Execute(&b, loggerMsg); err == nil {
+			return b.String(), true
+		}
+	}
+	message := loggerMessageFormat(defaultLoggerMessageFormat, loggerMsg)
+	return message + " " + loggerFieldsFormat(map[string]interface{}{"template_error": err.Error()}), true
+}
+
+//check a text/template format
+func checkTemplateFormat(format string) error {
+	if err := parseTemplateFormat(format).err; err != nil {
+		return errors.New("logger: config Format template error: " + err.Error())
+	}
+	return nil
+}
+
+//JSON encoding of a value
+func templateJson(value interface{}) (string, error) {
+	data, err := json.Marshal(value)
+	return string(data), err
+}
+
+//unix millisecond formatted by a time layout
+func templateTime(millisecond int64, layout string) string {
+	return time.Unix(0, millisecond*int64(time.Millisecond)).Format(layout)
+}
@@ -0,0 +1,80 @@
+//go:build !tinygo && !logger_tiny
+// +build !tinygo,!logger_tiny
+
+package go_logger
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestLogger_TemplateFormat(t *testing.T) {
+
+	err := RegisterTemplateFunc("mask", func(s string) string {
+		if len(s) <= 2 {
+			return s
+		}
+		return s[:2] + strings.Repeat("*", len(s)-2)
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if RegisterTemplateFunc("illegal", "not a function") == nil {
+		t.Error("template func of a string must be error")
+	}
+	if RegisterTemplateFunc("illegal", func() {}) == nil {
+		t.Error("template func without results must be error")
+	}
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("console", LoggerLevelDebug, &ConsoleConfig{
+		Format: `[{{upper .LevelString}}] {{.Body}}{{if .Fields.user}} user={{mask .Fields.user}}{{end}}` +
+			`{{with .Name}} logger={{.}}{{end}}`,
+	})
+	buffer := &bytes.Buffer{}
+	logger.outputList()[0].LoggerAbstract.(*AdapterConsole).write.writer = buffer
+
+	logger.Info("logger template")
+	logger.Named("auth").WithField("user", "alice").Warning("logger template user")
+	expected := "[INFO] logger template\n" +
+		"[WARNING] logger template user user=al*** logger=auth\n"
+	if buffer.String() != expected {
+		t.Error("logger template format error: " + buffer.String())
+	}
+}
+
+func TestLoggerMessageTemplate(t *testing.T) {
+
+	loggerMsg := &loggerMessage{
+		Millisecond: 1500000000123,
+		LevelString: "Error",
+		Body:        "logger template",
+		Fields:      map[string]interface{}{"id": 1, "path": "/a"},
+	}
+	formats := map[string]string{
+		`{{json .Fields}}`:                           `{"id":1,"path":"/a"}`,
+		`{{.Body}} {{fields .Fields}}`:               "logger template id=1 path=/a",
+		`{{lower .LevelString}} {{.Fields.missing}}`: "error <no value>",
+		`%body% {{.Body}}`:                           "%body% logger template",
+	}
+	for format, expected := range formats {
+		if text := loggerMessageFormat(format, loggerMsg); text != expected {
+			t.Errorf("template format %s error: %s", format, text)
+		}
+	}
+	if text := loggerMessageFormat(`{{time .Millisecond "2006"}}`, loggerMsg); text != "2017" {
+		t.Errorf("template time error: %s", text)
+	}
+	if text := loggerMessageFormat(`{{.Missing}}`, loggerMsg); !strings.Contains(text, "template_error=") {
+		t.Errorf("template execute error: %s", text)
+	}
+
+	if err := checkConfigFormat(&ConsoleConfig{Format: `{{.Body`}); err == nil {
+		t.Error("template format parse error must be error")
+	}
+	if err := checkConfigFormat(&ConsoleConfig{Format: `{{printf "%5s" .Body}}`}); err != nil {
+		t.Error(err.Error())
+	}
+}
@@ -0,0 +1,44 @@
+package go_logger
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+//set max age of messages waiting in the async queue
+//stale messages are written to the dead letter output only, or dropped if it is empty
+//params : ttl time.Duration, 0 is unlimited; deadLetterAdapter string, an attached adapter name or ""
+//return : error
+func (logger *Logger) SetQueueTTL(ttl time.Duration, deadLetterAdapter string) error {
+	if ttl < 0 {
+		return errors.New("logger: queue ttl cannot be negative!")
+	}
+	logger.lock.Lock()
+	defer logger.lock.Unlock()
+
+	if deadLetterAdapter != "" && logger.output(deadLetterAdapter) == nil {
+		return errors.New("logger: adapter " + deadLetterAdapter + " is not attached!")
+	}
+	logger.deadLetterAdapter.Store(deadLetterAdapter)
+	atomic.StoreInt64(&logger.queueTTL, int64(ttl))
+	return nil
+}
+
+//write message read from the async queue, stale message is diverted or dropped
+func (logger *Logger) writeQueued(loggerMsg *loggerMessage) {
+	ttl := time.Duration(atomic.LoadInt64(&logger.queueTTL))
+	if ttl == 0 || time.Since(time.Unix(0, loggerMsg.Millisecond*1e6)) <= ttl {
+		logger.writeToOutputs(loggerMsg)
+		return
+	}
+	atomic.AddInt64(&logger.stats.stale, 1)
+	deadLetterAdapter := logger.deadLetterAdapter.Load().(string)
+	if deadLetterAdapter == "" {
+		logger.notifyDrop(loggerMsg, DROP_REASON_STALE)
+		return
+	}
+	staleMsg := *loggerMsg
+	staleMsg.routeAdapters = map[string]bool{deadLetterAdapter: true}
+	logger.writeToOutputs(&staleMsg)
+}
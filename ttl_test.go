@@ -0,0 +1,38 @@
+package go_logger
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestLogger_SetQueueTTL(t *testing.T) {
+
+	logger := NewLogger()
+	logger.Detach("console")
+	logger.Attach("console", LoggerLevelDebug, &ConsoleConfig{
+		Format: "%body%",
+	})
+	buffer := &bytes.Buffer{}
+	logger.outputList()[0].LoggerAbstract.(*AdapterConsole).write.writer = buffer
+
+	if logger.SetQueueTTL(time.Second, "file") == nil {
+		t.Error("queue ttl with not attached dead letter adapter must be error")
+	}
+	logger.SetQueueTTL(time.Second, "")
+
+	freshMsg := &loggerMessage{Body: "fresh", Millisecond: time.Now().UnixNano() / 1e6}
+	staleMsg := &loggerMessage{Body: "stale", Millisecond: time.Now().Add(-time.Minute).UnixNano() / 1e6}
+	logger.writeQueued(freshMsg)
+	logger.writeQueued(staleMsg)
+	if buffer.String() != "fresh\n" || logger.stats.stale != 1 {
+		t.Error("logger queue ttl drop error: " + buffer.String())
+	}
+
+	buffer.Reset()
+	logger.SetQueueTTL(time.Second, "console")
+	logger.writeQueued(staleMsg)
+	if buffer.String() != "stale\n" {
+		t.Error("logger queue ttl dead letter error: " + buffer.String())
+	}
+}
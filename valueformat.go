@@ -0,0 +1,57 @@
+package go_logger
+
+import (
+	"math"
+	"strconv"
+	"time"
+)
+
+// render a field value in text output, JSON output keeps the value
+type ValueFormatter func(value interface{}) string
+
+// byte count field, humanized as "3.4 MiB" in text output, a number in JSON
+type Bytes int64
+
+// ratio field (0.87), humanized as "87%" in text output, a number in JSON
+type Percent float64
+
+//duration rounded to one decimal of its unit, 1.234567s is 1.2s
+func humanizeDuration(d time.Duration) string {
+	abs := d
+	if abs < 0 {
+		abs = -abs
+	}
+	switch {
+	case abs >= time.Minute:
+		return d.Round(time.Second).String()
+	case abs >= time.Second:
+		return d.Round(100 * time.Millisecond).String()
+	case abs >= time.Millisecond:
+		return d.Round(100 * time.Microsecond).String()
+	case abs >= time.Microsecond:
+		return d.Round(100 * time.Nanosecond).String()
+	}
+	return d.String()
+}
+
+func (b Bytes) String() string {
+	if b < 1024 && b > -1024 {
+		return strconv.FormatInt(int64(b), 10) + " B"
+	}
+	units := []string{"KiB", "MiB", "GiB", "TiB", "PiB", "EiB"}
+	value := math.Abs(float64(b)) / 1024
+	i := 0
+	for value >= 1024 && i < len(units)-1 {
+		value /= 1024
+		i++
+	}
+	text := strconv.FormatFloat(value, 'f', 1, 64) + " " + units[i]
+	if b < 0 {
+		return "-" + text
+	}
+	return text
+}
+
+func (p Percent) String() string {
+	return strconv.FormatFloat(math.Round(float64(p)*1000)/10, 'f', -1, 64) + "%"
+}
@@ -0,0 +1,45 @@
+package go_logger
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestValueFormatters(t *testing.T) {
+
+	fields := map[string]interface{}{
+		"duration": 1234567 * time.Microsecond,
+		"size":     Bytes(3565158),
+		"small":    Bytes(512),
+		"cpu":      Percent(0.87),
+	}
+	if text := loggerFieldsFormat(fields); text != `cpu=87% duration=1.2s size="3.4 MiB" small="512 B"` {
+		t.Error("humanized fields text error: " + text)
+	}
+	data, _ := json.Marshal(fields)
+	if string(data) != `{"cpu":0.87,"duration":1234567000,"size":3565158,"small":512}` {
+		t.Error("humanized fields must be numeric in JSON: " + string(data))
+	}
+}
+
+func TestHumanize(t *testing.T) {
+
+	durations := map[time.Duration]string{
+		90*time.Minute + 1234*time.Millisecond: "1h30m1s",
+		-1234 * time.Millisecond:               "-1.2s",
+		1234567 * time.Nanosecond:              "1.2ms",
+		850 * time.Nanosecond:                  "850ns",
+	}
+	for d, expected := range durations {
+		if text := humanizeDuration(d); text != expected {
+			t.Errorf("humanize duration %d error: %s", d, text)
+		}
+	}
+	if text := Bytes(-2048).String(); text != "-2.0 KiB" {
+		t.Error("humanize negative bytes error: " + text)
+	}
+	if text := Percent(0.8765).String(); text != "87.7%" {
+		t.Error("humanize percent error: " + text)
+	}
+}
@@ -0,0 +1,76 @@
+package go_logger
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"time"
+)
+
+// body of the synthetic record written by VerifyAttach
+const verifyAttachBody = "logger attach verification"
+
+//verify an adapter config without attaching it, for deploy-time smoke tests
+//the adapter is initialized, writes a synthetic record at level with field "logger_verify" true,
+//is flushed and closed if it implements io.Closer
+//errors are returned instead of exiting the process like Attach
+//params : adapterName string, level int, config Config
+//return : error
+func VerifyAttach(adapterName string, level int, config Config) (err error) {
+	logFun, ok := adapters[adapterName]
+	if !ok {
+		return errors.New("logger: adapter " + adapterName + " is not registered!")
+	}
+	levelString, ok := levelStringMapping[level]
+	if !ok {
+		return fmt.Errorf("logger: level %d is illegal!", level)
+	}
+	if err := checkConfigFormat(config); err != nil {
+		return err
+	}
+
+	adapterLog := logFun()
+	defer func() {
+		if e := recover(); e != nil {
+			err = fmt.Errorf("logger: adapter %s verify panic: %v", adapterName, e)
+		}
+	}()
+	if err := adapterLog.Init(config); err != nil {
+		return errors.New("logger: adapter " + adapterName + " init failed, error: " + err.Error())
+	}
+	if closer, ok := adapterLog.(io.Closer); ok {
+		defer func() {
+			if closeErr := closer.Close(); closeErr != nil && err == nil {
+				err = errors.New("logger: adapter " + adapterName + " close failed, error: " + closeErr.Error())
+			}
+		}()
+	}
+
+	now := time.Now()
+	filePath, line, funcName := loggerCaller(1)
+	_, filename := path.Split(filePath)
+	loggerMsg := &loggerMessage{
+		Timestamp:         now.Unix(),
+		TimestampFormat:   now.Format("2006-01-02 15:04:05"),
+		Millisecond:       now.UnixNano() / 1e6,
+		MillisecondFormat: now.Format("2006-01-02 15:04:05.999"),
+		Level:             level,
+		LevelString:       levelString,
+		Body:              verifyAttachBody,
+		File:              filename,
+		Line:              line,
+		Function:          funcName,
+		filePath:          filePath,
+		Fields:            map[string]interface{}{"logger_verify": true},
+		SchemaVersion:     LoggerMessageSchemaVersion,
+	}
+	if err := adapterLog.Write(loggerMsg); err != nil {
+		return errors.New("logger: adapter " + adapterName + " write failed, error: " + err.Error())
+	}
+	output := &outputLogger{outputState: &outputState{}, Name: adapterName, LoggerAbstract: adapterLog}
+	if err := output.flush(0); err != nil {
+		return errors.New("logger: adapter " + adapterName + " flush failed, error: " + err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package go_logger
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestVerifyAttach(t *testing.T) {
+
+	filename := "./test_verify.log"
+	defer os.Remove(filename)
+	err := VerifyAttach("file", LoggerLevelInfo, &FileConfig{
+		Filename: filename,
+		Format:   "%level_string% %body% %fields%",
+	})
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err.Error())
+	}
+	if string(data) != "Info logger attach verification logger_verify=true\r\n" {
+		t.Errorf("verify attach record error: %q", string(data))
+	}
+}
+
+func TestVerifyAttachError(t *testing.T) {
+
+	if VerifyAttach("not_registered", LoggerLevelInfo, &ConsoleConfig{}) == nil {
+		t.Error("not registered adapter must be error")
+	}
+	if VerifyAttach("console", 100, &ConsoleConfig{}) == nil {
+		t.Error("illegal level must be error")
+	}
+	err := VerifyAttach("file", LoggerLevelInfo, &FileConfig{})
+	if err == nil || !strings.Contains(err.Error(), "init failed") {
+		t.Errorf("init error must be returned: %v", err)
+	}
+	if VerifyAttach("flush_error", LoggerLevelInfo, &countConfig{}) == nil {
+		t.Error("flush error must be returned")
+	}
+}
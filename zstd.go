@@ -0,0 +1,378 @@
+package go_logger
+
+import (
+	"encoding/binary"
+	"io"
+	"math/bits"
+)
+
+// zstd frame, RFC 8878
+const (
+	zstdMagic     = 0xFD2FB528
+	zstdWindowLog = 17
+	zstdBlockSize = 1 << zstdWindowLog // max block size, the window covers a block
+	zstdMinMatch  = 4
+	zstdHashLog   = 14
+)
+
+// zstd compressor, a small encoder of the zstd format without dependencies:
+// matches in a block are found by a hash table of 4 bytes, literals are raw
+// and sequences are coded by the predefined FSE distributions, no checksum
+type zstdCompressor struct{}
+
+func (zstdCompressor) Name() string {
+	return "zstd"
+}
+
+func (zstdCompressor) Extension() string {
+	return ".zst"
+}
+
+func (zstdCompressor) NewWriter(w io.Writer) (io.WriteCloser, error) {
+	return &zstdWriter{w: w}, nil
+}
+
+// zstd frame writer, data is buffered and encoded by blocks
+type zstdWriter struct {
+	w         io.Writer
+	block     []byte // pending data of the next block
+	header    bool   // frame header written
+	closed    bool
+	err       error
+	table     []int32 // hash of 4 bytes to the position + 1 in the block
+	literals  []byte
+	sequences []zstdSequence
+	out       []byte
+}
+
+// literals length, match length and offset of a match
+type zstdSequence struct {
+	literals uint32
+	match    uint32
+	offset   uint32
+}
+
+func (writer *zstdWriter) Write(p []byte) (int, error) {
+	if writer.err != nil {
+		return 0, writer.err
+	}
+	n := 0
+	for len(p) > 0 {
+		// a full block is written when more data follows, Close writes the last block
+		if len(writer.block) == zstdBlockSize {
+			if err := writer.writeBlock(false); err != nil {
+				return n, err
+			}
+		}
+		chunk := p
+		if room := zstdBlockSize - len(writer.block); len(chunk) > room {
+			chunk = chunk[:room]
+		}
+		writer.block = append(writer.block, chunk...)
+		p = p[len(chunk):]
+		n += len(chunk)
+	}
+	return n, nil
+}
+
+//write the last block, the frame is complete, w is not closed
+func (writer *zstdWriter) Close() error {
+	if writer.closed || writer.err != nil {
+		return writer.err
+	}
+	writer.closed = true
+	return writer.writeBlock(true)
+}
+
+//write the frame header if not written, then the pending data as a compressed block,
+//or a raw block if it is not smaller
+func (writer *zstdWriter) writeBlock(last bool) error {
+	out := writer.out[:0]
+	if !writer.header {
+		// no content size, single segment or dictionary, window descriptor of exponent windowLog - 10
+		out = append(out, 0x28, 0xb5, 0x2f, 0xfd, 0x00, (zstdWindowLog-10)<<3)
+		writer.header = true
+	}
+	headerAt := len(out)
+	out = append(out, 0, 0, 0)
+	out = writer.compress(out, writer.block)
+
+	blockHeader := uint32(0)
+	if last {
+		blockHeader = 1
+	}
+	if size := len(out) - headerAt - 3; size > 0 && size < len(writer.block) {
+		blockHeader |= 2<<1 | uint32(size)<<3
+	} else {
+		out = append(out[:headerAt+3], writer.block...)
+		blockHeader |= uint32(len(writer.block)) << 3
+	}
+	out[headerAt] = byte(blockHeader)
+	out[headerAt+1] = byte(blockHeader >> 8)
+	out[headerAt+2] = byte(blockHeader >> 16)
+
+	writer.out = out
+	writer.block = writer.block[:0]
+	if _, err := writer.w.Write(out); err != nil {
+		writer.err = err
+		return err
+	}
+	return nil
+}
+
+//append the compressed block content of src to out
+//return : out, unchanged if src has no match
+func (writer *zstdWriter) compress(out []byte, src []byte) []byte {
+	if writer.table == nil {
+		writer.table = make([]int32, 1<<zstdHashLog)
+	} else {
+		for i := range writer.table {
+			writer.table[i] = 0
+		}
+	}
+	literals := writer.literals[:0]
+	sequences := writer.sequences[:0]
+	anchor := 0
+	for i := 0; i+zstdMinMatch <= len(src); {
+		value := binary.LittleEndian.Uint32(src[i:])
+		hash := (value * 2654435761) >> (32 - zstdHashLog)
+		candidate := int(writer.table[hash]) - 1
+		writer.table[hash] = int32(i + 1)
+		if candidate < 0 || binary.LittleEndian.Uint32(src[candidate:]) != value {
+			i++
+			continue
+		}
+		length := zstdMinMatch
+		for i+length < len(src) && src[candidate+length] == src[i+length] {
+			length++
+		}
+		for i > anchor && candidate > 0 && src[i-1] == src[candidate-1] {
+			i--
+			candidate--
+			length++
+		}
+		literals = append(literals, src[anchor:i]...)
+		sequences = append(sequences, zstdSequence{literals: uint32(i - anchor), match: uint32(length), offset: uint32(i - candidate)})
+		i += length
+		anchor = i
+	}
+	literals = append(literals, src[anchor:]...)
+	writer.literals = literals
+	writer.sequences = sequences
+	if len(sequences) == 0 {
+		return out
+	}
+
+	// raw literals section
+	switch size := len(literals); {
+	case size < 1<<5:
+		out = append(out, byte(size<<3))
+	case size < 1<<12:
+		out = append(out, byte(1<<2|size<<4), byte(size>>4))
+	default:
+		out = append(out, byte(3<<2|size<<4), byte(size>>4), byte(size>>12))
+	}
+	out = append(out, literals...)
+
+	// sequences section, the predefined modes of literals lengths, offsets and match lengths
+	switch count := len(sequences); {
+	case count < 0x80:
+		out = append(out, byte(count))
+	case count < 0x7f00:
+		out = append(out, byte(count>>8+0x80), byte(count))
+	default:
+		out = append(out, 0xff, byte(count-0x7f00), byte((count-0x7f00)>>8))
+	}
+	out = append(out, 0)
+	return zstdEncodeSequences(out, sequences)
+}
+
+//append the sequences bitstream, written backward from the last sequence
+func zstdEncodeSequences(out []byte, sequences []zstdSequence) []byte {
+	stream := &zstdBitWriter{out: out}
+
+	last := sequences[len(sequences)-1]
+	llCode, llBits, llExtra := zstdLengthCode(zstdLiteralLengthCodes, last.literals)
+	mlCode, mlBits, mlExtra := zstdLengthCode(zstdMatchLengthCodes, last.match)
+	ofCode, ofExtra := zstdOffsetCode(last.offset)
+	llState := zstdLiteralLengthsFSE.encode[llCode][0]
+	mlState := zstdMatchLengthsFSE.encode[mlCode][0]
+	ofState := zstdOffsetsFSE.encode[ofCode][0]
+	stream.add(llExtra, llBits)
+	stream.add(mlExtra, mlBits)
+	stream.add(ofExtra, uint(ofCode))
+
+	for n := len(sequences) - 2; n >= 0; n-- {
+		sequence := sequences[n]
+		llCode, llBits, llExtra = zstdLengthCode(zstdLiteralLengthCodes, sequence.literals)
+		mlCode, mlBits, mlExtra = zstdLengthCode(zstdMatchLengthCodes, sequence.match)
+		ofCode, ofExtra = zstdOffsetCode(sequence.offset)
+		ofState = zstdOffsetsFSE.encodeSymbol(stream, ofState, ofCode)
+		mlState = zstdMatchLengthsFSE.encodeSymbol(stream, mlState, mlCode)
+		llState = zstdLiteralLengthsFSE.encodeSymbol(stream, llState, llCode)
+		stream.add(llExtra, llBits)
+		stream.add(mlExtra, mlBits)
+		stream.add(ofExtra, uint(ofCode))
+	}
+	stream.add(uint32(mlState), zstdMatchLengthsFSE.log)
+	stream.add(uint32(ofState), zstdOffsetsFSE.log)
+	stream.add(uint32(llState), zstdLiteralLengthsFSE.log)
+	return stream.close()
+}
+
+// bits written from the lowest bit of each byte, read backward by the decoder
+type zstdBitWriter struct {
+	out   []byte
+	value uint64
+	count uint
+}
+
+func (stream *zstdBitWriter) add(value uint32, count uint) {
+	stream.value |= uint64(value&(1<<count-1)) << stream.count
+	stream.count += count
+	for stream.count >= 8 {
+		stream.out = append(stream.out, byte(stream.value))
+		stream.value >>= 8
+		stream.count -= 8
+	}
+}
+
+//end the stream by a 1 bit, then pad the last byte
+func (stream *zstdBitWriter) close() []byte {
+	stream.add(1, 1)
+	if stream.count > 0 {
+		stream.out = append(stream.out, byte(stream.value))
+	}
+	return stream.out
+}
+
+// baseline and extra bits of a length code
+type zstdLengthBaseline struct {
+	baseline uint32
+	bits     uint
+}
+
+var (
+	zstdLiteralLengthCodes = zstdLengthCodes(0, 16, []zstdLengthBaseline{
+		{16, 1}, {18, 1}, {20, 1}, {22, 1}, {24, 2}, {28, 2}, {32, 3}, {40, 3}, {48, 4}, {64, 6},
+		{128, 7}, {256, 8}, {512, 9}, {1024, 10}, {2048, 11}, {4096, 12}, {8192, 13}, {16384, 14},
+		{32768, 15}, {65536, 16},
+	})
+	zstdMatchLengthCodes = zstdLengthCodes(3, 32, []zstdLengthBaseline{
+		{35, 1}, {37, 1}, {39, 1}, {41, 1}, {43, 2}, {47, 2}, {51, 3}, {59, 3}, {67, 4}, {83, 4},
+		{99, 5}, {131, 7}, {259, 8}, {515, 9}, {1027, 10}, {2051, 11}, {4099, 12}, {8195, 13},
+		{16387, 14}, {32771, 15}, {65539, 16},
+	})
+)
+
+//length codes: count codes of no extra bits from first, then the codes with extra bits
+func zstdLengthCodes(first uint32, count int, codes []zstdLengthBaseline) []zstdLengthBaseline {
+	baselines := make([]zstdLengthBaseline, 0, count+len(codes))
+	for i := 0; i < count; i++ {
+		baselines = append(baselines, zstdLengthBaseline{baseline: first + uint32(i)})
+	}
+	return append(baselines, codes...)
+}
+
+//code of a literals or match length
+//return : code, extra bits count, extra bits value
+func zstdLengthCode(codes []zstdLengthBaseline, length uint32) (uint8, uint, uint32) {
+	low, high := 0, len(codes)-1
+	for low < high {
+		middle := (low + high + 1) / 2
+		if codes[middle].baseline <= length {
+			low = middle
+		} else {
+			high = middle - 1
+		}
+	}
+	return uint8(low), codes[low].bits, length - codes[low].baseline
+}
+
+//code of an offset, offset values 1 to 3 are repeated offsets
+//return : code, the extra bits count, extra bits value
+func zstdOffsetCode(offset uint32) (uint8, uint32) {
+	value := offset + 3
+	code := uint8(bits.Len32(value) - 1)
+	return code, value - 1<<code
+}
+
+// FSE table of a predefined distribution
+type zstdFSE struct {
+	log    uint
+	states []zstdFSEState
+	encode [][]uint16 // symbol and the state following it to the state of the symbol
+}
+
+// decoding state, the next state is baseline + bits read
+type zstdFSEState struct {
+	symbol   uint8
+	bits     uint8
+	baseline uint16
+}
+
+var (
+	zstdLiteralLengthsFSE = newZstdFSE(6, []int16{
+		4, 3, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1,
+		2, 2, 2, 2, 2, 2, 2, 2, 2, 3, 2, 1, 1, 1, 1, 1,
+		-1, -1, -1, -1,
+	})
+	zstdMatchLengthsFSE = newZstdFSE(6, []int16{
+		1, 4, 3, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1,
+		1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+		1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, -1, -1,
+		-1, -1, -1, -1, -1,
+	})
+	zstdOffsetsFSE = newZstdFSE(5, []int16{
+		1, 1, 1, 1, 1, 1, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1,
+		1, 1, 1, 1, 1, 1, 1, 1, -1, -1, -1, -1, -1,
+	})
+)
+
+//build the FSE table of a normalized distribution, -1 is a "less than 1" probability
+func newZstdFSE(log uint, distribution []int16) *zstdFSE {
+	size := 1 << log
+	fse := &zstdFSE{log: log, states: make([]zstdFSEState, size), encode: make([][]uint16, len(distribution))}
+	next := make([]uint16, len(distribution))
+	high := size - 1
+	for symbol, probability := range distribution {
+		next[symbol] = uint16(probability)
+		if probability == -1 {
+			fse.states[high].symbol = uint8(symbol)
+			high--
+			next[symbol] = 1
+		}
+	}
+	position, step := 0, size>>1+size>>3+3
+	for symbol, probability := range distribution {
+		for i := 0; i < int(probability); i++ {
+			fse.states[position].symbol = uint8(symbol)
+			position = (position + step) & (size - 1)
+			for position > high {
+				position = (position + step) & (size - 1)
+			}
+		}
+	}
+	for symbol := range fse.encode {
+		fse.encode[symbol] = make([]uint16, size)
+	}
+	for i := range fse.states {
+		state := &fse.states[i]
+		n := next[state.symbol]
+		next[state.symbol]++
+		state.bits = uint8(log) - uint8(bits.Len16(n)-1)
+		state.baseline = uint16(int(n)<<state.bits - size)
+		for following := int(state.baseline); following < int(state.baseline)+1<<state.bits; following++ {
+			fse.encode[state.symbol][following] = uint16(i)
+		}
+	}
+	return fse
+}
+
+//write the bits from the state of symbol to state, the state following symbol
+//return : the state of symbol
+func (fse *zstdFSE) encodeSymbol(stream *zstdBitWriter, state uint16, symbol uint8) uint16 {
+	symbolState := fse.encode[symbol][state]
+	stream.add(uint32(state-fse.states[symbolState].baseline), uint(fse.states[symbolState].bits))
+	return symbolState
+}
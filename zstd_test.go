@@ -0,0 +1,131 @@
+package go_logger
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/bits"
+	"math/rand"
+	"testing"
+)
+
+func TestZstdCompressor(t *testing.T) {
+
+	compressor, err := compressorByName("zstd")
+	if err != nil || compressor.Extension() != ".zst" {
+		t.Fatalf("zstd compressor must be built in: %v", err)
+	}
+	var logs bytes.Buffer
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 5000; i++ {
+		fmt.Fprintf(&logs, "2024-05-01 12:00:%02d [Info] request id=%d user=%d\n", i%60, random.Intn(100000), random.Intn(50))
+	}
+	noise := make([]byte, 50000)
+	random.Read(noise)
+
+	for _, data := range [][]byte{nil, []byte("a"), bytes.Repeat([]byte("x"), 300000), logs.Bytes(), noise} {
+		compressed, err := compressBytes(compressor, data)
+		if err != nil {
+			t.Fatal(err.Error())
+		}
+		decompressed, err := zstdDecode(compressed)
+		if err != nil || !bytes.Equal(decompressed, data) {
+			t.Errorf("zstd compressor of %d bytes must be decoded: %v", len(data), err)
+		}
+		if len(data) > 0 && len(compressed) > len(data)+9 {
+			t.Errorf("zstd compressor of %d bytes must fall back to raw blocks: %d bytes", len(data), len(compressed))
+		}
+	}
+	if compressed, _ := compressBytes(compressor, logs.Bytes()); len(compressed) > logs.Len()/3 {
+		t.Errorf("zstd compressor of %d bytes of logs: %d bytes", logs.Len(), len(compressed))
+	}
+}
+
+//decode a frame of zstdWriter: raw and compressed blocks, raw literals and predefined sequence codes
+func zstdDecode(data []byte) ([]byte, error) {
+	if len(data) < 6 || binary.LittleEndian.Uint32(data) != zstdMagic || data[4] != 0 {
+		return nil, errors.New("zstd frame header error")
+	}
+	data = data[6:]
+	out := []byte{}
+	for {
+		if len(data) < 3 {
+			return nil, errors.New("zstd block header truncated")
+		}
+		header := uint32(data[0]) | uint32(data[1])<<8 | uint32(data[2])<<16
+		size := int(header >> 3)
+		if len(data) < 3+size {
+			return nil, errors.New("zstd block truncated")
+		}
+		block := data[3 : 3+size]
+		data = data[3+size:]
+		switch header >> 1 & 3 {
+		case 0:
+			out = append(out, block...)
+		case 2:
+			out = zstdDecodeBlock(out, block)
+		default:
+			return nil, errors.New("zstd block type error")
+		}
+		if header&1 == 1 {
+			return out, nil
+		}
+	}
+}
+
+func zstdDecodeBlock(out []byte, block []byte) []byte {
+	size, headerSize := int(block[0]>>3), 1
+	switch block[0] >> 2 & 3 {
+	case 1:
+		size, headerSize = int(block[0]>>4)|int(block[1])<<4, 2
+	case 3:
+		size, headerSize = int(block[0]>>4)|int(block[1])<<4|int(block[2])<<12, 3
+	}
+	literals := block[headerSize : headerSize+size]
+	block = block[headerSize+size:]
+	count := int(block[0])
+	switch {
+	case count == 0xff:
+		count, block = int(block[1])|int(block[2])<<8+0x7f00, block[3:]
+	case count >= 0x80:
+		count, block = (count-0x80)<<8|int(block[1]), block[2:]
+	default:
+		block = block[1:]
+	}
+	block = block[1:]
+
+	// the bitstream is read backward from the 1 bit ending it
+	position := (len(block)-1)*8 + bits.Len8(block[len(block)-1]) - 1
+	read := func(n uint) uint32 {
+		position -= int(n)
+		value := uint32(0)
+		for i := 0; i < int(n); i++ {
+			bit := position + i
+			value |= uint32(block[bit/8]>>uint(bit%8)&1) << uint(i)
+		}
+		return value
+	}
+	llState := read(zstdLiteralLengthsFSE.log)
+	ofState := read(zstdOffsetsFSE.log)
+	mlState := read(zstdMatchLengthsFSE.log)
+	for n := 0; n < count; n++ {
+		ll := zstdLiteralLengthsFSE.states[llState]
+		of := zstdOffsetsFSE.states[ofState]
+		ml := zstdMatchLengthsFSE.states[mlState]
+		offset := int(1<<of.symbol+read(uint(of.symbol))) - 3
+		match := int(zstdMatchLengthCodes[ml.symbol].baseline + read(zstdMatchLengthCodes[ml.symbol].bits))
+		literal := int(zstdLiteralLengthCodes[ll.symbol].baseline + read(zstdLiteralLengthCodes[ll.symbol].bits))
+		out = append(out, literals[:literal]...)
+		literals = literals[literal:]
+		for i := 0; i < match; i++ {
+			out = append(out, out[len(out)-offset])
+		}
+		if n < count-1 {
+			llState = uint32(ll.baseline) + read(uint(ll.bits))
+			mlState = uint32(ml.baseline) + read(uint(ml.bits))
+			ofState = uint32(of.baseline) + read(uint(of.bits))
+		}
+	}
+	return append(out, literals...)
+}